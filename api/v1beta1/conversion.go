@@ -0,0 +1,234 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+// hubDataAnnotation stores the fields of the hub version (v1beta2), that cannot be represented in v1beta1.
+// Without it, a client updating the SSP using v1beta1 would remove them.
+const hubDataAnnotation = "ssp.kubevirt.io/v1beta2-conversion-data"
+
+type hubData struct {
+	Spec   v1beta2.SSPSpec   `json:"spec,omitempty"`
+	Status v1beta2.SSPStatus `json:"status,omitempty"`
+}
+
+// ConvertTo converts this SSP to the hub version (v1beta2).
+// The NodeLabeller field is dropped, because the node-labeller operand does not exist in v1beta2.
+func (src *SSP) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.SSP)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", dstRaw)
+	}
+
+	restored := &hubData{}
+	if data, exists := src.Annotations[hubDataAnnotation]; exists {
+		if err := json.Unmarshal([]byte(data), restored); err != nil {
+			return fmt.Errorf("failed to parse annotation %s: %w", hubDataAnnotation, err)
+		}
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, hubDataAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	dst.Spec = convertSpecToV1beta2(src.Spec.DeepCopy(), &restored.Spec)
+	dst.Status = convertStatusToV1beta2(src.Status.DeepCopy(), &restored.Status)
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta2) to this version.
+func (dst *SSP) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.SSP)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", srcRaw)
+	}
+
+	data, err := json.Marshal(&hubData{
+		Spec:   src.Spec,
+		Status: src.Status,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store v1beta2 fields: %w", err)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[hubDataAnnotation] = string(data)
+	dst.Spec = convertSpecFromV1beta2(src.Spec.DeepCopy())
+	dst.Status = convertStatusFromV1beta2(src.Status.DeepCopy())
+	return nil
+}
+
+// The conversion functions below take ownership of their arguments, so callers pass deep copies.
+// Fields that exist only in v1beta2 are taken from the restored hub data.
+
+func convertSpecToV1beta2(src *SSPSpec, restored *v1beta2.SSPSpec) v1beta2.SSPSpec {
+	dst := *restored
+	dst.TemplateValidator = convertTemplateValidatorToV1beta2(src.TemplateValidator, restored.TemplateValidator)
+	dst.CommonTemplates = convertCommonTemplatesToV1beta2(&src.CommonTemplates, &restored.CommonTemplates)
+	dst.TLSSecurityProfile = src.TLSSecurityProfile
+	dst.CommonInstancetypes = convertInstancetypesToV1beta2(src.CommonInstancetypes, restored.CommonInstancetypes)
+	dst.TektonPipelines = (*v1beta2.TektonPipelines)(src.TektonPipelines)
+	dst.TektonTasks = (*v1beta2.TektonTasks)(src.TektonTasks)
+	dst.FeatureGates = convertFeatureGatesToV1beta2(src.FeatureGates, restored.FeatureGates)
+	return dst
+}
+
+func convertSpecFromV1beta2(src *v1beta2.SSPSpec) SSPSpec {
+	return SSPSpec{
+		TemplateValidator:   convertTemplateValidatorFromV1beta2(src.TemplateValidator),
+		CommonTemplates:     convertCommonTemplatesFromV1beta2(&src.CommonTemplates),
+		TLSSecurityProfile:  src.TLSSecurityProfile,
+		CommonInstancetypes: convertInstancetypesFromV1beta2(src.CommonInstancetypes),
+		TektonPipelines:     (*TektonPipelines)(src.TektonPipelines),
+		TektonTasks:         (*TektonTasks)(src.TektonTasks),
+		FeatureGates:        convertFeatureGatesFromV1beta2(src.FeatureGates),
+	}
+}
+
+func convertTemplateValidatorToV1beta2(src *TemplateValidator, restored *v1beta2.TemplateValidator) *v1beta2.TemplateValidator {
+	if src == nil {
+		return nil
+	}
+	dst := &v1beta2.TemplateValidator{}
+	if restored != nil {
+		dst = restored
+	}
+	dst.Replicas = src.Replicas
+	dst.Placement = src.Placement
+	return dst
+}
+
+func convertTemplateValidatorFromV1beta2(src *v1beta2.TemplateValidator) *TemplateValidator {
+	if src == nil {
+		return nil
+	}
+	return &TemplateValidator{
+		Replicas:  src.Replicas,
+		Placement: src.Placement,
+	}
+}
+
+func convertCommonTemplatesToV1beta2(src *CommonTemplates, restored *v1beta2.CommonTemplates) v1beta2.CommonTemplates {
+	dst := *restored
+	dst.Namespace = src.Namespace
+	dst.DataImportCronTemplates = nil
+	if src.DataImportCronTemplates != nil {
+		dst.DataImportCronTemplates = make([]v1beta2.DataImportCronTemplate, 0, len(src.DataImportCronTemplates))
+		for i := range src.DataImportCronTemplates {
+			template := &src.DataImportCronTemplates[i]
+			converted := v1beta2.DataImportCronTemplate{
+				ObjectMeta: template.ObjectMeta,
+				Spec:       template.Spec,
+			}
+			// The source is kept only if the template was not reordered or renamed using v1beta1
+			if i < len(restored.DataImportCronTemplates) && restored.DataImportCronTemplates[i].Name == template.Name {
+				converted.Source = restored.DataImportCronTemplates[i].Source
+			}
+			dst.DataImportCronTemplates = append(dst.DataImportCronTemplates, converted)
+		}
+	}
+	return dst
+}
+
+func convertCommonTemplatesFromV1beta2(src *v1beta2.CommonTemplates) CommonTemplates {
+	dst := CommonTemplates{
+		Namespace: src.Namespace,
+	}
+	if src.DataImportCronTemplates != nil {
+		dst.DataImportCronTemplates = make([]DataImportCronTemplate, 0, len(src.DataImportCronTemplates))
+		for i := range src.DataImportCronTemplates {
+			template := &src.DataImportCronTemplates[i]
+			dst.DataImportCronTemplates = append(dst.DataImportCronTemplates, DataImportCronTemplate{
+				ObjectMeta: template.ObjectMeta,
+				Spec:       template.Spec,
+			})
+		}
+	}
+	return dst
+}
+
+func convertInstancetypesToV1beta2(src *CommonInstancetypes, restored *v1beta2.CommonInstancetypes) *v1beta2.CommonInstancetypes {
+	if src == nil {
+		return nil
+	}
+	dst := &v1beta2.CommonInstancetypes{}
+	if restored != nil {
+		dst = restored
+	}
+	dst.URL = src.URL
+	return dst
+}
+
+func convertInstancetypesFromV1beta2(src *v1beta2.CommonInstancetypes) *CommonInstancetypes {
+	if src == nil {
+		return nil
+	}
+	return &CommonInstancetypes{
+		URL: src.URL,
+	}
+}
+
+func convertFeatureGatesToV1beta2(src *FeatureGates, restored *v1beta2.FeatureGates) *v1beta2.FeatureGates {
+	if src == nil {
+		return nil
+	}
+	dst := &v1beta2.FeatureGates{}
+	if restored != nil {
+		dst = restored
+	}
+	dst.DeployTektonTaskResources = src.DeployTektonTaskResources
+	return dst
+}
+
+func convertFeatureGatesFromV1beta2(src *v1beta2.FeatureGates) *FeatureGates {
+	if src == nil {
+		return nil
+	}
+	return &FeatureGates{
+		DeployTektonTaskResources: src.DeployTektonTaskResources,
+	}
+}
+
+func convertStatusToV1beta2(src *SSPStatus, restored *v1beta2.SSPStatus) v1beta2.SSPStatus {
+	return v1beta2.SSPStatus{
+		Status:                 src.Status,
+		Paused:                 src.Paused,
+		ObservedGeneration:     src.ObservedGeneration,
+		RecentImports:          restored.RecentImports,
+		DataSourceTemplateRefs: restored.DataSourceTemplateRefs,
+	}
+}
+
+func convertStatusFromV1beta2(src *v1beta2.SSPStatus) SSPStatus {
+	return SSPStatus{
+		Status:             src.Status,
+		Paused:             src.Paused,
+		ObservedGeneration: src.ObservedGeneration,
+	}
+}
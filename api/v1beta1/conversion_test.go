@@ -0,0 +1,134 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+const fuzzIterations = 100
+
+// newFuzzer returns a fuzzer, that generates only values which can be serialized to JSON.
+// Fields of v1beta2 are stored as JSON in an annotation of the v1beta1 object.
+func newFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).NumElements(0, 3).Funcs(
+		func(fields *metav1.FieldsV1, c fuzz.Continue) {
+			fields.Raw = []byte("{}")
+		},
+		func(value *intstr.IntOrString, c fuzz.Continue) {
+			*value = intstr.FromInt(c.Int())
+		},
+	)
+}
+
+func TestRoundTripFromHub(t *testing.T) {
+	fuzzer := newFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1beta2.SSP{}
+		fuzzer.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		converted := &SSP{}
+		if err := converted.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		roundTripped := &v1beta2.SSP{}
+		if err := converted.ConvertTo(roundTripped); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1beta2 SSP changed after round trip through v1beta1:\noriginal: %+v\nround trip: %+v", original, roundTripped)
+		}
+	}
+}
+
+func TestRoundTripToHub(t *testing.T) {
+	fuzzer := newFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &SSP{}
+		fuzzer.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+		// The node-labeller operand does not exist in v1beta2
+		original.Spec.NodeLabeller = nil
+
+		hub := &v1beta2.SSP{}
+		if err := original.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+		roundTripped := &SSP{}
+		if err := roundTripped.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		delete(roundTripped.Annotations, hubDataAnnotation)
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1beta1 SSP changed after round trip through v1beta2:\noriginal: %+v\nround trip: %+v", original, roundTripped)
+		}
+	}
+}
+
+func TestUpdateUsingV1beta1KeepsV1beta2Fields(t *testing.T) {
+	hub := &v1beta2.SSP{
+		Spec: v1beta2.SSPSpec{
+			TemplateValidator: &v1beta2.TemplateValidator{
+				Replicas: pointer.Int32(2),
+				Image:    "registry.example.com/template-validator:v1",
+			},
+			CommonTemplates: v1beta2.CommonTemplates{
+				Namespace:       "templates",
+				CanaryNamespace: "canary",
+				DataImportCronTemplates: []v1beta2.DataImportCronTemplate{{
+					ObjectMeta: metav1.ObjectMeta{Name: "fedora"},
+					Source: &v1beta2.GoldenImageSource{
+						Transport: v1beta2.GoldenImageSourceRegistry,
+						URL:       "docker://quay.io/containerdisks/fedora:latest",
+					},
+				}},
+			},
+			PriorityClassName: "custom-priority",
+		},
+	}
+
+	converted := &SSP{}
+	if err := converted.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+
+	converted.Spec.TemplateValidator.Replicas = pointer.Int32(3)
+	converted.Spec.CommonTemplates.Namespace = "updated-templates"
+
+	updated := &v1beta2.SSP{}
+	if err := converted.ConvertTo(updated); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+
+	expected := hub.DeepCopy()
+	expected.Spec.TemplateValidator.Replicas = pointer.Int32(3)
+	expected.Spec.CommonTemplates.Namespace = "updated-templates"
+	if !equality.Semantic.DeepEqual(expected, updated) {
+		t.Fatalf("v1beta2 fields were not kept after update using v1beta1:\nexpected: %+v\ngot: %+v", expected, updated)
+	}
+}
@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	ocpv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
@@ -25,6 +26,46 @@ import (
 
 const (
 	OperatorPausedAnnotation = "kubevirt.io/operator.paused"
+
+	// PromoteTemplatesAnnotation approves deployment of a common templates version to the
+	// common templates namespace, when a canary namespace is configured. Its value is the approved version.
+	PromoteTemplatesAnnotation = "ssp.kubevirt.io/promote-templates"
+
+	// PinTemplateVersionAnnotation pins common templates to a version embedded in the operator.
+	// The pinned version is deployed instead of the latest version, also after the operator is upgraded.
+	PinTemplateVersionAnnotation = "ssp.kubevirt.io/pin-template-version"
+
+	// TemplateValidatorRestartAnnotation can be set on the SSP CR to restart the template validator pods.
+	// Every change of its value causes a rollout of the template validator deployment.
+	TemplateValidatorRestartAnnotation = "ssp.kubevirt.io/template-validator.restart"
+
+	// OrphanResourcesAcknowledgedAnnotation must be set to "true" on the SSP CR to disable a feature gate,
+	// whose resources are not removed by the operator and have to be cleaned up manually.
+	OrphanResourcesAcknowledgedAnnotation = "ssp.kubevirt.io/orphan-resources-acknowledged"
+
+	// ValidateInstancetypeURLAnnotation can be set to "true" on the SSP CR, so the admission webhook checks
+	// that the ref or version of the commonInstancetypes URL exists in the remote repository.
+	ValidateInstancetypeURLAnnotation = "ssp.kubevirt.io/validate-instancetype-url"
+
+	// ForceDeleteAnnotation must be set to "true" on the SSP CR to delete it, while DataSources
+	// in the golden images namespace are still owned by it. The DataSources are removed with the SSP CR.
+	ForceDeleteAnnotation = "ssp.kubevirt.io/force-delete"
+
+	// InventoryConfigMapAnnotation can be set on the SSP CR to the name of a ConfigMap, where the operator
+	// exports the list of resources managed for the SSP CR. The ConfigMap is created in the namespace
+	// of the SSP CR and it is updated on each reconciliation.
+	InventoryConfigMapAnnotation = "ssp.kubevirt.io/inventory-configmap"
+
+	// ForceRefreshAnnotation can be set on the SSP CR, or on a DataImportCronTemplate, to make CDI check
+	// the source of golden images immediately, instead of waiting for the schedule. A new image is imported,
+	// if the source changed. Every change of its value triggers one check, for example a timestamp can be used.
+	// When set on the SSP CR, it applies to all DataImportCronTemplates.
+	ForceRefreshAnnotation = "ssp.kubevirt.io/force-refresh"
+
+	// DataImportCronTemplateEnableAnnotation can be set to "false" on a DataImportCronTemplate to disable it,
+	// without removing it from the SSP CR. The DataImportCron of a disabled template is not created, or it is removed.
+	// Whether the imported data is kept when the DataImportCron is removed depends on its retentionPolicy.
+	DataImportCronTemplateEnableAnnotation = "ssp.kubevirt.io/enable"
 )
 
 type TemplateValidator struct {
@@ -35,17 +76,97 @@ type TemplateValidator struct {
 
 	// Placement describes the node scheduling configuration
 	Placement *lifecycleapi.NodePlacement `json:"placement,omitempty"`
+
+	// MatchPolicy is the matchPolicy of the template validator webhooks.
+	// Allowed values are "Exact" and "Equivalent". If not set, the API server default is used.
+	//+kubebuilder:validation:Enum=Exact;Equivalent
+	MatchPolicy *string `json:"matchPolicy,omitempty"`
+
+	// SideEffects is the sideEffects declaration of the template validator webhooks.
+	// Allowed values are "None" and "NoneOnDryRun". If not set, "None" is used.
+	//+kubebuilder:validation:Enum=None;NoneOnDryRun
+	SideEffects *string `json:"sideEffects,omitempty"`
+
+	// MetricsRoute is the configuration of a Route exposing the template validator metrics.
+	// The Route is removed when this field is not set.
+	MetricsRoute *MetricsRoute `json:"metricsRoute,omitempty"`
+
+	// Sidecars are additional containers added to the template validator pod.
+	// Names of containers deployed by the operator are reserved and cannot be used.
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// Image is the container image reference of the template validator, for example
+	// "registry.example.com/kubevirt/kubevirt-template-validator:v1.0.0".
+	// It is used as is, ImageRegistryOverride is not applied to it.
+	// If not set, the default image of the operator is used.
+	Image string `json:"image,omitempty"`
+}
+
+// MetricsRoute defines the Route exposing metrics
+type MetricsRoute struct {
+	// Host is the host name of the Route. If not set, it is generated by the cluster.
+	Host string `json:"host,omitempty"`
 }
 
 type CommonTemplates struct {
-	// Namespace is the k8s namespace where CommonTemplates should be installed
+	// Enabled specifies if common templates are managed by the operator. Defaults to true.
+	// If it is false, common templates are not created, updated or removed,
+	// and the Namespace does not have to be set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Namespace is the k8s namespace where CommonTemplates should be installed.
+	// It is required, unless common templates are disabled.
 	//+kubebuilder:validation:MaxLength=63
 	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
-	Namespace string `json:"namespace"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 
 	// DataImportCronTemplates defines a list of DataImportCrons managed by the SSP
 	// Operator. This is intended for images used by CommonTemplates.
 	DataImportCronTemplates []DataImportCronTemplate `json:"dataImportCronTemplates,omitempty"`
+
+	// AdditionalNamespaces is a list of namespaces, where DataSources from the golden images
+	// namespace are replicated. The namespaces must exist.
+	// Replicated DataSources are removed when their namespace is removed from the list.
+	// The maximum number of namespaces is limited by the operator configuration.
+	//+listType=set
+	AdditionalNamespaces []string `json:"additionalNamespaces,omitempty"`
+
+	// CanaryNamespace is a namespace, where a new version of common templates is deployed first.
+	// The new version is deployed to Namespace only after it is approved by setting
+	// the ssp.kubevirt.io/promote-templates annotation on the SSP CR to the new version.
+	// The namespace must exist.
+	// +optional
+	CanaryNamespace string `json:"canaryNamespace,omitempty"`
+
+	// GoldenImagesNamespace is the namespace, where DataImportCrons and DataSources of golden images
+	// are created. The namespace must exist. If it is not set, the kubevirt-os-images namespace
+	// is created and used.
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +optional
+	GoldenImagesNamespace string `json:"goldenImagesNamespace,omitempty"`
+
+	// PauseDataImports pauses reconciliation of DataImportCrons, for example during storage maintenance.
+	// While paused, DataImportCrons are not created, updated or removed. Existing DataImportCrons
+	// keep their current state. Common templates and DataSources are still reconciled.
+	// +optional
+	PauseDataImports *bool `json:"pauseDataImports,omitempty"`
+
+	// IncludedWorkloads is a list of workloads, for example server, desktop or highperformance.
+	// If it is set, only common templates for one of the listed workloads are deployed,
+	// and other common templates are removed. If it is empty, all common templates are deployed.
+	//+listType=set
+	// +optional
+	IncludedWorkloads []string `json:"includedWorkloads,omitempty"`
+
+	// WaitForDataSources specifies if common templates are reported as ready only after
+	// the DataSources referenced by them are ready, so VMs can be created from the templates.
+	// While a DataSource is not ready, the TemplatesUpToDate condition is false and the SSP CR
+	// is not available. Defaults to false.
+	// +optional
+	WaitForDataSources *bool `json:"waitForDataSources,omitempty"`
 }
 
 type CommonInstancetypes struct {
@@ -55,11 +176,24 @@ type CommonInstancetypes struct {
 	//
 	// * Only 'https://' and 'git://' URLs are supported.
 	//
+	// * The URL must not contain credentials. Only a user name without
+	//   a password is allowed for 'ssh://' URLs. To fetch from a private
+	//   repository, use an 'ssh://' URL with the private key in the Secret
+	//   referenced by spec.commonInstancetypes.sshSecretName.
+	//
 	// * The URL must include '?ref=$ref' or '?version=$ref' pinning it to a specific
 	//   reference. It is recommended that the reference be a specific commit or tag
 	//   to ensure the generated contents does not change over time. As such it is
 	//   recommended not to use branches as the ref for the time being.
 	//
+	// * If the ssp.kubevirt.io/validate-instancetype-url annotation is set to "true"
+	//   on the SSP CR, the SSP is rejected if the ref does not exist in the remote repository.
+	//
+	// * Alternatively, an OCI artifact can be referenced by an 'oci://' URL,
+	//   for example 'oci://quay.io/org/common-instancetypes@sha256:$digest'.
+	//   The artifact must be referenced by digest, and its layers must contain
+	//   YAML documents. Only anonymous access to the registry is supported.
+	//
 	// * Only VirtualMachineClusterPreference and VirtualMachineClusterInstancetype
 	//   resources generated from the URL are deployed by the operand.
 	//
@@ -68,6 +202,45 @@ type CommonInstancetypes struct {
 	// remote targets
 	// https://github.com/kubernetes-sigs/kustomize/blob/master/examples/remoteBuild.md
 	URL *string `json:"url,omitempty"`
+
+	// DefaultPreference is a VirtualMachineClusterPreference deployed and kept reconciled
+	// by the operand, in addition to the preferences from the bundle or the URL.
+	// Its name must not be the same as the name of any other preference deployed by the operand.
+	// +optional
+	DefaultPreference *DefaultPreference `json:"defaultPreference,omitempty"`
+
+	// SSHSecretName is the name of a Secret in the namespace of the SSP CR, that contains
+	// the private key used to fetch 'ssh://' URLs in the ssh-privatekey key.
+	// It is required if the URL uses the 'ssh://' scheme.
+	// If the Secret also contains the known_hosts key, the host key of the remote is verified against it.
+	// Otherwise, the host key is not verified.
+	// +optional
+	SSHSecretName string `json:"sshSecretName,omitempty"`
+}
+
+// DefaultPreference defines the VirtualMachineClusterPreference deployed by the common-instancetypes operand.
+// Only a subset of the preference spec can be configured, so the SSP API does not depend
+// on the alpha instancetype API of KubeVirt.
+type DefaultPreference struct {
+	// Name of the VirtualMachineClusterPreference
+	Name string `json:"name"`
+
+	// PreferredCPUTopology is the preferred guest visible CPU topology
+	// +kubebuilder:validation:Enum=preferSockets;preferCores;preferThreads
+	// +optional
+	PreferredCPUTopology string `json:"preferredCPUTopology,omitempty"`
+
+	// PreferredDiskBus is the preferred bus of disk devices, for example virtio or sata
+	// +optional
+	PreferredDiskBus string `json:"preferredDiskBus,omitempty"`
+
+	// PreferredInterfaceModel is the preferred model of network interfaces, for example virtio or e1000e
+	// +optional
+	PreferredInterfaceModel string `json:"preferredInterfaceModel,omitempty"`
+
+	// PreferredMachineType is the preferred machine type, for example q35
+	// +optional
+	PreferredMachineType string `json:"preferredMachineType,omitempty"`
 }
 
 // SSPSpec defines the desired state of SSP
@@ -92,6 +265,51 @@ type SSPSpec struct {
 
 	// FeatureGates is the configuration of the tekton operands
 	FeatureGates *FeatureGates `json:"featureGates,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass used by pods deployed by the operator.
+	// The PriorityClass must exist in the cluster.
+	// If not set, system-cluster-critical is used.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Monitoring is the configuration of the metrics operand
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// ImageRegistryOverride is a registry, optionally with a path, that replaces the registry
+	// of all images of components deployed by the operator. It can be used in disconnected
+	// clusters, where images are mirrored. For example: "registry.example.com:5000/mirror"
+	ImageRegistryOverride string `json:"imageRegistryOverride,omitempty"`
+
+	// ManagedByLabelValue is the value of the "ssp.kubevirt.io/managed-by" label added to all
+	// objects managed by the operator. It can be used by external tooling to identify the objects.
+	// The "app.kubernetes.io/managed-by" label is always set to "ssp-operator".
+	ManagedByLabelValue string `json:"managedByLabelValue,omitempty"`
+
+	// CommonMetadata contains labels and annotations added to all objects managed by the operator.
+	// +optional
+	CommonMetadata *CommonMetadata `json:"commonMetadata,omitempty"`
+}
+
+// CommonMetadata defines labels and annotations added to all objects managed by the operator.
+// Removing a label or an annotation does not remove it from objects, that already have it.
+type CommonMetadata struct {
+	// Labels added to all managed objects. Labels set by the operator are not overwritten.
+	// Keys in the kubevirt.io domain and its subdomains are reserved and cannot be used.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to all managed objects. Annotations set by the operator are not overwritten.
+	// Keys in the kubevirt.io domain and its subdomains are reserved and cannot be used.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Monitoring defines the configuration of the monitoring resources
+type Monitoring struct {
+	// ServiceMonitorLabels are additional labels set on the generated ServiceMonitor.
+	// They can be used to match the serviceMonitorSelector of a Prometheus instance,
+	// for example the "release" label used by Prometheus Operator deployments.
+	// Labels set by the operator take precedence.
+	ServiceMonitorLabels map[string]string `json:"serviceMonitorLabels,omitempty"`
 }
 
 // TektonPipelines defines the desired state of pipelines
@@ -107,6 +325,10 @@ type TektonTasks struct {
 // FeatureGates defines feature gate for tto operator
 type FeatureGates struct {
 	DeployTektonTaskResources bool `json:"deployTektonTaskResources,omitempty"`
+
+	// ExportCommonInstancetypes enables export of the applied common-instancetypes
+	// manifests to a ConfigMap, which can be synced to other clusters by external tooling.
+	ExportCommonInstancetypes bool `json:"exportCommonInstancetypes,omitempty"`
 }
 
 // DataImportCronTemplate defines the template type for DataImportCrons.
@@ -115,13 +337,72 @@ type DataImportCronTemplate struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Spec cdiv1beta1.DataImportCronSpec `json:"spec"`
+
+	// Source selects the transport and location of the golden image.
+	// If set, it is mapped to the source of the DataVolume template in spec,
+	// which must not be set then.
+	// +optional
+	Source *GoldenImageSource `json:"source,omitempty"`
+}
+
+// GoldenImageSourceTransport is the transport used to import a golden image.
+// DataImportCrons in CDI only poll container registries, so other transports, like http, are not supported.
+// +kubebuilder:validation:Enum=registry
+type GoldenImageSourceTransport string
+
+const (
+	// GoldenImageSourceRegistry imports the golden image from a container registry
+	GoldenImageSourceRegistry GoldenImageSourceTransport = "registry"
+)
+
+// GoldenImageSource defines where a golden image is imported from
+type GoldenImageSource struct {
+	// Transport is the transport used to import the image
+	Transport GoldenImageSourceTransport `json:"transport"`
+
+	// URL of the image. For the registry transport, it must start with docker:// or oci-archive://.
+	URL string `json:"url"`
+
+	// SecretRef is the name of a Secret with credentials needed to access the image
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// CertConfigMap is the name of a ConfigMap with the CA certificates of the image source
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
 }
 
 // AsDataImportCron converts the DataImportCronTemplate to a cdiv1beta1.DataImportCron
 func (t *DataImportCronTemplate) AsDataImportCron() cdiv1beta1.DataImportCron {
+	spec := *t.Spec.DeepCopy()
+	if t.Source != nil {
+		spec.Template.Spec.Source = t.Source.asDataVolumeSource()
+	}
 	return cdiv1beta1.DataImportCron{
 		ObjectMeta: t.ObjectMeta,
-		Spec:       t.Spec,
+		Spec:       spec,
+	}
+}
+
+func (s *GoldenImageSource) asDataVolumeSource() *cdiv1beta1.DataVolumeSource {
+	switch s.Transport {
+	case GoldenImageSourceRegistry:
+		// Copy the values, so the returned source does not point into the template
+		url, secretRef, certConfigMap := s.URL, s.SecretRef, s.CertConfigMap
+		source := &cdiv1beta1.DataVolumeSourceRegistry{
+			URL: &url,
+		}
+		if secretRef != "" {
+			source.SecretRef = &secretRef
+		}
+		if certConfigMap != "" {
+			source.CertConfigMap = &certConfigMap
+		}
+		return &cdiv1beta1.DataVolumeSource{
+			Registry: source,
+		}
+	default:
+		return nil
 	}
 }
 
@@ -134,6 +415,43 @@ type SSPStatus struct {
 
 	// ObservedGeneration is the latest generation observed by the operator.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// RecentImports are the most recent results of golden image imports by DataImportCrons
+	// managed by the operator, sorted from the newest. At most MaxRecentImports entries are kept.
+	RecentImports []ImportHistoryEntry `json:"recentImports,omitempty"`
+
+	// DataSourceTemplateRefs maps the name of each DataSource referenced by common templates
+	// to the sorted names of the common templates that reference it.
+	DataSourceTemplateRefs map[string][]string `json:"dataSourceTemplateRefs,omitempty"`
+}
+
+// MaxRecentImports is the maximum number of entries in SSPStatus.RecentImports
+const MaxRecentImports = 10
+
+// ImportResult is the result of a golden image import
+type ImportResult string
+
+const (
+	ImportSucceeded ImportResult = "Succeeded"
+	ImportFailed    ImportResult = "Failed"
+)
+
+// ImportHistoryEntry is the result of a golden image import observed on a DataImportCron
+type ImportHistoryEntry struct {
+	// Name is the name of the DataImportCron
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the DataImportCron
+	Namespace string `json:"namespace"`
+
+	// Result is the result of the import
+	Result ImportResult `json:"result"`
+
+	// Time is when the import finished
+	Time metav1.Time `json:"time"`
+
+	// Message describes the failure of the import
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -22,7 +22,8 @@ limitations under the License.
 package v1beta2
 
 import (
-	"github.com/openshift/api/config/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -34,6 +35,11 @@ func (in *CommonInstancetypes) DeepCopyInto(out *CommonInstancetypes) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DefaultPreference != nil {
+		in, out := &in.DefaultPreference, &out.DefaultPreference
+		*out = new(DefaultPreference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonInstancetypes.
@@ -46,9 +52,43 @@ func (in *CommonInstancetypes) DeepCopy() *CommonInstancetypes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonMetadata) DeepCopyInto(out *CommonMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonMetadata.
+func (in *CommonMetadata) DeepCopy() *CommonMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonTemplates) DeepCopyInto(out *CommonTemplates) {
 	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DataImportCronTemplates != nil {
 		in, out := &in.DataImportCronTemplates, &out.DataImportCronTemplates
 		*out = make([]DataImportCronTemplate, len(*in))
@@ -56,6 +96,26 @@ func (in *CommonTemplates) DeepCopyInto(out *CommonTemplates) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AdditionalNamespaces != nil {
+		in, out := &in.AdditionalNamespaces, &out.AdditionalNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseDataImports != nil {
+		in, out := &in.PauseDataImports, &out.PauseDataImports
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludedWorkloads != nil {
+		in, out := &in.IncludedWorkloads, &out.IncludedWorkloads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WaitForDataSources != nil {
+		in, out := &in.WaitForDataSources, &out.WaitForDataSources
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonTemplates.
@@ -73,6 +133,11 @@ func (in *DataImportCronTemplate) DeepCopyInto(out *DataImportCronTemplate) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(GoldenImageSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronTemplate.
@@ -85,6 +150,21 @@ func (in *DataImportCronTemplate) DeepCopy() *DataImportCronTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultPreference) DeepCopyInto(out *DefaultPreference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultPreference.
+func (in *DefaultPreference) DeepCopy() *DefaultPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGates) DeepCopyInto(out *FeatureGates) {
 	*out = *in
@@ -100,6 +180,74 @@ func (in *FeatureGates) DeepCopy() *FeatureGates {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageSource) DeepCopyInto(out *GoldenImageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageSource.
+func (in *GoldenImageSource) DeepCopy() *GoldenImageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportHistoryEntry) DeepCopyInto(out *ImportHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportHistoryEntry.
+func (in *ImportHistoryEntry) DeepCopy() *ImportHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsRoute) DeepCopyInto(out *MetricsRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsRoute.
+func (in *MetricsRoute) DeepCopy() *MetricsRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.ServiceMonitorLabels != nil {
+		in, out := &in.ServiceMonitorLabels, &out.ServiceMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSP) DeepCopyInto(out *SSP) {
 	*out = *in
@@ -170,7 +318,7 @@ func (in *SSPSpec) DeepCopyInto(out *SSPSpec) {
 	in.CommonTemplates.DeepCopyInto(&out.CommonTemplates)
 	if in.TLSSecurityProfile != nil {
 		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
-		*out = new(v1.TLSSecurityProfile)
+		*out = new(configv1.TLSSecurityProfile)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CommonInstancetypes != nil {
@@ -193,6 +341,16 @@ func (in *SSPSpec) DeepCopyInto(out *SSPSpec) {
 		*out = new(FeatureGates)
 		**out = **in
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommonMetadata != nil {
+		in, out := &in.CommonMetadata, &out.CommonMetadata
+		*out = new(CommonMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSPSpec.
@@ -209,6 +367,28 @@ func (in *SSPSpec) DeepCopy() *SSPSpec {
 func (in *SSPStatus) DeepCopyInto(out *SSPStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.RecentImports != nil {
+		in, out := &in.RecentImports, &out.RecentImports
+		*out = make([]ImportHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataSourceTemplateRefs != nil {
+		in, out := &in.DataSourceTemplateRefs, &out.DataSourceTemplateRefs
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSPStatus.
@@ -263,6 +443,28 @@ func (in *TemplateValidator) DeepCopyInto(out *TemplateValidator) {
 		in, out := &in.Placement, &out.Placement
 		*out = (*in).DeepCopy()
 	}
+	if in.MatchPolicy != nil {
+		in, out := &in.MatchPolicy, &out.MatchPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.SideEffects != nil {
+		in, out := &in.SideEffects, &out.SideEffects
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricsRoute != nil {
+		in, out := &in.MetricsRoute, &out.MetricsRoute
+		*out = new(MetricsRoute)
+		**out = **in
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateValidator.
@@ -0,0 +1,258 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+// ConvertTo converts this SSP to the hub version (v1beta2).
+func (src *SSP) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.SSP)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecToV1beta2(src.Spec.DeepCopy())
+	dst.Status = convertStatusToV1beta2(src.Status.DeepCopy())
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta2) to this version.
+func (dst *SSP) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.SSP)
+	if !ok {
+		return fmt.Errorf("unsupported conversion hub type: %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = convertSpecFromV1beta2(src.Spec.DeepCopy())
+	dst.Status = convertStatusFromV1beta2(src.Status.DeepCopy())
+	return nil
+}
+
+// The conversion functions below take ownership of their argument, so callers pass a deep copy.
+
+func convertSpecToV1beta2(src *SSPSpec) v1beta2.SSPSpec {
+	return v1beta2.SSPSpec{
+		TemplateValidator:     convertTemplateValidatorToV1beta2(src.TemplateValidator),
+		CommonTemplates:       convertCommonTemplatesToV1beta2(&src.CommonTemplates),
+		TLSSecurityProfile:    src.TLSSecurityProfile,
+		CommonInstancetypes:   convertInstancetypesToV1beta2(src.Instancetypes),
+		TektonPipelines:       (*v1beta2.TektonPipelines)(src.TektonPipelines),
+		TektonTasks:           (*v1beta2.TektonTasks)(src.TektonTasks),
+		FeatureGates:          (*v1beta2.FeatureGates)(src.FeatureGates),
+		PriorityClassName:     src.PriorityClassName,
+		Monitoring:            (*v1beta2.Monitoring)(src.Monitoring),
+		ImageRegistryOverride: src.ImageRegistryOverride,
+		ManagedByLabelValue:   src.ManagedByLabelValue,
+		CommonMetadata:        (*v1beta2.CommonMetadata)(src.CommonMetadata),
+	}
+}
+
+func convertSpecFromV1beta2(src *v1beta2.SSPSpec) SSPSpec {
+	return SSPSpec{
+		TemplateValidator:     convertTemplateValidatorFromV1beta2(src.TemplateValidator),
+		CommonTemplates:       convertCommonTemplatesFromV1beta2(&src.CommonTemplates),
+		TLSSecurityProfile:    src.TLSSecurityProfile,
+		Instancetypes:         convertInstancetypesFromV1beta2(src.CommonInstancetypes),
+		TektonPipelines:       (*TektonPipelines)(src.TektonPipelines),
+		TektonTasks:           (*TektonTasks)(src.TektonTasks),
+		FeatureGates:          (*FeatureGates)(src.FeatureGates),
+		PriorityClassName:     src.PriorityClassName,
+		Monitoring:            (*Monitoring)(src.Monitoring),
+		ImageRegistryOverride: src.ImageRegistryOverride,
+		ManagedByLabelValue:   src.ManagedByLabelValue,
+		CommonMetadata:        (*CommonMetadata)(src.CommonMetadata),
+	}
+}
+
+func convertTemplateValidatorToV1beta2(src *TemplateValidator) *v1beta2.TemplateValidator {
+	if src == nil {
+		return nil
+	}
+	return &v1beta2.TemplateValidator{
+		Replicas:     src.Replicas,
+		Placement:    src.Placement,
+		MatchPolicy:  src.MatchPolicy,
+		SideEffects:  src.SideEffects,
+		MetricsRoute: (*v1beta2.MetricsRoute)(src.MetricsRoute),
+		Sidecars:     src.Sidecars,
+		Image:        src.Image,
+	}
+}
+
+func convertTemplateValidatorFromV1beta2(src *v1beta2.TemplateValidator) *TemplateValidator {
+	if src == nil {
+		return nil
+	}
+	return &TemplateValidator{
+		Replicas:     src.Replicas,
+		Placement:    src.Placement,
+		MatchPolicy:  src.MatchPolicy,
+		SideEffects:  src.SideEffects,
+		MetricsRoute: (*MetricsRoute)(src.MetricsRoute),
+		Sidecars:     src.Sidecars,
+		Image:        src.Image,
+	}
+}
+
+func convertCommonTemplatesToV1beta2(src *CommonTemplates) v1beta2.CommonTemplates {
+	dst := v1beta2.CommonTemplates{
+		Namespace:             src.Namespace,
+		AdditionalNamespaces:  src.AdditionalNamespaces,
+		CanaryNamespace:       src.CanaryNamespace,
+		GoldenImagesNamespace: src.GoldenImagesNamespace,
+		PauseDataImports:      src.PauseDataImports,
+		IncludedWorkloads:     src.IncludedWorkloads,
+		Enabled:               src.Enabled,
+		WaitForDataSources:    src.WaitForDataSources,
+	}
+	if src.DataImportCronTemplates != nil {
+		dst.DataImportCronTemplates = make([]v1beta2.DataImportCronTemplate, 0, len(src.DataImportCronTemplates))
+		for i := range src.DataImportCronTemplates {
+			template := &src.DataImportCronTemplates[i]
+			dst.DataImportCronTemplates = append(dst.DataImportCronTemplates, v1beta2.DataImportCronTemplate{
+				ObjectMeta: template.ObjectMeta,
+				Spec:       template.Spec,
+				Source:     convertGoldenImageSourceToV1beta2(template.Source),
+			})
+		}
+	}
+	return dst
+}
+
+func convertCommonTemplatesFromV1beta2(src *v1beta2.CommonTemplates) CommonTemplates {
+	dst := CommonTemplates{
+		Namespace:             src.Namespace,
+		AdditionalNamespaces:  src.AdditionalNamespaces,
+		CanaryNamespace:       src.CanaryNamespace,
+		GoldenImagesNamespace: src.GoldenImagesNamespace,
+		PauseDataImports:      src.PauseDataImports,
+		IncludedWorkloads:     src.IncludedWorkloads,
+		Enabled:               src.Enabled,
+		WaitForDataSources:    src.WaitForDataSources,
+	}
+	if src.DataImportCronTemplates != nil {
+		dst.DataImportCronTemplates = make([]DataImportCronTemplate, 0, len(src.DataImportCronTemplates))
+		for i := range src.DataImportCronTemplates {
+			template := &src.DataImportCronTemplates[i]
+			dst.DataImportCronTemplates = append(dst.DataImportCronTemplates, DataImportCronTemplate{
+				ObjectMeta: template.ObjectMeta,
+				Spec:       template.Spec,
+				Source:     convertGoldenImageSourceFromV1beta2(template.Source),
+			})
+		}
+	}
+	return dst
+}
+
+func convertGoldenImageSourceToV1beta2(src *GoldenImageSource) *v1beta2.GoldenImageSource {
+	if src == nil {
+		return nil
+	}
+	return &v1beta2.GoldenImageSource{
+		Transport:     v1beta2.GoldenImageSourceTransport(src.Transport),
+		URL:           src.URL,
+		SecretRef:     src.SecretRef,
+		CertConfigMap: src.CertConfigMap,
+	}
+}
+
+func convertGoldenImageSourceFromV1beta2(src *v1beta2.GoldenImageSource) *GoldenImageSource {
+	if src == nil {
+		return nil
+	}
+	return &GoldenImageSource{
+		Transport:     GoldenImageSourceTransport(src.Transport),
+		URL:           src.URL,
+		SecretRef:     src.SecretRef,
+		CertConfigMap: src.CertConfigMap,
+	}
+}
+
+// The URL is copied verbatim, so the ?ref= or ?version= query that pins it to a reference is preserved.
+func convertInstancetypesToV1beta2(src *Instancetypes) *v1beta2.CommonInstancetypes {
+	if src == nil {
+		return nil
+	}
+	return &v1beta2.CommonInstancetypes{
+		URL:               src.BundleURL,
+		DefaultPreference: (*v1beta2.DefaultPreference)(src.DefaultPreference),
+		SSHSecretName:     src.SSHSecretName,
+	}
+}
+
+func convertInstancetypesFromV1beta2(src *v1beta2.CommonInstancetypes) *Instancetypes {
+	if src == nil {
+		return nil
+	}
+	return &Instancetypes{
+		BundleURL:         src.URL,
+		DefaultPreference: (*DefaultPreference)(src.DefaultPreference),
+		SSHSecretName:     src.SSHSecretName,
+	}
+}
+
+func convertStatusToV1beta2(src *SSPStatus) v1beta2.SSPStatus {
+	dst := v1beta2.SSPStatus{
+		Status:                 src.Status,
+		Paused:                 src.Paused,
+		ObservedGeneration:     src.ObservedGeneration,
+		DataSourceTemplateRefs: src.DataSourceTemplateRefs,
+	}
+	if src.RecentImports != nil {
+		dst.RecentImports = make([]v1beta2.ImportHistoryEntry, 0, len(src.RecentImports))
+		for _, entry := range src.RecentImports {
+			dst.RecentImports = append(dst.RecentImports, v1beta2.ImportHistoryEntry{
+				Name:      entry.Name,
+				Namespace: entry.Namespace,
+				Result:    v1beta2.ImportResult(entry.Result),
+				Time:      entry.Time,
+				Message:   entry.Message,
+			})
+		}
+	}
+	return dst
+}
+
+func convertStatusFromV1beta2(src *v1beta2.SSPStatus) SSPStatus {
+	dst := SSPStatus{
+		Status:                 src.Status,
+		Paused:                 src.Paused,
+		ObservedGeneration:     src.ObservedGeneration,
+		DataSourceTemplateRefs: src.DataSourceTemplateRefs,
+	}
+	if src.RecentImports != nil {
+		dst.RecentImports = make([]ImportHistoryEntry, 0, len(src.RecentImports))
+		for _, entry := range src.RecentImports {
+			dst.RecentImports = append(dst.RecentImports, ImportHistoryEntry{
+				Name:      entry.Name,
+				Namespace: entry.Namespace,
+				Result:    ImportResult(entry.Result),
+				Time:      entry.Time,
+				Message:   entry.Message,
+			})
+		}
+	}
+	return dst
+}
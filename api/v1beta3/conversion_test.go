@@ -0,0 +1,108 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta3
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+const fuzzIterations = 100
+
+func TestRoundTripFromHub(t *testing.T) {
+	fuzzer := fuzz.New().NilChance(0.2).NumElements(0, 3)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1beta2.SSP{}
+		fuzzer.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		converted := &SSP{}
+		if err := converted.ConvertFrom(original.DeepCopy()); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		roundTripped := &v1beta2.SSP{}
+		if err := converted.ConvertTo(roundTripped); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1beta2 SSP changed after round trip through v1beta3:\noriginal: %+v\nround trip: %+v", original, roundTripped)
+		}
+	}
+}
+
+func TestRoundTripToHub(t *testing.T) {
+	fuzzer := fuzz.New().NilChance(0.2).NumElements(0, 3)
+	for i := 0; i < fuzzIterations; i++ {
+		original := &SSP{}
+		fuzzer.Fuzz(original)
+		original.TypeMeta = metav1.TypeMeta{}
+
+		hub := &v1beta2.SSP{}
+		if err := original.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+		roundTripped := &SSP{}
+		if err := roundTripped.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+
+		if !equality.Semantic.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1beta3 SSP changed after round trip through v1beta2:\noriginal: %+v\nround trip: %+v", original, roundTripped)
+		}
+	}
+}
+
+func TestConvertInstancetypesURL(t *testing.T) {
+	urls := []string{
+		"https://github.com/kubevirt/common-instancetypes/VirtualMachineClusterInstancetypes?ref=v0.2.0",
+		"https://github.com/kubevirt/common-instancetypes/VirtualMachineClusterInstancetypes?version=v0.2.0",
+		"git://github.com/kubevirt/common-instancetypes?ref=0123456789abcdef&timeout=90s",
+		"ssh://git@github.com/kubevirt/common-instancetypes.git?version=v0.2.0",
+	}
+	for _, url := range urls {
+		hub := &v1beta2.SSP{
+			Spec: v1beta2.SSPSpec{
+				CommonInstancetypes: &v1beta2.CommonInstancetypes{
+					URL: pointer.String(url),
+				},
+			},
+		}
+
+		converted := &SSP{}
+		if err := converted.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom failed: %v", err)
+		}
+		if converted.Spec.Instancetypes == nil || pointer.StringDeref(converted.Spec.Instancetypes.BundleURL, "") != url {
+			t.Fatalf("expected v1beta3 bundleURL %q, got: %+v", url, converted.Spec.Instancetypes)
+		}
+
+		roundTripped := &v1beta2.SSP{}
+		if err := converted.ConvertTo(roundTripped); err != nil {
+			t.Fatalf("ConvertTo failed: %v", err)
+		}
+		if roundTripped.Spec.CommonInstancetypes == nil || pointer.StringDeref(roundTripped.Spec.CommonInstancetypes.URL, "") != url {
+			t.Fatalf("expected v1beta2 url %q, got: %+v", url, roundTripped.Spec.CommonInstancetypes)
+		}
+	}
+}
@@ -0,0 +1,478 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta3
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonMetadata) DeepCopyInto(out *CommonMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonMetadata.
+func (in *CommonMetadata) DeepCopy() *CommonMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonTemplates) DeepCopyInto(out *CommonTemplates) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DataImportCronTemplates != nil {
+		in, out := &in.DataImportCronTemplates, &out.DataImportCronTemplates
+		*out = make([]DataImportCronTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalNamespaces != nil {
+		in, out := &in.AdditionalNamespaces, &out.AdditionalNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PauseDataImports != nil {
+		in, out := &in.PauseDataImports, &out.PauseDataImports
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludedWorkloads != nil {
+		in, out := &in.IncludedWorkloads, &out.IncludedWorkloads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WaitForDataSources != nil {
+		in, out := &in.WaitForDataSources, &out.WaitForDataSources
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonTemplates.
+func (in *CommonTemplates) DeepCopy() *CommonTemplates {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonTemplates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImportCronTemplate) DeepCopyInto(out *DataImportCronTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(GoldenImageSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImportCronTemplate.
+func (in *DataImportCronTemplate) DeepCopy() *DataImportCronTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImportCronTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultPreference) DeepCopyInto(out *DefaultPreference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultPreference.
+func (in *DefaultPreference) DeepCopy() *DefaultPreference {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultPreference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureGates) DeepCopyInto(out *FeatureGates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGates.
+func (in *FeatureGates) DeepCopy() *FeatureGates {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GoldenImageSource) DeepCopyInto(out *GoldenImageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoldenImageSource.
+func (in *GoldenImageSource) DeepCopy() *GoldenImageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GoldenImageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportHistoryEntry) DeepCopyInto(out *ImportHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportHistoryEntry.
+func (in *ImportHistoryEntry) DeepCopy() *ImportHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Instancetypes) DeepCopyInto(out *Instancetypes) {
+	*out = *in
+	if in.BundleURL != nil {
+		in, out := &in.BundleURL, &out.BundleURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultPreference != nil {
+		in, out := &in.DefaultPreference, &out.DefaultPreference
+		*out = new(DefaultPreference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instancetypes.
+func (in *Instancetypes) DeepCopy() *Instancetypes {
+	if in == nil {
+		return nil
+	}
+	out := new(Instancetypes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsRoute) DeepCopyInto(out *MetricsRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsRoute.
+func (in *MetricsRoute) DeepCopy() *MetricsRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.ServiceMonitorLabels != nil {
+		in, out := &in.ServiceMonitorLabels, &out.ServiceMonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSP) DeepCopyInto(out *SSP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSP.
+func (in *SSP) DeepCopy() *SSP {
+	if in == nil {
+		return nil
+	}
+	out := new(SSP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSPList) DeepCopyInto(out *SSPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSPList.
+func (in *SSPList) DeepCopy() *SSPList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSPSpec) DeepCopyInto(out *SSPSpec) {
+	*out = *in
+	if in.TemplateValidator != nil {
+		in, out := &in.TemplateValidator, &out.TemplateValidator
+		*out = new(TemplateValidator)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonTemplates.DeepCopyInto(&out.CommonTemplates)
+	if in.TLSSecurityProfile != nil {
+		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
+		*out = new(configv1.TLSSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Instancetypes != nil {
+		in, out := &in.Instancetypes, &out.Instancetypes
+		*out = new(Instancetypes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TektonPipelines != nil {
+		in, out := &in.TektonPipelines, &out.TektonPipelines
+		*out = new(TektonPipelines)
+		**out = **in
+	}
+	if in.TektonTasks != nil {
+		in, out := &in.TektonTasks, &out.TektonTasks
+		*out = new(TektonTasks)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = new(FeatureGates)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommonMetadata != nil {
+		in, out := &in.CommonMetadata, &out.CommonMetadata
+		*out = new(CommonMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSPSpec.
+func (in *SSPSpec) DeepCopy() *SSPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSPStatus) DeepCopyInto(out *SSPStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.RecentImports != nil {
+		in, out := &in.RecentImports, &out.RecentImports
+		*out = make([]ImportHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataSourceTemplateRefs != nil {
+		in, out := &in.DataSourceTemplateRefs, &out.DataSourceTemplateRefs
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSPStatus.
+func (in *SSPStatus) DeepCopy() *SSPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonPipelines) DeepCopyInto(out *TektonPipelines) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonPipelines.
+func (in *TektonPipelines) DeepCopy() *TektonPipelines {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonPipelines)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TektonTasks) DeepCopyInto(out *TektonTasks) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TektonTasks.
+func (in *TektonTasks) DeepCopy() *TektonTasks {
+	if in == nil {
+		return nil
+	}
+	out := new(TektonTasks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateValidator) DeepCopyInto(out *TemplateValidator) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = (*in).DeepCopy()
+	}
+	if in.MatchPolicy != nil {
+		in, out := &in.MatchPolicy, &out.MatchPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.SideEffects != nil {
+		in, out := &in.SideEffects, &out.SideEffects
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricsRoute != nil {
+		in, out := &in.MetricsRoute, &out.MetricsRoute
+		*out = new(MetricsRoute)
+		**out = **in
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateValidator.
+func (in *TemplateValidator) DeepCopy() *TemplateValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateValidator)
+	in.DeepCopyInto(out)
+	return out
+}
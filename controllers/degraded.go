@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"time"
+)
+
+// degradedTracker remembers since when operands are failing. With a grace period configured,
+// the Degraded condition is set only for operands that keep failing for the whole grace period,
+// so short transient failures do not trigger alerts.
+type degradedTracker struct {
+	failingSince map[string]time.Time
+	now          func() time.Time
+}
+
+func newDegradedTracker() *degradedTracker {
+	return &degradedTracker{
+		failingSince: map[string]time.Time{},
+		now:          time.Now,
+	}
+}
+
+// markFailing records that the operand is failing and returns the remaining time of its grace period.
+// Zero is returned when the operand has been failing for at least the whole grace period.
+func (t *degradedTracker) markFailing(operandName string, gracePeriod time.Duration) time.Duration {
+	now := t.now()
+	since, found := t.failingSince[operandName]
+	if !found {
+		since = now
+		t.failingSince[operandName] = since
+	}
+
+	remaining := since.Add(gracePeriod).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// markHealthy forgets that the operand was failing, so its grace period starts again on the next failure
+func (t *degradedTracker) markHealthy(operandName string) {
+	delete(t.failingSince, operandName)
+}
+
+// operandError is returned when reconciliation of an operand fails
+type operandError struct {
+	operandName string
+	err         error
+}
+
+func (e *operandError) Error() string {
+	return e.err.Error()
+}
+
+func (e *operandError) Unwrap() error {
+	return e.err
+}
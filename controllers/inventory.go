@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+const (
+	// InventoryDataKey is the key in the inventory ConfigMap, that contains the list of managed resources
+	InventoryDataKey = "inventory.json"
+
+	inventoryLabel = "ssp.kubevirt.io/inventory"
+)
+
+type inventoryEntry struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// reconcileInventory exports the resources managed by the operands to the ConfigMap
+// requested by the annotation on the SSP CR. Inventory ConfigMaps that are not requested anymore are removed.
+func reconcileInventory(request *common.Request, operandResults []operandReconcileResults) error {
+	configMapName := request.Instance.GetAnnotations()[ssp.InventoryConfigMapAnnotation]
+	if err := removeUnusedInventories(request, configMapName); err != nil {
+		return err
+	}
+	if configMapName == "" {
+		return nil
+	}
+
+	inventory, err := newInventory(request, operandResults)
+	if err != nil {
+		return err
+	}
+	inventoryJSON, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = common.CreateOrUpdate(request).
+		NamespacedResource(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: request.Instance.Namespace,
+				Labels: map[string]string{
+					inventoryLabel: "true",
+				},
+			},
+			Data: map[string]string{
+				InventoryDataKey: string(inventoryJSON),
+			},
+		}).
+		Options(common.ReconcileOptions{AlwaysCallUpdateFunc: true}).
+		Reconcile()
+	if err != nil {
+		return fmt.Errorf("failed to update inventory ConfigMap %s: %w", configMapName, err)
+	}
+	return nil
+}
+
+// newInventory returns sorted kinds, namespaces and names of the reconciled resources. Removed resources are skipped.
+func newInventory(request *common.Request, operandResults []operandReconcileResults) ([]inventoryEntry, error) {
+	var inventory []inventoryEntry
+	for _, operandResult := range operandResults {
+		for _, reconcileResult := range operandResult.results {
+			if reconcileResult.Resource == nil || reconcileResult.OperationResult == common.OperationResultDeleted {
+				continue
+			}
+			gvk, err := apiutil.GVKForObject(reconcileResult.Resource, request.Client.Scheme())
+			if err != nil {
+				return nil, err
+			}
+			inventory = append(inventory, inventoryEntry{
+				Kind:      gvk.Kind,
+				Namespace: reconcileResult.Resource.GetNamespace(),
+				Name:      reconcileResult.Resource.GetName(),
+			})
+		}
+	}
+
+	sort.Slice(inventory, func(i, j int) bool {
+		if inventory[i].Kind != inventory[j].Kind {
+			return inventory[i].Kind < inventory[j].Kind
+		}
+		if inventory[i].Namespace != inventory[j].Namespace {
+			return inventory[i].Namespace < inventory[j].Namespace
+		}
+		return inventory[i].Name < inventory[j].Name
+	})
+	return inventory, nil
+}
+
+func removeUnusedInventories(request *common.Request, configMapName string) error {
+	configMaps := &v1.ConfigMapList{}
+	err := request.Client.List(request.Context, configMaps,
+		client.InNamespace(request.Instance.Namespace),
+		client.MatchingLabels{inventoryLabel: "true"})
+	if err != nil {
+		return err
+	}
+
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if configMap.Name == configMapName {
+			continue
+		}
+		// Cleanup removes the ConfigMap only if it is owned by the SSP CR
+		if _, err := common.Cleanup(request, configMap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"kubevirt.io/ssp-operator/internal/common"
+	"kubevirt.io/ssp-operator/internal/operands"
+	common_instancetypes "kubevirt.io/ssp-operator/internal/operands/common-instancetypes"
+	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
+	data_sources "kubevirt.io/ssp-operator/internal/operands/data-sources"
+	"kubevirt.io/ssp-operator/internal/operands/metrics"
+	operator_config "kubevirt.io/ssp-operator/internal/operands/operator-config"
+	tekton_pipelines "kubevirt.io/ssp-operator/internal/operands/tekton-pipelines"
+	tekton_tasks "kubevirt.io/ssp-operator/internal/operands/tekton-tasks"
+	template_validator "kubevirt.io/ssp-operator/internal/operands/template-validator"
+	vm_console_proxy "kubevirt.io/ssp-operator/internal/operands/vm-console-proxy"
+	tekton_bundle "kubevirt.io/ssp-operator/internal/tekton-bundle"
+	vm_console_proxy_bundle "kubevirt.io/ssp-operator/internal/vm-console-proxy-bundle"
+)
+
+// managedObjectVerbs are needed to create, update and remove objects of kinds managed by operands.
+// They match the verbs of the kubebuilder RBAC markers of the operands.
+var managedObjectVerbs = []string{"create", "delete", "get", "list", "patch", "update", "watch"}
+
+// readOnlyVerbs are needed to read and watch objects
+var readOnlyVerbs = []string{"get", "list", "watch"}
+
+// operatorRules are needed by the operator itself and its webhook, independently of operands
+var operatorRules = []rbac.PolicyRule{{
+	APIGroups: []string{""},
+	Resources: []string{"events"},
+	Verbs:     []string{"create", "patch"},
+}, {
+	APIGroups: []string{""},
+	Resources: []string{"nodes"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"apiextensions.k8s.io"},
+	Resources: []string{"customresourcedefinitions"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"config.openshift.io"},
+	Resources: []string{"clusterversions", "infrastructures"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"cdi.kubevirt.io"},
+	Resources: []string{"storageprofiles"},
+	Verbs:     readOnlyVerbs,
+}, {
+	// The webhook lists PodDisruptionBudgets protecting template validator pods
+	APIGroups: []string{"policy"},
+	Resources: []string{"poddisruptionbudgets"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"scheduling.k8s.io"},
+	Resources: []string{"priorityclasses"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"ssp.kubevirt.io"},
+	Resources: []string{"ssps"},
+	Verbs:     managedObjectVerbs,
+}, {
+	APIGroups: []string{"ssp.kubevirt.io"},
+	Resources: []string{"ssps/status"},
+	Verbs:     []string{"get", "patch", "update"},
+}, {
+	APIGroups: []string{"ssp.kubevirt.io"},
+	Resources: []string{"ssps/finalizers"},
+	Verbs:     []string{"update"},
+}, {
+	// CRs of the old operators are paused and removed during upgrade
+	APIGroups: []string{"ssp.kubevirt.io"},
+	Resources: []string{"kubevirtcommontemplatesbundles", "kubevirtmetricsaggregations", "kubevirttemplatevalidators"},
+	Verbs:     managedObjectVerbs,
+}}
+
+// operandRules are needed by operands in addition to the kinds they watch.
+// Most of them are granted by Roles and ClusterRoles the operands create,
+// because RBAC does not allow granting permissions the operator does not have.
+var operandRules = []rbac.PolicyRule{{
+	// Read by template-validator for its certificates and by common-instancetypes for the SSH key
+	APIGroups: []string{""},
+	Resources: []string{"secrets"},
+	Verbs:     []string{"get"},
+}, {
+	// metrics
+	APIGroups: []string{""},
+	Resources: []string{"endpoints", "pods"},
+	Verbs:     readOnlyVerbs,
+}, {
+	// data-sources
+	APIGroups: []string{""},
+	Resources: []string{"persistentvolumeclaims"},
+	Verbs:     managedObjectVerbs,
+}, {
+	APIGroups: []string{""},
+	Resources: []string{"persistentvolumeclaims/status"},
+	Verbs:     readOnlyVerbs,
+}, {
+	APIGroups: []string{"cdi.kubevirt.io"},
+	Resources: []string{"datavolumes/source"},
+	Verbs:     []string{"create"},
+}, {
+	// vm-console-proxy
+	APIGroups: []string{"authentication.k8s.io"},
+	Resources: []string{"tokenreviews"},
+	Verbs:     []string{"create"},
+}, {
+	APIGroups: []string{"authorization.k8s.io"},
+	Resources: []string{"subjectaccessreviews"},
+	Verbs:     []string{"create"},
+}, {
+	// tekton-tasks and tekton-pipelines
+	APIGroups: []string{"*"},
+	Resources: []string{"configmaps"},
+	Verbs:     []string{"create", "delete", "list", "watch"},
+}, {
+	APIGroups: []string{"*"},
+	Resources: []string{"persistentvolumeclaims", "secrets"},
+	Verbs:     []string{"*"},
+}, {
+	APIGroups: []string{"*"},
+	Resources: []string{"pods"},
+	Verbs:     []string{"create"},
+}, {
+	APIGroups: []string{"cdi.kubevirt.io"},
+	Resources: []string{"datavolumes"},
+	Verbs:     []string{"*"},
+}, {
+	APIGroups: []string{"kubevirt.io"},
+	Resources: []string{"virtualmachineinstances", "virtualmachines"},
+	Verbs:     []string{"create", "delete", "get", "list", "update", "watch"},
+}, {
+	APIGroups: []string{"kubevirt.io"},
+	Resources: []string{"virtualmachines/finalizers"},
+	Verbs:     []string{"*"},
+}, {
+	APIGroups: []string{"subresources.kubevirt.io"},
+	Resources: []string{"virtualmachines/restart", "virtualmachines/start", "virtualmachines/stop"},
+	Verbs:     []string{"update"},
+}, {
+	APIGroups: []string{"tekton.dev"},
+	Resources: []string{"pipelines", "tasks"},
+	Verbs:     managedObjectVerbs,
+}, {
+	APIGroups: []string{"tekton.dev"},
+	Resources: []string{"clustertasks"},
+	Verbs:     []string{"delete", "get", "list", "patch", "update"},
+}, {
+	APIGroups: []string{"template.openshift.io"},
+	Resources: []string{"processedtemplates"},
+	Verbs:     []string{"create"},
+}}
+
+// leaderElectionRules are needed in the operator namespace to acquire the leader election Lease
+var leaderElectionRules = []rbac.PolicyRule{{
+	APIGroups: []string{"coordination.k8s.io"},
+	Resources: []string{"leases"},
+	Verbs:     []string{"create", "get", "update"},
+}, {
+	APIGroups: []string{""},
+	Resources: []string{"events"},
+	Verbs:     []string{"create", "patch"},
+}}
+
+// allOperands returns all operands the operator can deploy on any platform.
+// They are created without bundles, so only the kinds they manage can be used.
+func allOperands() []operands.Operand {
+	return []operands.Operand{
+		common_instancetypes.New("", ""),
+		data_sources.New(nil),
+		operator_config.New(nil),
+		tekton_tasks.New(&tekton_bundle.Bundle{}),
+		tekton_pipelines.New(&tekton_bundle.Bundle{}),
+		metrics.New(),
+		template_validator.New(),
+		common_templates.New(nil, nil),
+		vm_console_proxy.New(&vm_console_proxy_bundle.Bundle{}),
+	}
+}
+
+// RequiredRBACRules returns the cluster-wide RBAC rules the operator needs to reconcile the SSP CR
+// and all kinds managed by its operands. The rules are sorted by API group.
+func RequiredRBACRules() ([]rbac.PolicyRule, error) {
+	resourcesByGroup := map[string]map[string]struct{}{}
+	for _, operand := range allOperands() {
+		watchTypes := append(operand.WatchTypes(), operand.WatchClusterTypes()...)
+		for _, watchType := range watchTypes {
+			gvk, err := apiutil.GVKForObject(watchType.Object, common.Scheme)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get kind managed by operand %s: %w", operand.Name(), err)
+			}
+			resource, _ := meta.UnsafeGuessKindToResource(gvk)
+			if resourcesByGroup[resource.Group] == nil {
+				resourcesByGroup[resource.Group] = map[string]struct{}{}
+			}
+			resourcesByGroup[resource.Group][resource.Resource] = struct{}{}
+		}
+	}
+
+	rules := make([]rbac.PolicyRule, 0, len(resourcesByGroup)+len(operatorRules)+len(operandRules))
+	rules = append(rules, operatorRules...)
+	rules = append(rules, operandRules...)
+	for group, resourceSet := range resourcesByGroup {
+		resources := make([]string, 0, len(resourceSet))
+		for resource := range resourceSet {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+		rules = append(rules, rbac.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources,
+			Verbs:     managedObjectVerbs,
+		})
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].APIGroups[0] < rules[j].APIGroups[0]
+	})
+	return rules, nil
+}
+
+// RequiredLeaderElectionRBACRules returns the RBAC rules the operator needs in its namespace for leader election
+func RequiredLeaderElectionRBACRules() []rbac.PolicyRule {
+	rules := make([]rbac.PolicyRule, 0, len(leaderElectionRules))
+	for i := range leaderElectionRules {
+		rules = append(rules, *leaderElectionRules[i].DeepCopy())
+	}
+	return rules
+}
@@ -3,10 +3,10 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/go-logr/logr"
 	v1 "github.com/openshift/api/config/v1"
+	templatev1 "github.com/openshift/api/template/v1"
 	"kubevirt.io/ssp-operator/internal/common"
 	crd_watch "kubevirt.io/ssp-operator/internal/crd-watch"
 	"kubevirt.io/ssp-operator/internal/operands"
@@ -14,6 +14,7 @@ import (
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
 	data_sources "kubevirt.io/ssp-operator/internal/operands/data-sources"
 	"kubevirt.io/ssp-operator/internal/operands/metrics"
+	operator_config "kubevirt.io/ssp-operator/internal/operands/operator-config"
 	tekton_pipelines "kubevirt.io/ssp-operator/internal/operands/tekton-pipelines"
 	tekton_tasks "kubevirt.io/ssp-operator/internal/operands/tekton-tasks"
 	template_validator "kubevirt.io/ssp-operator/internal/operands/template-validator"
@@ -28,12 +29,14 @@ import (
 // Need to watch CRDs
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
-func CreateAndStartReconciler(ctx context.Context, mgr controllerruntime.Manager) error {
+// CreateAndStartReconciler sets up the SSP controller and starts the manager.
+// The runtimeFlags are the command line flags the operator was started with.
+func CreateAndStartReconciler(ctx context.Context, mgr controllerruntime.Manager, finalizerName string, runtimeFlags map[string]string) error {
 	mgrCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	mgrCtx = logr.NewContext(mgrCtx, mgr.GetLogger())
 
-	if err := setupManager(mgrCtx, cancel, mgr); err != nil {
+	if err := setupManager(mgrCtx, cancel, mgr, finalizerName, runtimeFlags); err != nil {
 		return err
 	}
 
@@ -45,18 +48,23 @@ func CreateAndStartReconciler(ctx context.Context, mgr controllerruntime.Manager
 	return nil
 }
 
-func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controllerruntime.Manager) error {
+func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controllerruntime.Manager, finalizerName string, runtimeFlags map[string]string) error {
 	runningOnOpenShift, err := common.RunningOnOpenshift(ctx, mgr.GetAPIReader())
 	if err != nil {
 		return err
 	}
 
-	templatesFile := filepath.Join(templateBundleDir, "common-templates-"+common_templates.Version+".yaml")
+	templatesFile := common_templates.BundlePath(common_templates.BundleDir, common_templates.Version)
 	templatesBundle, err := template_bundle.ReadBundle(templatesFile)
 	if err != nil {
 		return fmt.Errorf("failed to read template bundle: %w", err)
 	}
 
+	pinnableTemplates, err := readPinnableTemplates()
+	if err != nil {
+		return err
+	}
+
 	vmConsoleProxyBundlePath := vm_console_proxy_bundle.GetBundlePath()
 	vmConsoleProxyBundle, err := vm_console_proxy_bundle.ReadBundle(vmConsoleProxyBundlePath)
 	if err != nil {
@@ -83,6 +91,7 @@ func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controller
 			common_instancetypes.BundleDir+common_instancetypes.ClusterPreferencesBundle,
 		),
 		data_sources.New(templatesBundle.DataSources),
+		operator_config.New(runtimeFlags),
 		// Tekton Tasks Operand should be before Pipelines to avoid errors
 		tektonTasksOperand,
 		tektonPipelinesOperand,
@@ -92,7 +101,7 @@ func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controller
 		sspOperands = append(sspOperands,
 			metrics.New(),
 			template_validator.New(),
-			common_templates.New(templatesBundle.Templates),
+			common_templates.New(templatesBundle.Templates, pinnableTemplates),
 			vm_console_proxy.New(vmConsoleProxyBundle),
 		)
 	}
@@ -104,9 +113,13 @@ func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controller
 	}
 
 	crdWatch := crd_watch.New(requiredCrds...)
+	if runningOnOpenShift {
+		crdWatch.AddOptionalCrds(template_validator.VirtualMachineCrd)
+	}
 	// Cleanly stops the manager and exit. The pod will be restarted.
 	crdWatch.AllCrdsAddedHandler = cancel
 	crdWatch.SomeCrdRemovedHandler = cancel
+	crdWatch.OptionalCrdChangedHandler = cancel
 
 	if err = crdWatch.Init(ctx, mgr.GetAPIReader()); err != nil {
 		return err
@@ -139,11 +152,32 @@ func setupManager(ctx context.Context, cancel context.CancelFunc, mgr controller
 		return fmt.Errorf("error adding service controller: %w", err)
 	}
 
-	reconciler := NewSspReconciler(mgr.GetClient(), mgr.GetAPIReader(), infrastructureTopology, sspOperands, crdWatch)
+	reconciler := NewSspReconciler(mgr.GetClient(), mgr.GetAPIReader(), infrastructureTopology, sspOperands, crdWatch, finalizerName, mgr.GetEventRecorderFor(OperatorName))
 
 	return reconciler.setupController(mgr)
 }
 
+// readPinnableTemplates reads templates of all embedded bundles, except the current version
+func readPinnableTemplates() (map[string][]templatev1.Template, error) {
+	versions, err := common_templates.BundledVersions(common_templates.BundleDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template bundles: %w", err)
+	}
+
+	result := make(map[string][]templatev1.Template, len(versions))
+	for _, version := range versions {
+		if version == common_templates.Version {
+			continue
+		}
+		bundle, err := template_bundle.ReadBundle(common_templates.BundlePath(common_templates.BundleDir, version))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template bundle %s: %w", version, err)
+		}
+		result[version] = bundle.Templates
+	}
+	return result, nil
+}
+
 func getRequiredCrds(operand operands.Operand) []string {
 	var result []string
 	for _, watchType := range operand.WatchTypes() {
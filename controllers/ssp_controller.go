@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	osconfv1 "github.com/openshift/api/config/v1"
@@ -34,6 +36,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -54,10 +58,22 @@ import (
 )
 
 const (
-	finalizerName    = "ssp.kubevirt.io/finalizer"
-	oldFinalizerName = "finalize.ssp.kubevirt.io"
+	// DefaultFinalizerName is the finalizer added to the SSP CR, if no other name is configured
+	DefaultFinalizerName = "ssp.kubevirt.io/finalizer"
+	oldFinalizerName     = "finalize.ssp.kubevirt.io"
 
-	templateBundleDir = "data/common-templates-bundle/"
+	// appliedFinalizerAnnotation records the finalizer added to the SSP CR, so it can be removed
+	// after the operator is restarted with a different finalizer name.
+	appliedFinalizerAnnotation = "ssp.kubevirt.io/applied-finalizer"
+
+	correlationIDLogKey = "correlationID"
+
+	// CDIMissingReason is the reason of the Degraded condition, when DataImportCronTemplates
+	// are configured, but CDI is not installed
+	CDIMissingReason = "CDIMissing"
+
+	cdiCrdSuffix              = ".cdi.kubevirt.io"
+	cdiMissingRequeueInterval = 1 * time.Minute
 )
 
 // List of legacy CRDs and their corresponding kinds
@@ -69,18 +85,22 @@ var kvsspCRDs = map[string]string{
 
 // sspReconciler reconciles a SSP object
 type sspReconciler struct {
-	client           client.Client
-	uncachedReader   client.Reader
-	log              logr.Logger
-	operands         []operands.Operand
-	lastSspSpec      ssp.SSPSpec
-	subresourceCache common.VersionCache
-	topologyMode     osconfv1.TopologyMode
-	crdList          crd_watch.CrdList
-	areCrdsMissing   bool
-}
-
-func NewSspReconciler(client client.Client, uncachedReader client.Reader, infrastructureTopology osconfv1.TopologyMode, operands []operands.Operand, crdList crd_watch.CrdList) *sspReconciler {
+	client               client.Client
+	uncachedReader       client.Reader
+	log                  logr.Logger
+	operands             []operands.Operand
+	lastSspSpec          ssp.SSPSpec
+	lastValidatorRestart string
+	subresourceCache     common.VersionCache
+	topologyMode         osconfv1.TopologyMode
+	crdList              crd_watch.CrdList
+	areCrdsMissing       bool
+	finalizerName        string
+	recorder             record.EventRecorder
+	degradedTracker      *degradedTracker
+}
+
+func NewSspReconciler(client client.Client, uncachedReader client.Reader, infrastructureTopology osconfv1.TopologyMode, operands []operands.Operand, crdList crd_watch.CrdList, finalizerName string, recorder record.EventRecorder) *sspReconciler {
 	return &sspReconciler{
 		client:           client,
 		uncachedReader:   uncachedReader,
@@ -89,6 +109,9 @@ func NewSspReconciler(client client.Client, uncachedReader client.Reader, infras
 		subresourceCache: common.VersionCache{},
 		topologyMode:     infrastructureTopology,
 		crdList:          crdList,
+		finalizerName:    finalizerName,
+		recorder:         recorder,
+		degradedTracker:  newDegradedTracker(),
 	}
 }
 
@@ -99,6 +122,7 @@ var _ reconcile.Reconciler = &sspReconciler{}
 // +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=ssps/finalizers,verbs=update
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures;clusterversions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=list
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=kubevirtcommontemplatesbundles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=kubevirtmetricsaggregations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ssp.kubevirt.io,resources=kubevirttemplatevalidators,verbs=get;list;watch;create;update;patch;delete
@@ -130,12 +154,16 @@ func (r *sspReconciler) setupController(mgr ctrl.Manager) error {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
 func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, err error) {
+	common.SSPReconcileTotal.Inc()
 	defer func() {
 		if err != nil {
 			common.SSPOperatorReconcilingProperly.Set(0)
 		}
 	}()
-	reqLogger := r.log.WithValues("ssp", req.NamespacedName)
+	// All log entries of a single reconciliation share the correlation ID,
+	// so they can be grouped together by log aggregation.
+	reqLogger := r.log.WithValues("ssp", req.NamespacedName, correlationIDLogKey, uuid.NewUUID())
+	ctx = logr.NewContext(ctx, reqLogger)
 	reqLogger.Info("Starting reconciliation")
 
 	// Fetch the SSP instance
@@ -164,6 +192,7 @@ func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ct
 		VersionCache:   r.subresourceCache,
 		TopologyMode:   r.topologyMode,
 		CrdList:        r.crdList,
+		EventRecorder:  r.recorder,
 	}
 
 	if restartNeeded {
@@ -171,13 +200,13 @@ func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ct
 	}
 
 	if !isInitialized(sspRequest.Instance) {
-		err := initialize(sspRequest)
+		err := initialize(sspRequest, r.finalizerName)
 		// No need to requeue here, because
 		// the update will trigger reconciliation again
 		return ctrl.Result{}, err
 	}
 
-	if updated, err := updateSsp(sspRequest); updated || (err != nil) {
+	if updated, err := updateSsp(sspRequest, r.finalizerName); updated || (err != nil) {
 		// SSP was updated, and the update will trigger reconciliation again.
 		return ctrl.Result{}, err
 	}
@@ -203,12 +232,25 @@ func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ct
 	}
 
 	if r.areCrdsMissing {
-		err := updateStatusMissingCrds(sspRequest, r.crdList.MissingCrds())
-		return ctrl.Result{}, err
+		missingCrds := r.crdList.MissingCrds()
+		if err := updateStatusMissingCrds(sspRequest, missingCrds); err != nil {
+			return ctrl.Result{}, err
+		}
+		if isCdiRequiredAndMissing(sspRequest.Instance, missingCrds) {
+			// The operator restarts when the missing CRDs are created.
+			// Until then, reconcile periodically to keep the status up to date.
+			return ctrl.Result{RequeueAfter: cdiMissingRequeueInterval}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
+	degradedGracePeriod, err := common.GetDegradedGracePeriod()
+	if err != nil {
+		return handleError(sspRequest, err, sspRequest.Logger)
 	}
 
 	sspRequest.Logger.V(1).Info("Updating CR status prior to operand reconciliation...")
-	err = preUpdateStatus(sspRequest)
+	err = preUpdateStatus(sspRequest, degradedGracePeriod)
 	if err != nil {
 		return handleError(sspRequest, err, sspRequest.Logger)
 	}
@@ -218,12 +260,17 @@ func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ct
 	sspRequest.Logger.Info("Reconciling operands...")
 	reconcileResults, err := r.reconcileOperands(sspRequest)
 	if err != nil {
-		return handleError(sspRequest, err, sspRequest.Logger)
+		return r.handleOperandError(sspRequest, err, degradedGracePeriod)
 	}
 	sspRequest.Logger.V(1).Info("Operands reconciled")
 
+	if err := reconcileInventory(sspRequest, reconcileResults); err != nil {
+		return handleError(sspRequest, err, sspRequest.Logger)
+	}
+
 	sspRequest.Logger.V(1).Info("Updating CR status post reconciliation...")
-	err = updateStatus(sspRequest, reconcileResults)
+	inGracePeriod := r.operandsInDegradedGracePeriod(sspRequest, reconcileResults, degradedGracePeriod)
+	err = updateStatus(sspRequest, reconcileResults, inGracePeriod)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -235,7 +282,7 @@ func (r *sspReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ct
 		common.SSPOperatorReconcilingProperly.Set(0)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: sspRequest.GetRequeueAfter()}, nil
 }
 
 func (r *sspReconciler) isRestartNeeded(sspObj *ssp.SSP) bool {
@@ -258,14 +305,18 @@ func (r *sspReconciler) restart(request *common.Request) {
 }
 
 func (r *sspReconciler) clearCacheIfNeeded(sspObj *ssp.SSP) {
-	if !reflect.DeepEqual(r.lastSspSpec, sspObj.Spec) {
+	// The validator restart annotation changes the deployment, so it is treated like a spec change
+	validatorRestart := sspObj.GetAnnotations()[ssp.TemplateValidatorRestartAnnotation]
+	if !reflect.DeepEqual(r.lastSspSpec, sspObj.Spec) || r.lastValidatorRestart != validatorRestart {
 		r.subresourceCache = common.VersionCache{}
 		r.lastSspSpec = sspObj.Spec
+		r.lastValidatorRestart = validatorRestart
 	}
 }
 
 func (r *sspReconciler) clearCache() {
 	r.lastSspSpec = ssp.SSPSpec{}
+	r.lastValidatorRestart = ""
 	r.subresourceCache = common.VersionCache{}
 }
 
@@ -292,19 +343,25 @@ func isInitialized(ssp *ssp.SSP) bool {
 	return isBeingDeleted(ssp) || ssp.Status.Phase != lifecycleapi.PhaseEmpty
 }
 
-func initialize(request *common.Request) error {
-	controllerutil.AddFinalizer(request.Instance, finalizerName)
+func initialize(request *common.Request, finalizerName string) error {
+	addFinalizer(request.Instance, finalizerName)
 	return updateSspResource(request)
 }
 
-func updateSsp(request *common.Request) (bool, error) {
+func updateSsp(request *common.Request, finalizerName string) (bool, error) {
 	updated := false
 
-	// Update old finalizer to new one
-	if controllerutil.ContainsFinalizer(request.Instance, oldFinalizerName) {
-		controllerutil.RemoveFinalizer(request.Instance, oldFinalizerName)
-		controllerutil.AddFinalizer(request.Instance, finalizerName)
-		updated = true
+	// Replace finalizers used by older versions or a different configuration with the configured one.
+	// New finalizers cannot be added to an object that is being deleted.
+	if !isBeingDeleted(request.Instance) {
+		for _, otherFinalizer := range knownFinalizers(request.Instance, finalizerName) {
+			if otherFinalizer == finalizerName || !controllerutil.ContainsFinalizer(request.Instance, otherFinalizer) {
+				continue
+			}
+			controllerutil.RemoveFinalizer(request.Instance, otherFinalizer)
+			addFinalizer(request.Instance, finalizerName)
+			updated = true
+		}
 	}
 
 	if !updated {
@@ -330,9 +387,33 @@ func updateSspResource(request *common.Request) error {
 	return setSspResourceDeploying(request)
 }
 
+// addFinalizer adds the finalizer to the SSP CR and records its name in an annotation
+func addFinalizer(sspObj *ssp.SSP, finalizerName string) {
+	controllerutil.AddFinalizer(sspObj, finalizerName)
+	metav1.SetMetaDataAnnotation(&sspObj.ObjectMeta, appliedFinalizerAnnotation, finalizerName)
+}
+
+// knownFinalizers returns all finalizers that the operator may have added to the SSP CR,
+// including the one recorded in the annotation, if the operator ran with a different finalizer name before.
+func knownFinalizers(sspObj *ssp.SSP, finalizerName string) []string {
+	finalizers := []string{finalizerName, DefaultFinalizerName, oldFinalizerName}
+	if appliedFinalizer := sspObj.GetAnnotations()[appliedFinalizerAnnotation]; appliedFinalizer != "" {
+		finalizers = append(finalizers, appliedFinalizer)
+	}
+	return finalizers
+}
+
+func containsKnownFinalizer(sspObj *ssp.SSP, finalizerName string) bool {
+	for _, finalizer := range knownFinalizers(sspObj, finalizerName) {
+		if controllerutil.ContainsFinalizer(sspObj, finalizer) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *sspReconciler) cleanup(request *common.Request) error {
-	if controllerutil.ContainsFinalizer(request.Instance, finalizerName) ||
-		controllerutil.ContainsFinalizer(request.Instance, oldFinalizerName) {
+	if containsKnownFinalizer(request.Instance, r.finalizerName) {
 		sspStatus := &request.Instance.Status
 		sspStatus.Phase = lifecycleapi.PhaseDeleting
 		sspStatus.ObservedGeneration = request.Instance.Generation
@@ -379,8 +460,9 @@ func (r *sspReconciler) cleanup(request *common.Request) error {
 			return nil
 		}
 
-		controllerutil.RemoveFinalizer(request.Instance, finalizerName)
-		controllerutil.RemoveFinalizer(request.Instance, oldFinalizerName)
+		for _, finalizer := range knownFinalizers(request.Instance, r.finalizerName) {
+			controllerutil.RemoveFinalizer(request.Instance, finalizer)
+		}
 		err = request.Client.Update(request.Context, request.Instance)
 		if err != nil {
 			return err
@@ -450,7 +532,13 @@ func listExistingCRDKinds(sspRequest *common.Request) []string {
 	return foundKinds
 }
 
-func (r *sspReconciler) reconcileOperands(sspRequest *common.Request) ([]common.ReconcileResult, error) {
+// operandReconcileResults holds the reconcile results of resources managed by a single operand
+type operandReconcileResults struct {
+	operandName string
+	results     []common.ReconcileResult
+}
+
+func (r *sspReconciler) reconcileOperands(sspRequest *common.Request) ([]operandReconcileResults, error) {
 	kinds := listExistingCRDKinds(sspRequest)
 
 	// Mark existing CRs as paused
@@ -460,21 +548,26 @@ func (r *sspReconciler) reconcileOperands(sspRequest *common.Request) ([]common.
 	}
 
 	// Reconcile all operands
-	allReconcileResults := make([]common.ReconcileResult, 0, len(r.operands))
+	allReconcileResults := make([]operandReconcileResults, 0, len(r.operands))
 	for _, operand := range r.operands {
 		sspRequest.Logger.V(1).Info(fmt.Sprintf("Reconciling operand: %s", operand.Name()))
 		reconcileResults, err := operand.Reconcile(sspRequest)
 		if err != nil {
 			sspRequest.Logger.Info(fmt.Sprintf("Operand reconciliation failed: %s", err.Error()))
-			return nil, err
+			return nil, &operandError{operandName: operand.Name(), err: err}
 		}
-		allReconcileResults = append(allReconcileResults, reconcileResults...)
+		allReconcileResults = append(allReconcileResults, operandReconcileResults{
+			operandName: operand.Name(),
+			results:     reconcileResults,
+		})
 	}
 
 	return allReconcileResults, nil
 }
 
-func preUpdateStatus(request *common.Request) error {
+// preUpdateStatus marks the SSP as being reconciled. With a degraded grace period,
+// the Degraded condition is not changed until operands are reconciled.
+func preUpdateStatus(request *common.Request, degradedGracePeriod time.Duration) error {
 	operatorVersion := common.GetOperatorVersion()
 
 	sspStatus := &request.Instance.Status
@@ -507,7 +600,7 @@ func preUpdateStatus(request *common.Request) error {
 		})
 	}
 
-	if !conditionsv1.IsStatusConditionPresentAndEqual(sspStatus.Conditions, conditionsv1.ConditionDegraded, v1.ConditionTrue) {
+	if degradedGracePeriod == 0 && !conditionsv1.IsStatusConditionPresentAndEqual(sspStatus.Conditions, conditionsv1.ConditionDegraded, v1.ConditionTrue) {
 		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
 			Type:    conditionsv1.ConditionDegraded,
 			Status:  v1.ConditionTrue,
@@ -519,48 +612,60 @@ func preUpdateStatus(request *common.Request) error {
 	return request.Client.Status().Update(request.Context, request.Instance)
 }
 
-func updateStatus(request *common.Request, reconcileResults []common.ReconcileResult) error {
-	notAvailable := make([]common.ReconcileResult, 0, len(reconcileResults))
-	progressing := make([]common.ReconcileResult, 0, len(reconcileResults))
-	degraded := make([]common.ReconcileResult, 0, len(reconcileResults))
-	for _, reconcileResult := range reconcileResults {
-		if reconcileResult.Status.NotAvailable != nil {
-			notAvailable = append(notAvailable, reconcileResult)
+// operandsInDegradedGracePeriod returns operands with degraded resources, that are failing for a shorter time
+// than the grace period. Reconciliation is repeated when the grace period of any of them ends.
+func (r *sspReconciler) operandsInDegradedGracePeriod(request *common.Request, operandResults []operandReconcileResults, gracePeriod time.Duration) map[string]bool {
+	inGracePeriod := map[string]bool{}
+	for _, operandResult := range operandResults {
+		if !isOperandDegraded(operandResult) {
+			r.degradedTracker.markHealthy(operandResult.operandName)
+			continue
+		}
+		if gracePeriod == 0 {
+			continue
 		}
-		if reconcileResult.Status.Progressing != nil {
-			progressing = append(progressing, reconcileResult)
+		if remaining := r.degradedTracker.markFailing(operandResult.operandName, gracePeriod); remaining > 0 {
+			inGracePeriod[operandResult.operandName] = true
+			request.RequeueAfter(remaining)
 		}
+	}
+	return inGracePeriod
+}
+
+func isOperandDegraded(operandResult operandReconcileResults) bool {
+	for _, reconcileResult := range operandResult.results {
 		if reconcileResult.Status.Degraded != nil {
-			degraded = append(degraded, reconcileResult)
+			return true
 		}
 	}
+	return false
+}
 
-	sspStatus := &request.Instance.Status
-	switch len(notAvailable) {
-	case 0:
-		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
-			Type:    conditionsv1.ConditionAvailable,
-			Status:  v1.ConditionTrue,
-			Reason:  "Available",
-			Message: "All SSP resources are available",
-		})
-	case 1:
-		reconcileResult := notAvailable[0]
-		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
-			Type:    conditionsv1.ConditionAvailable,
-			Status:  v1.ConditionFalse,
-			Reason:  "Available",
-			Message: prefixResourceTypeAndName(*reconcileResult.Status.NotAvailable, reconcileResult.Resource),
-		})
-	default:
-		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
-			Type:    conditionsv1.ConditionAvailable,
-			Status:  v1.ConditionFalse,
-			Reason:  "Available",
-			Message: fmt.Sprintf("%d SSP resources are not available", len(notAvailable)),
-		})
+// updateStatus sets the status from the results of all operands.
+// Degraded resources of operands in the inGracePeriod set do not make the SSP degraded.
+func updateStatus(request *common.Request, operandResults []operandReconcileResults, inGracePeriod map[string]bool) error {
+	var notAvailable, progressing, degraded, degradedInGracePeriod []common.ReconcileResult
+	for _, operandResult := range operandResults {
+		for _, reconcileResult := range operandResult.results {
+			if reconcileResult.Status.NotAvailable != nil {
+				notAvailable = append(notAvailable, reconcileResult)
+			}
+			if reconcileResult.Status.Progressing != nil {
+				progressing = append(progressing, reconcileResult)
+			}
+			if reconcileResult.Status.Degraded != nil {
+				if inGracePeriod[operandResult.operandName] {
+					degradedInGracePeriod = append(degradedInGracePeriod, reconcileResult)
+				} else {
+					degraded = append(degraded, reconcileResult)
+				}
+			}
+		}
 	}
 
+	sspStatus := &request.Instance.Status
+	conditionsv1.SetStatusCondition(&sspStatus.Conditions, availableCondition(operandResults))
+
 	switch len(progressing) {
 	case 0:
 		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
@@ -586,15 +691,23 @@ func updateStatus(request *common.Request, reconcileResults []common.ReconcileRe
 		})
 	}
 
-	switch len(degraded) {
-	case 0:
+	switch {
+	case len(degraded) == 0 && len(degradedInGracePeriod) > 0:
+		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
+			Type:   conditionsv1.ConditionDegraded,
+			Status: v1.ConditionFalse,
+			Reason: "Degraded",
+			Message: fmt.Sprintf("%d SSP resources are failing for a shorter time than the degraded grace period",
+				len(degradedInGracePeriod)),
+		})
+	case len(degraded) == 0:
 		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
 			Type:    conditionsv1.ConditionDegraded,
 			Status:  v1.ConditionFalse,
 			Reason:  "Degraded",
 			Message: "No SSP resources are degraded",
 		})
-	case 1:
+	case len(degraded) == 1:
 		reconcileResult := degraded[0]
 		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
 			Type:    conditionsv1.ConditionDegraded,
@@ -612,7 +725,7 @@ func updateStatus(request *common.Request, reconcileResults []common.ReconcileRe
 	}
 
 	sspStatus.ObservedGeneration = request.Instance.Generation
-	if len(notAvailable) == 0 && len(progressing) == 0 && len(degraded) == 0 {
+	if len(notAvailable) == 0 && len(progressing) == 0 && len(degraded) == 0 && len(degradedInGracePeriod) == 0 {
 		sspStatus.Phase = lifecycleapi.PhaseDeployed
 		sspStatus.ObservedVersion = common.GetOperatorVersion()
 	} else {
@@ -622,6 +735,52 @@ func updateStatus(request *common.Request, reconcileResults []common.ReconcileRe
 	return request.Client.Status().Update(request.Context, request.Instance)
 }
 
+// availableCondition returns the Available condition aggregated from the readiness of all operands.
+// The SSP is available only if all resources of all operands are available.
+func availableCondition(operandResults []operandReconcileResults) conditionsv1.Condition {
+	var notReadyOperands []string
+	var notAvailable []common.ReconcileResult
+	for _, operandResult := range operandResults {
+		operandReady := true
+		for _, reconcileResult := range operandResult.results {
+			if reconcileResult.Status.NotAvailable != nil {
+				notAvailable = append(notAvailable, reconcileResult)
+				operandReady = false
+			}
+		}
+		if !operandReady {
+			notReadyOperands = append(notReadyOperands, operandResult.operandName)
+		}
+	}
+
+	switch len(notAvailable) {
+	case 0:
+		return conditionsv1.Condition{
+			Type:    conditionsv1.ConditionAvailable,
+			Status:  v1.ConditionTrue,
+			Reason:  "Available",
+			Message: "All SSP resources are available",
+		}
+	case 1:
+		reconcileResult := notAvailable[0]
+		return conditionsv1.Condition{
+			Type:   conditionsv1.ConditionAvailable,
+			Status: v1.ConditionFalse,
+			Reason: "Available",
+			Message: fmt.Sprintf("Operand %s is not ready: %s", notReadyOperands[0],
+				prefixResourceTypeAndName(*reconcileResult.Status.NotAvailable, reconcileResult.Resource)),
+		}
+	default:
+		return conditionsv1.Condition{
+			Type:   conditionsv1.ConditionAvailable,
+			Status: v1.ConditionFalse,
+			Reason: "Available",
+			Message: fmt.Sprintf("%d SSP resources are not available, not ready operands: %s",
+				len(notAvailable), strings.Join(notReadyOperands, ", ")),
+		}
+	}
+}
+
 func updateStatusMissingCrds(request *common.Request, missingCrds []string) error {
 	sspStatus := &request.Instance.Status
 
@@ -640,16 +799,39 @@ func updateStatusMissingCrds(request *common.Request, missingCrds []string) erro
 		Message: message,
 	})
 
+	degradedReason := "Degraded"
+	degradedMessage := message
+	if isCdiRequiredAndMissing(request.Instance, missingCrds) {
+		degradedReason = CDIMissingReason
+		degradedMessage = fmt.Sprintf("DataImportCronTemplates are configured, but CDI is not installed. Missing CDI CRDs: %s",
+			strings.Join(missingCdiCrds(missingCrds), ", "))
+	}
+
 	conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
 		Type:    conditionsv1.ConditionDegraded,
 		Status:  v1.ConditionTrue,
-		Reason:  "Degraded",
-		Message: message,
+		Reason:  degradedReason,
+		Message: degradedMessage,
 	})
 
 	return request.Client.Status().Update(request.Context, request.Instance)
 }
 
+func isCdiRequiredAndMissing(sspObj *ssp.SSP, missingCrds []string) bool {
+	return len(sspObj.Spec.CommonTemplates.DataImportCronTemplates) > 0 && len(missingCdiCrds(missingCrds)) > 0
+}
+
+func missingCdiCrds(missingCrds []string) []string {
+	var result []string
+	for _, crd := range missingCrds {
+		if strings.HasSuffix(crd, cdiCrdSuffix) {
+			result = append(result, crd)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 func prefixResourceTypeAndName(message string, resource client.Object) string {
 	return fmt.Sprintf("%s %s/%s: %s",
 		resource.GetObjectKind().GroupVersionKind().Kind,
@@ -673,6 +855,31 @@ func handleError(request *common.Request, errParam error, logger logr.Logger) (c
 	}
 
 	// Default error handling, if error is not known
+	setErrorStatus(request, errParam, true)
+	return ctrl.Result{}, errParam
+}
+
+// handleOperandError handles an error returned by an operand. The Degraded condition is not set
+// while the operand is failing for a shorter time than the grace period.
+func (r *sspReconciler) handleOperandError(request *common.Request, errParam error, gracePeriod time.Duration) (ctrl.Result, error) {
+	opErr, isOperandError := errParam.(*operandError)
+	if gracePeriod == 0 || !isOperandError || errors.IsConflict(opErr.err) {
+		return handleError(request, errParam, request.Logger)
+	}
+
+	if r.degradedTracker.markFailing(opErr.operandName, gracePeriod) == 0 {
+		return handleError(request, errParam, request.Logger)
+	}
+
+	request.Logger.Info("Operand is failing within the degraded grace period",
+		"operand", opErr.operandName,
+		"gracePeriod", gracePeriod.String(),
+	)
+	setErrorStatus(request, errParam, false)
+	return ctrl.Result{}, errParam
+}
+
+func setErrorStatus(request *common.Request, errParam error, degraded bool) {
 	errorMsg := fmt.Sprintf("Error: %v", errParam)
 	sspStatus := &request.Instance.Status
 	sspStatus.Phase = lifecycleapi.PhaseDeploying
@@ -688,18 +895,18 @@ func handleError(request *common.Request, errParam error, logger logr.Logger) (c
 		Reason:  "Progressing",
 		Message: errorMsg,
 	})
-	conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
-		Type:    conditionsv1.ConditionDegraded,
-		Status:  v1.ConditionTrue,
-		Reason:  "Degraded",
-		Message: errorMsg,
-	})
+	if degraded {
+		conditionsv1.SetStatusCondition(&sspStatus.Conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionDegraded,
+			Status:  v1.ConditionTrue,
+			Reason:  "Degraded",
+			Message: errorMsg,
+		})
+	}
 	err := request.Client.Status().Update(request.Context, request.Instance)
 	if err != nil {
 		request.Logger.Error(err, "Error updating SSP status.")
 	}
-
-	return ctrl.Result{}, errParam
 }
 
 func watchSspResource(bldr *ctrl.Builder) {
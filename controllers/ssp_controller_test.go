@@ -0,0 +1,1079 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	routev1 "github.com/openshift/api/route/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+	"kubevirt.io/ssp-operator/internal/operands"
+	common_instancetypes "kubevirt.io/ssp-operator/internal/operands/common-instancetypes"
+	vm_console_proxy "kubevirt.io/ssp-operator/internal/operands/vm-console-proxy"
+	vm_console_proxy_bundle "kubevirt.io/ssp-operator/internal/vm-console-proxy-bundle"
+)
+
+var _ = Describe("Available condition", func() {
+	newResult := func(name string, notAvailable *string) common.ReconcileResult {
+		return common.ReconcileResult{
+			Resource: &apps.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					Kind: "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "test-ns",
+				},
+			},
+			Status: common.ResourceStatus{
+				NotAvailable: notAvailable,
+			},
+		}
+	}
+
+	It("should be true when all operands are ready", func() {
+		condition := availableCondition([]operandReconcileResults{{
+			operandName: "operand-a",
+			results:     []common.ReconcileResult{newResult("a-1", nil), newResult("a-2", nil)},
+		}, {
+			operandName: "operand-b",
+			results:     []common.ReconcileResult{newResult("b-1", nil)},
+		}})
+
+		Expect(condition.Type).To(Equal(conditionsv1.ConditionAvailable))
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Message).To(Equal("All SSP resources are available"))
+	})
+
+	It("should be true when operands have no resources", func() {
+		condition := availableCondition([]operandReconcileResults{{
+			operandName: "operand-a",
+		}})
+
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+	})
+
+	It("should name the single not ready operand", func() {
+		condition := availableCondition([]operandReconcileResults{{
+			operandName: "operand-a",
+			results:     []common.ReconcileResult{newResult("a-1", nil)},
+		}, {
+			operandName: "operand-b",
+			results:     []common.ReconcileResult{newResult("b-1", pointer.String("No ready replicas"))},
+		}})
+
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(Equal("Operand operand-b is not ready: Deployment test-ns/b-1: No ready replicas"))
+	})
+
+	It("should explain when the template validator is not ready", func() {
+		validatorResult := newResult("virt-template-validator",
+			pointer.String("Template validator is not ready, ready replicas: 0, desired replicas: 2"))
+		condition := availableCondition([]operandReconcileResults{{
+			operandName: "template-validator",
+			results:     []common.ReconcileResult{validatorResult},
+		}, {
+			operandName: "operand-b",
+			results:     []common.ReconcileResult{newResult("b-1", nil)},
+		}})
+
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(Equal("Operand template-validator is not ready: " +
+			"Deployment test-ns/virt-template-validator: Template validator is not ready, ready replicas: 0, desired replicas: 2"))
+	})
+
+	It("should name all not ready operands", func() {
+		condition := availableCondition([]operandReconcileResults{{
+			operandName: "operand-a",
+			results: []common.ReconcileResult{
+				newResult("a-1", pointer.String("Not available")),
+				newResult("a-2", pointer.String("Not available")),
+			},
+		}, {
+			operandName: "operand-b",
+			results:     []common.ReconcileResult{newResult("b-1", nil)},
+		}, {
+			operandName: "operand-c",
+			results:     []common.ReconcileResult{newResult("c-1", pointer.String("Not available"))},
+		}})
+
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(Equal("3 SSP resources are not available, not ready operands: operand-a, operand-c"))
+	})
+})
+
+var _ = Describe("Version cache", func() {
+	var reconciler *sspReconciler
+
+	BeforeEach(func() {
+		reconciler = NewSspReconciler(nil, nil, "", nil, fakeCrdList{}, DefaultFinalizerName, nil)
+	})
+
+	newSsp := func(annotations map[string]string) *ssp.SSP {
+		return &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-ssp",
+				Namespace:   "kubevirt",
+				Annotations: annotations,
+			},
+			Spec: ssp.SSPSpec{
+				CommonTemplates: ssp.CommonTemplates{
+					Namespace: "templates",
+				},
+			},
+		}
+	}
+
+	fillCache := func() {
+		reconciler.subresourceCache.Add(&v1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-cm",
+				Namespace:  "kubevirt",
+				Generation: 1,
+			},
+		})
+	}
+
+	It("should keep cache when SSP did not change", func() {
+		reconciler.clearCacheIfNeeded(newSsp(nil))
+		fillCache()
+
+		reconciler.clearCacheIfNeeded(newSsp(map[string]string{"unrelated": "value"}))
+		Expect(reconciler.subresourceCache).ToNot(BeEmpty())
+	})
+
+	It("should clear cache when validator restart annotation changes", func() {
+		reconciler.clearCacheIfNeeded(newSsp(nil))
+		fillCache()
+
+		reconciler.clearCacheIfNeeded(newSsp(map[string]string{ssp.TemplateValidatorRestartAnnotation: "restart"}))
+		Expect(reconciler.subresourceCache).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Reconcile logging", func() {
+	var (
+		logLines   []map[string]interface{}
+		reconciler *sspReconciler
+	)
+
+	BeforeEach(func() {
+		logLines = nil
+		logger := funcr.NewJSON(func(obj string) {
+			line := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(obj), &line)).To(Succeed())
+			logLines = append(logLines, line)
+		}, funcr.Options{Verbosity: 1})
+
+		sspObj := &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-ssp",
+				Namespace:  "kubevirt",
+				Finalizers: []string{DefaultFinalizerName},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		}
+		apiClient := fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(sspObj).Build()
+
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{&loggingOperand{}}, fakeCrdList{}, DefaultFinalizerName, nil)
+		reconciler.log = logger
+	})
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should use the same correlation ID in all log lines of a reconciliation", func() {
+		reconcile()
+
+		Expect(logLines).To(ContainElement(HaveKeyWithValue("msg", "Starting reconciliation")))
+		Expect(logLines).To(ContainElement(HaveKeyWithValue("msg", loggingOperandMessage)))
+		Expect(logLines).To(ContainElement(HaveKeyWithValue("msg", loggingOperandContextMessage)))
+
+		correlationID := logLines[0][correlationIDLogKey]
+		Expect(correlationID).To(BeAssignableToTypeOf(""))
+		Expect(correlationID).ToNot(BeEmpty())
+		for _, line := range logLines {
+			Expect(line).To(HaveKeyWithValue(correlationIDLogKey, correlationID))
+		}
+	})
+
+	It("should use a different correlation ID for each reconciliation", func() {
+		reconcile()
+		firstID := logLines[0][correlationIDLogKey]
+
+		logLines = nil
+		reconcile()
+		Expect(logLines).ToNot(BeEmpty())
+		Expect(logLines[0][correlationIDLogKey]).ToNot(Equal(firstID))
+	})
+})
+
+var _ = Describe("Finalizer", func() {
+	const (
+		customFinalizerName   = "example.com/custom-finalizer"
+		previousFinalizerName = "example.com/previous-finalizer"
+	)
+
+	var (
+		apiClient  client.Client
+		reconciler *sspReconciler
+	)
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+
+	createSsp := func(annotations map[string]string, finalizers ...string) {
+		sspObj := &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Annotations: annotations,
+				Finalizers:  finalizers,
+			},
+		}
+		if len(finalizers) > 0 {
+			sspObj.Status.Phase = lifecycleapi.PhaseDeployed
+		}
+		Expect(apiClient.Create(context.Background(), sspObj)).To(Succeed())
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getSsp := func() *ssp.SSP {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		return sspObj
+	}
+
+	BeforeEach(func() {
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{&loggingOperand{}}, fakeCrdList{}, customFinalizerName, nil)
+	})
+
+	It("should add configured finalizer", func() {
+		createSsp(nil)
+		reconcile()
+
+		Expect(getSsp().GetFinalizers()).To(ConsistOf(customFinalizerName))
+		Expect(getSsp().GetAnnotations()).To(HaveKeyWithValue(appliedFinalizerAnnotation, customFinalizerName))
+	})
+
+	It("should replace default finalizer with configured finalizer", func() {
+		createSsp(nil, DefaultFinalizerName)
+		reconcile()
+
+		Expect(getSsp().GetFinalizers()).To(ConsistOf(customFinalizerName))
+		Expect(getSsp().GetAnnotations()).To(HaveKeyWithValue(appliedFinalizerAnnotation, customFinalizerName))
+	})
+
+	It("should replace previous custom finalizer with configured finalizer", func() {
+		createSsp(map[string]string{appliedFinalizerAnnotation: previousFinalizerName}, previousFinalizerName)
+		reconcile()
+
+		Expect(getSsp().GetFinalizers()).To(ConsistOf(customFinalizerName))
+		Expect(getSsp().GetAnnotations()).To(HaveKeyWithValue(appliedFinalizerAnnotation, customFinalizerName))
+	})
+
+	It("should remove previous custom finalizer when SSP is deleted", func() {
+		createSsp(map[string]string{appliedFinalizerAnnotation: previousFinalizerName}, previousFinalizerName)
+
+		Expect(apiClient.Delete(context.Background(), getSsp())).To(Succeed())
+		reconcile()
+
+		err := apiClient.Get(context.Background(), key, &ssp.SSP{})
+		Expect(errors.IsNotFound(err)).To(BeTrue(), "SSP should be removed after finalizer is removed")
+	})
+
+	It("should remove configured finalizer when SSP is deleted", func() {
+		createSsp(nil)
+		reconcile()
+		Expect(getSsp().GetFinalizers()).To(ConsistOf(customFinalizerName))
+
+		Expect(apiClient.Delete(context.Background(), getSsp())).To(Succeed())
+		reconcile()
+
+		err := apiClient.Get(context.Background(), key, &ssp.SSP{})
+		Expect(errors.IsNotFound(err)).To(BeTrue(), "SSP should be removed after finalizer is removed")
+	})
+})
+
+var _ = Describe("Missing CDI", func() {
+	const (
+		dataImportCronCrd = "dataimportcrons.cdi.kubevirt.io"
+		dataSourceCrd     = "datasources.cdi.kubevirt.io"
+		otherCrd          = "virtualmachines.kubevirt.io"
+	)
+
+	var apiClient client.Client
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+
+	createSsp := func(cronTemplates ...ssp.DataImportCronTemplate) {
+		sspObj := &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Finalizers: []string{DefaultFinalizerName},
+			},
+			Spec: ssp.SSPSpec{
+				CommonTemplates: ssp.CommonTemplates{
+					DataImportCronTemplates: cronTemplates,
+				},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		}
+		Expect(apiClient.Create(context.Background(), sspObj)).To(Succeed())
+	}
+
+	reconcile := func(missingCrds ...string) ctrl.Result {
+		reconciler := NewSspReconciler(apiClient, apiClient, "", []operands.Operand{&loggingOperand{}}, missingCrdList(missingCrds), DefaultFinalizerName, nil)
+		reconciler.areCrdsMissing = len(missingCrds) > 0
+
+		result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	getDegradedCondition := func() *conditionsv1.Condition {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		return conditionsv1.FindStatusCondition(sspObj.Status.Conditions, conditionsv1.ConditionDegraded)
+	}
+
+	cronTemplate := ssp.DataImportCronTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cron",
+		},
+	}
+
+	BeforeEach(func() {
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+	})
+
+	It("should set CDIMissing degraded condition when DataImportCronTemplates are configured", func() {
+		createSsp(cronTemplate)
+		result := reconcile(dataSourceCrd, dataImportCronCrd, otherCrd)
+
+		Expect(result.RequeueAfter).To(Equal(cdiMissingRequeueInterval))
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(CDIMissingReason))
+		Expect(condition.Message).To(Equal("DataImportCronTemplates are configured, but CDI is not installed. " +
+			"Missing CDI CRDs: " + dataImportCronCrd + ", " + dataSourceCrd))
+	})
+
+	It("should not set CDIMissing degraded condition when DataImportCronTemplates are not configured", func() {
+		createSsp()
+		result := reconcile(dataSourceCrd, dataImportCronCrd)
+
+		Expect(result.RequeueAfter).To(BeZero())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Degraded"))
+	})
+
+	It("should not set CDIMissing degraded condition when CDI is installed", func() {
+		createSsp(cronTemplate)
+		result := reconcile(otherCrd)
+
+		Expect(result.RequeueAfter).To(BeZero())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Reason).To(Equal("Degraded"))
+		Expect(condition.Message).To(Equal("Required CRDs are missing: " + otherCrd))
+	})
+
+	It("should not be degraded when all CRDs are present", func() {
+		createSsp(cronTemplate)
+		result := reconcile()
+
+		Expect(result.RequeueAfter).To(BeZero())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+	})
+})
+
+var _ = Describe("Degraded grace period", func() {
+	const gracePeriod = 5 * time.Minute
+
+	var (
+		apiClient  client.Client
+		reconciler *sspReconciler
+		operand    *failingOperand
+		now        time.Time
+	)
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+
+	reconcile := func() (ctrl.Result, error) {
+		return reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	}
+
+	getDegradedCondition := func() *conditionsv1.Condition {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		return conditionsv1.FindStatusCondition(sspObj.Status.Conditions, conditionsv1.ConditionDegraded)
+	}
+
+	BeforeEach(func() {
+		Expect(os.Setenv(common.DegradedGracePeriodKey, gracePeriod.String())).To(Succeed())
+		DeferCleanup(os.Unsetenv, common.DegradedGracePeriodKey)
+
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		Expect(apiClient.Create(context.Background(), &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Finalizers: []string{DefaultFinalizerName},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		})).To(Succeed())
+
+		operand = &failingOperand{}
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{operand}, fakeCrdList{}, DefaultFinalizerName, nil)
+
+		now = time.Now()
+		reconciler.degradedTracker.now = func() time.Time { return now }
+	})
+
+	It("should not be degraded when operand is healthy", func() {
+		_, err := reconcile()
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(Equal("No SSP resources are degraded"))
+	})
+
+	It("should set degraded condition only after operand reconciliation fails for the grace period", func() {
+		operand.err = fmt.Errorf("transient error")
+
+		_, err := reconcile()
+		Expect(err).To(MatchError("transient error"))
+		Expect(getDegradedCondition()).To(BeNil())
+
+		now = now.Add(gracePeriod - time.Second)
+		_, err = reconcile()
+		Expect(err).To(HaveOccurred())
+		Expect(getDegradedCondition()).To(BeNil())
+
+		now = now.Add(time.Second)
+		_, err = reconcile()
+		Expect(err).To(HaveOccurred())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Message).To(Equal("Error: transient error"))
+	})
+
+	It("should set degraded condition only after operand resource is degraded for the grace period", func() {
+		operand.degraded = pointer.String("resource is degraded")
+
+		result, err := reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(gracePeriod))
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("degraded grace period"))
+
+		now = now.Add(gracePeriod - time.Minute)
+		result, err = reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(getDegradedCondition().Status).To(Equal(v1.ConditionFalse))
+
+		now = now.Add(time.Minute)
+		_, err = reconcile()
+		Expect(err).ToNot(HaveOccurred())
+
+		condition = getDegradedCondition()
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Message).To(HaveSuffix("test-deployment: resource is degraded"))
+	})
+
+	It("should restart grace period when operand recovers", func() {
+		operand.degraded = pointer.String("resource is degraded")
+		_, err := reconcile()
+		Expect(err).ToNot(HaveOccurred())
+
+		now = now.Add(gracePeriod - time.Minute)
+		operand.degraded = nil
+		_, err = reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getDegradedCondition().Status).To(Equal(v1.ConditionFalse))
+
+		now = now.Add(time.Minute)
+		operand.degraded = pointer.String("resource is degraded")
+		_, err = reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getDegradedCondition().Status).To(Equal(v1.ConditionFalse))
+	})
+
+	It("should set degraded condition immediately without grace period", func() {
+		Expect(os.Unsetenv(common.DegradedGracePeriodKey)).To(Succeed())
+		operand.degraded = pointer.String("resource is degraded")
+
+		_, err := reconcile()
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := getDegradedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Message).To(HaveSuffix("test-deployment: resource is degraded"))
+	})
+})
+
+var _ = Describe("Inventory", func() {
+	const configMapName = "ssp-inventory"
+
+	var (
+		apiClient  client.Client
+		reconciler *sspReconciler
+		operand    *staticOperand
+	)
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	setAnnotation := func(value string) {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		if value == "" {
+			delete(sspObj.Annotations, ssp.InventoryConfigMapAnnotation)
+		} else {
+			metav1.SetMetaDataAnnotation(&sspObj.ObjectMeta, ssp.InventoryConfigMapAnnotation, value)
+		}
+		Expect(apiClient.Update(context.Background(), sspObj)).To(Succeed())
+	}
+
+	getInventory := func(name string) []inventoryEntry {
+		configMap := &v1.ConfigMap{}
+		Expect(apiClient.Get(context.Background(), client.ObjectKey{Namespace: key.Namespace, Name: name}, configMap)).To(Succeed())
+
+		var inventory []inventoryEntry
+		Expect(json.Unmarshal([]byte(configMap.Data[InventoryDataKey]), &inventory)).To(Succeed())
+		return inventory
+	}
+
+	expectConfigMapRemoved := func(name string) {
+		err := apiClient.Get(context.Background(), client.ObjectKey{Namespace: key.Namespace, Name: name}, &v1.ConfigMap{})
+		Expect(errors.IsNotFound(err)).To(BeTrue(), "ConfigMap %s should be removed", name)
+	}
+
+	newResult := func(obj client.Object, operationResult common.OperationResult) common.ReconcileResult {
+		return common.ReconcileResult{Resource: obj, OperationResult: operationResult}
+	}
+
+	BeforeEach(func() {
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		Expect(apiClient.Create(context.Background(), &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Finalizers: []string{DefaultFinalizerName},
+				Annotations: map[string]string{
+					ssp.InventoryConfigMapAnnotation: configMapName,
+				},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		})).To(Succeed())
+
+		operand = &staticOperand{results: []common.ReconcileResult{
+			newResult(&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "kubevirt"}}, common.OperationResultCreated),
+			newResult(&apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "kubevirt"}}, common.OperationResultNone),
+			newResult(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}}, common.OperationResultUpdated),
+			newResult(&apps.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "removed-deployment", Namespace: "kubevirt"}}, common.OperationResultDeleted),
+		}}
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{operand}, fakeCrdList{}, DefaultFinalizerName, nil)
+	})
+
+	It("should export managed resources to ConfigMap", func() {
+		reconcile()
+
+		Expect(getInventory(configMapName)).To(Equal([]inventoryEntry{
+			{Kind: "Deployment", Namespace: "kubevirt", Name: "test-deployment"},
+			{Kind: "Namespace", Name: "test-namespace"},
+			{Kind: "ServiceAccount", Namespace: "kubevirt", Name: "test-sa"},
+		}))
+	})
+
+	It("should update inventory when managed resources change", func() {
+		reconcile()
+
+		operand.results = operand.results[:1]
+		reconcile()
+
+		Expect(getInventory(configMapName)).To(Equal([]inventoryEntry{
+			{Kind: "ServiceAccount", Namespace: "kubevirt", Name: "test-sa"},
+		}))
+	})
+
+	It("should remove ConfigMap when annotation is removed", func() {
+		reconcile()
+		Expect(getInventory(configMapName)).ToNot(BeEmpty())
+
+		setAnnotation("")
+		reconcile()
+
+		expectConfigMapRemoved(configMapName)
+	})
+
+	It("should move inventory when annotation changes", func() {
+		reconcile()
+
+		setAnnotation("other-inventory")
+		reconcile()
+
+		expectConfigMapRemoved(configMapName)
+		Expect(getInventory("other-inventory")).To(HaveLen(3))
+	})
+
+	It("should not remove ConfigMap not owned by SSP CR", func() {
+		notOwned := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "not-owned",
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					inventoryLabel: "true",
+				},
+			},
+		}
+		Expect(apiClient.Create(context.Background(), notOwned)).To(Succeed())
+
+		reconcile()
+
+		Expect(apiClient.Get(context.Background(), client.ObjectKeyFromObject(notOwned), &v1.ConfigMap{})).To(Succeed())
+	})
+})
+
+const (
+	loggingOperandMessage        = "Reconciling logging operand"
+	loggingOperandContextMessage = "Reconciling logging operand using context logger"
+)
+
+// loggingOperand logs using both the request logger and the logger from context
+type loggingOperand struct{}
+
+var _ operands.Operand = &loggingOperand{}
+
+func (l *loggingOperand) Name() string {
+	return "logging-operand"
+}
+
+func (l *loggingOperand) WatchTypes() []operands.WatchType {
+	return nil
+}
+
+func (l *loggingOperand) WatchClusterTypes() []operands.WatchType {
+	return nil
+}
+
+func (l *loggingOperand) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
+	request.Logger.Info(loggingOperandMessage)
+	logr.FromContextOrDiscard(request.Context).Info(loggingOperandContextMessage)
+	return nil, nil
+}
+
+func (l *loggingOperand) Cleanup(*common.Request) ([]common.CleanupResult, error) {
+	return nil, nil
+}
+
+// failingOperand returns the configured error or a degraded resource
+type failingOperand struct {
+	err      error
+	degraded *string
+}
+
+var _ operands.Operand = &failingOperand{}
+
+func (f *failingOperand) Name() string {
+	return "failing-operand"
+}
+
+func (f *failingOperand) WatchTypes() []operands.WatchType {
+	return nil
+}
+
+func (f *failingOperand) WatchClusterTypes() []operands.WatchType {
+	return nil
+}
+
+func (f *failingOperand) Reconcile(*common.Request) ([]common.ReconcileResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []common.ReconcileResult{{
+		Resource: &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-deployment",
+				Namespace: "kubevirt",
+			},
+		},
+		Status: common.ResourceStatus{
+			Degraded: f.degraded,
+		},
+	}}, nil
+}
+
+func (f *failingOperand) Cleanup(*common.Request) ([]common.CleanupResult, error) {
+	return nil, nil
+}
+
+// staticOperand returns the configured reconcile results
+type staticOperand struct {
+	results []common.ReconcileResult
+}
+
+var _ operands.Operand = &staticOperand{}
+
+func (s *staticOperand) Name() string {
+	return "static-operand"
+}
+
+func (s *staticOperand) WatchTypes() []operands.WatchType {
+	return nil
+}
+
+func (s *staticOperand) WatchClusterTypes() []operands.WatchType {
+	return nil
+}
+
+func (s *staticOperand) Reconcile(*common.Request) ([]common.ReconcileResult, error) {
+	return s.results, nil
+}
+
+func (s *staticOperand) Cleanup(*common.Request) ([]common.CleanupResult, error) {
+	return nil, nil
+}
+
+type fakeCrdList struct{}
+
+func (fakeCrdList) CrdExists(string) bool {
+	return true
+}
+
+func (fakeCrdList) MissingCrds() []string {
+	return nil
+}
+
+// missingCrdList reports the listed CRDs as missing
+type missingCrdList []string
+
+func (l missingCrdList) CrdExists(crdName string) bool {
+	for _, missing := range l {
+		if missing == crdName {
+			return false
+		}
+	}
+	return true
+}
+
+func (l missingCrdList) MissingCrds() []string {
+	return l
+}
+
+var _ = Describe("Reconcile total metric", func() {
+	getReconcileTotal := func() float64 {
+		metric := &io_prometheus_client.Metric{}
+		Expect(common.SSPReconcileTotal.Write(metric)).To(Succeed())
+		return metric.GetCounter().GetValue()
+	}
+
+	It("should increment on every reconcile", func() {
+		apiClient := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		reconciler := NewSspReconciler(apiClient, apiClient, "", nil, fakeCrdList{}, DefaultFinalizerName, nil)
+		request := ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}}
+
+		initial := getReconcileTotal()
+		for i := 1; i <= 3; i++ {
+			_, err := reconciler.Reconcile(context.Background(), request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getReconcileTotal()).To(Equal(initial + float64(i)))
+		}
+	})
+})
+
+var _ = Describe("Common instancetypes synced condition", func() {
+	const instancetypesURL = "https://github.com/kubevirt/common-instancetypes//VirtualMachineClusterInstancetypes?ref=0123456789abcdef0123456789abcdef01234567"
+
+	var (
+		apiClient  client.Client
+		reconciler *sspReconciler
+		operand    *common_instancetypes.CommonInstancetypes
+		fetchErr   error
+	)
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+
+	reconcile := func() error {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		return err
+	}
+
+	getSyncedCondition := func() *conditionsv1.Condition {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		return conditionsv1.FindStatusCondition(sspObj.Status.Conditions, common_instancetypes.ConditionSynced)
+	}
+
+	BeforeEach(func() {
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		Expect(apiClient.Create(context.Background(), &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Finalizers: []string{DefaultFinalizerName},
+			},
+			Spec: ssp.SSPSpec{
+				CommonInstancetypes: &ssp.CommonInstancetypes{
+					URL: pointer.String(instancetypesURL),
+				},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		})).To(Succeed())
+
+		fetchErr = nil
+		operand = common_instancetypes.New("", "")
+		operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+			if fetchErr != nil {
+				return nil, fetchErr
+			}
+			return &common_instancetypes.MockResMap{}, nil
+		}
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{operand}, fakeCrdList{}, DefaultFinalizerName, nil)
+	})
+
+	It("should be true with the applied revision after successful sync", func() {
+		Expect(reconcile()).To(Succeed())
+
+		condition := getSyncedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("Synced"))
+		Expect(condition.Message).To(ContainSubstring("0123456789abcdef0123456789abcdef01234567"))
+	})
+
+	It("should be false when fetch fails", func() {
+		fetchErr = fmt.Errorf("repository not reachable")
+		Expect(reconcile()).To(MatchError(ContainSubstring("repository not reachable")))
+
+		condition := getSyncedCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("FetchFailed"))
+		Expect(condition.Message).To(ContainSubstring("repository not reachable"))
+	})
+
+	It("should retry failed fetch and become true", func() {
+		fetchErr = fmt.Errorf("repository not reachable")
+		Expect(reconcile()).ToNot(Succeed())
+		Expect(getSyncedCondition().Status).To(Equal(v1.ConditionFalse))
+
+		fetchErr = nil
+		Expect(reconcile()).To(Succeed())
+		Expect(getSyncedCondition().Status).To(Equal(v1.ConditionTrue))
+	})
+
+	It("should be removed when URL is unset", func() {
+		Expect(reconcile()).To(Succeed())
+		Expect(getSyncedCondition()).ToNot(BeNil())
+
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		sspObj.Spec.CommonInstancetypes = nil
+		Expect(apiClient.Update(context.Background(), sspObj)).To(Succeed())
+
+		// The bundle is not available in unit tests, so the reconciliation fails,
+		// but the condition is removed before the bundle is read.
+		_ = reconcile()
+		Expect(getSyncedCondition()).To(BeNil())
+	})
+})
+
+var _ = Describe("VM console proxy route readiness", func() {
+	var (
+		apiClient  client.Client
+		reconciler *sspReconciler
+		bundle     *vm_console_proxy_bundle.Bundle
+	)
+
+	key := client.ObjectKey{Namespace: "kubevirt", Name: "test-ssp"}
+	routeKey := client.ObjectKey{Namespace: "kubevirt", Name: "vm-console-proxy"}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getAvailableCondition := func() *conditionsv1.Condition {
+		sspObj := &ssp.SSP{}
+		Expect(apiClient.Get(context.Background(), key, sspObj)).To(Succeed())
+		return conditionsv1.FindStatusCondition(sspObj.Status.Conditions, conditionsv1.ConditionAvailable)
+	}
+
+	makeDeploymentAvailable := func() {
+		deployment := &apps.Deployment{}
+		Expect(apiClient.Get(context.Background(), client.ObjectKey{Namespace: "kubevirt", Name: bundle.Deployment.Name}, deployment)).To(Succeed())
+		deployment.Status.Replicas = *deployment.Spec.Replicas
+		deployment.Status.ReadyReplicas = *deployment.Spec.Replicas
+		deployment.Status.AvailableReplicas = *deployment.Spec.Replicas
+		deployment.Status.UpdatedReplicas = *deployment.Spec.Replicas
+		Expect(apiClient.Status().Update(context.Background(), deployment)).To(Succeed())
+	}
+
+	setRouteAdmitted := func(status v1.ConditionStatus) {
+		route := &routev1.Route{}
+		Expect(apiClient.Get(context.Background(), routeKey, route)).To(Succeed())
+		route.Status.Ingress = []routev1.RouteIngress{{
+			Host:       "vm-console-proxy.example.com",
+			RouterName: "default",
+			Conditions: []routev1.RouteIngressCondition{{
+				Type:   routev1.RouteAdmitted,
+				Status: status,
+			}},
+		}}
+		Expect(apiClient.Status().Update(context.Background(), route)).To(Succeed())
+	}
+
+	createSsp := func(annotations map[string]string) {
+		Expect(apiClient.Create(context.Background(), &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Annotations: annotations,
+				Finalizers:  []string{DefaultFinalizerName},
+			},
+			Status: ssp.SSPStatus{
+				Status: lifecycleapi.Status{
+					Phase: lifecycleapi.PhaseDeployed,
+				},
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		bundle, err = vm_console_proxy_bundle.ReadBundle("../data/vm-console-proxy-bundle/vm-console-proxy.yaml")
+		Expect(err).ToNot(HaveOccurred())
+
+		apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		reconciler = NewSspReconciler(apiClient, apiClient, "", []operands.Operand{vm_console_proxy.New(bundle)}, fakeCrdList{}, DefaultFinalizerName, nil)
+	})
+
+	It("should not be available until the route is admitted", func() {
+		createSsp(map[string]string{vm_console_proxy.EnableAnnotation: "true"})
+		reconcile()
+		makeDeploymentAvailable()
+		reconcile()
+
+		condition := getAvailableCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("Operand vm-console-proxy is not ready"))
+		Expect(condition.Message).To(ContainSubstring("Route is not yet admitted by a router"))
+
+		setRouteAdmitted(v1.ConditionTrue)
+		reconcile()
+
+		condition = getAvailableCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+	})
+
+	It("should not be available when the route is rejected", func() {
+		createSsp(map[string]string{vm_console_proxy.EnableAnnotation: "true"})
+		reconcile()
+		makeDeploymentAvailable()
+		setRouteAdmitted(v1.ConditionFalse)
+		reconcile()
+
+		condition := getAvailableCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionFalse))
+		Expect(condition.Message).To(ContainSubstring("Route is not admitted"))
+	})
+
+	It("should skip the route when the operand is disabled", func() {
+		createSsp(nil)
+		reconcile()
+
+		route := &routev1.Route{}
+		Expect(errors.IsNotFound(apiClient.Get(context.Background(), routeKey, route))).To(BeTrue())
+
+		condition := getAvailableCondition()
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(v1.ConditionTrue))
+	})
+})
+
+func TestControllers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Controllers Suite")
+}
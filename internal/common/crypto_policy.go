@@ -3,7 +3,10 @@ package common
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ocpv1 "github.com/openshift/api/config/v1"
@@ -132,3 +135,54 @@ func tlsVersionToHumanReadable(version ocpv1.TLSProtocolVersion) (string, error)
 		return "", fmt.Errorf("invalid ocpv1.VersionTLS %v", version)
 	}
 }
+
+// fipsEnabledPath contains "1" when the kernel runs in FIPS mode.
+// In a cluster installed in FIPS mode, all nodes run in FIPS mode.
+const fipsEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+// IsFIPSEnabled returns true if the node, where the operator runs, is in FIPS mode
+func IsFIPSEnabled() (bool, error) {
+	content, err := os.ReadFile(fipsEnabledPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(content)) == "1", nil
+}
+
+// fipsApprovedCiphers are the OpenSSL names of ciphers that can be used in FIPS mode
+var fipsApprovedCiphers = map[string]bool{
+	// TLS 1.3
+	"TLS_AES_128_GCM_SHA256": true,
+	"TLS_AES_256_GCM_SHA384": true,
+
+	// TLS 1.2
+	"ECDHE-ECDSA-AES128-GCM-SHA256": true,
+	"ECDHE-RSA-AES128-GCM-SHA256":   true,
+	"ECDHE-ECDSA-AES256-GCM-SHA384": true,
+	"ECDHE-RSA-AES256-GCM-SHA384":   true,
+	"AES128-GCM-SHA256":             true,
+	"AES256-GCM-SHA384":             true,
+}
+
+// NonFIPSCiphers returns the ciphers selected by an Old or Custom TLS profile, that cannot be used in FIPS mode.
+// The Intermediate and Modern profiles are supported in FIPS mode, their non-approved ciphers are skipped by the TLS stack.
+func NonFIPSCiphers(tlsSecurityProfile *ocpv1.TLSSecurityProfile) []string {
+	if tlsSecurityProfile == nil {
+		return nil
+	}
+	if tlsSecurityProfile.Type != ocpv1.TLSProfileOldType && tlsSecurityProfile.Type != ocpv1.TLSProfileCustomType {
+		return nil
+	}
+
+	ciphers, _ := selectCipherSuitesAndMinTLSVersion(tlsSecurityProfile)
+	var nonFIPSCiphers []string
+	for _, cipher := range ciphers {
+		if !fipsApprovedCiphers[cipher] {
+			nonFIPSCiphers = append(nonFIPSCiphers, cipher)
+		}
+	}
+	return nonFIPSCiphers
+}
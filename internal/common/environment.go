@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	osconfv1 "github.com/openshift/api/config/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,10 +26,35 @@ const (
 	TektonTasksDiskVirtImageKey = "TEKTON_TASKS_DISK_VIRT_IMG"
 	VirtioImageKey              = "VIRTIO_IMG"
 
+	DataImportCronMinIntervalKey  = "DATA_IMPORT_CRON_MIN_INTERVAL"
+	SSPMaxSpecSizeKey             = "SSP_MAX_SPEC_SIZE"
+	MaxAdditionalNamespacesKey    = "MAX_ADDITIONAL_NAMESPACES"
+	RequireImageDigestKey         = "REQUIRE_IMAGE_DIGEST"
+	MaxDataImportCronStorageKey   = "MAX_DATA_IMPORT_CRON_STORAGE"
+	MaxDataImportCronCreationsKey = "MAX_DATA_IMPORT_CRON_CREATIONS"
+	MaxConcurrentDataImportsKey   = "MAX_CONCURRENT_DATA_IMPORTS"
+
+	InstancetypeURLAllowedPortsKey = "INSTANCETYPE_URL_ALLOWED_PORTS"
+	InstancetypeURLPolicyKey       = "INSTANCETYPE_URL_POLICY"
+
+	DataImportCronActiveRequeueIntervalKey = "DATA_IMPORT_CRON_ACTIVE_REQUEUE_INTERVAL"
+	DataImportCronSteadyRequeueIntervalKey = "DATA_IMPORT_CRON_STEADY_REQUEUE_INTERVAL"
+	DataImportCronStartupDelayKey          = "DATA_IMPORT_CRON_STARTUP_DELAY"
+	DataImportCronMaxBackoffKey            = "DATA_IMPORT_CRON_MAX_BACKOFF"
+	DegradedGracePeriodKey                 = "DEGRADED_GRACE_PERIOD"
+
 	DefaultTektonTasksIMG         = "quay.io/kubevirt/tekton-tasks:" + TektonTasksVersion
 	DeafultTektonTasksDiskVirtIMG = "quay.io/kubevirt/tekton-tasks-disk-virt:" + TektonTasksVersion
 	DefaultVirtioIMG              = "quay.io/kubevirt/virtio-container-disk:v0.59.0"
 
+	DefaultDataImportCronMinInterval = time.Hour
+	DefaultSSPMaxSpecSize            = "1Mi"
+	DefaultMaxAdditionalNamespaces   = 20
+
+	DefaultDataImportCronActiveRequeueInterval = 10 * time.Second
+	DefaultDataImportCronSteadyRequeueInterval = 10 * time.Minute
+	DefaultDataImportCronMaxBackoff            = 5 * time.Minute
+
 	defaultOperatorVersion = "devel"
 )
 
@@ -44,6 +73,256 @@ func GetVirtioImage() string {
 	return EnvOrDefault(VirtioImageKey, DefaultVirtioIMG)
 }
 
+// GetDataImportCronMinInterval returns the minimum allowed interval between DataImportCron imports
+func GetDataImportCronMinInterval() (time.Duration, error) {
+	val := os.Getenv(DataImportCronMinIntervalKey)
+	if val == "" {
+		return DefaultDataImportCronMinInterval, nil
+	}
+	interval, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", DataImportCronMinIntervalKey, err)
+	}
+	return interval, nil
+}
+
+// GetDataImportCronActiveRequeueInterval returns the interval of reconciliation while DataImportCron imports are in progress
+func GetDataImportCronActiveRequeueInterval() (time.Duration, error) {
+	return getPositiveDuration(DataImportCronActiveRequeueIntervalKey, DefaultDataImportCronActiveRequeueInterval)
+}
+
+// GetDataImportCronSteadyRequeueInterval returns the interval of reconciliation while no DataImportCron imports are in progress
+func GetDataImportCronSteadyRequeueInterval() (time.Duration, error) {
+	return getPositiveDuration(DataImportCronSteadyRequeueIntervalKey, DefaultDataImportCronSteadyRequeueInterval)
+}
+
+// GetDataImportCronMaxBackoff returns the maximum delay before a DataImportCron, that failed to reconcile, is retried
+func GetDataImportCronMaxBackoff() (time.Duration, error) {
+	return getPositiveDuration(DataImportCronMaxBackoffKey, DefaultDataImportCronMaxBackoff)
+}
+
+// GetDataImportCronStartupDelay returns the delay between creations of DataImportCrons after installation,
+// or zero if the creations are not delayed
+func GetDataImportCronStartupDelay() (time.Duration, error) {
+	val := os.Getenv(DataImportCronStartupDelayKey)
+	if val == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", DataImportCronStartupDelayKey, err)
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("%s must not be negative", DataImportCronStartupDelayKey)
+	}
+	return delay, nil
+}
+
+// GetDegradedGracePeriod returns how long an operand has to be failing before the SSP is marked as degraded,
+// or zero if the SSP is marked as degraded immediately
+func GetDegradedGracePeriod() (time.Duration, error) {
+	val := os.Getenv(DegradedGracePeriodKey)
+	if val == "" {
+		return 0, nil
+	}
+	gracePeriod, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", DegradedGracePeriodKey, err)
+	}
+	if gracePeriod < 0 {
+		return 0, fmt.Errorf("%s must not be negative", DegradedGracePeriodKey)
+	}
+	return gracePeriod, nil
+}
+
+func getPositiveDuration(envName string, defVal time.Duration) (time.Duration, error) {
+	val := os.Getenv(envName)
+	if val == "" {
+		return defVal, nil
+	}
+	duration, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", envName, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be positive", envName)
+	}
+	return duration, nil
+}
+
+// GetSSPMaxSpecSize returns the maximum allowed size of the serialized SSP spec in bytes
+func GetSSPMaxSpecSize() (int64, error) {
+	val := EnvOrDefault(SSPMaxSpecSizeKey, DefaultSSPMaxSpecSize)
+	size, err := resource.ParseQuantity(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", SSPMaxSpecSizeKey, err)
+	}
+	return size.Value(), nil
+}
+
+// GetMaxAdditionalNamespaces returns the maximum number of namespaces, where DataSources can be replicated
+func GetMaxAdditionalNamespaces() (int, error) {
+	val := os.Getenv(MaxAdditionalNamespacesKey)
+	if val == "" {
+		return DefaultMaxAdditionalNamespaces, nil
+	}
+	maxNamespaces, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", MaxAdditionalNamespacesKey, err)
+	}
+	if maxNamespaces < 0 {
+		return 0, fmt.Errorf("%s must not be negative", MaxAdditionalNamespacesKey)
+	}
+	return maxNamespaces, nil
+}
+
+// GetRequireImageDigest returns true if golden images imported from a registry must be referenced by digest
+func GetRequireImageDigest() (bool, error) {
+	val := os.Getenv(RequireImageDigestKey)
+	if val == "" {
+		return false, nil
+	}
+	requireDigest, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", RequireImageDigestKey, err)
+	}
+	return requireDigest, nil
+}
+
+// GetMaxDataImportCronStorage returns the maximum storage a DataImportCronTemplate can request,
+// or nil if the storage request is not limited
+func GetMaxDataImportCronStorage() (*resource.Quantity, error) {
+	val := os.Getenv(MaxDataImportCronStorageKey)
+	if val == "" {
+		return nil, nil
+	}
+	maxStorage, err := resource.ParseQuantity(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", MaxDataImportCronStorageKey, err)
+	}
+	if maxStorage.Sign() <= 0 {
+		return nil, fmt.Errorf("%s must be positive", MaxDataImportCronStorageKey)
+	}
+	return &maxStorage, nil
+}
+
+// GetMaxDataImportCronCreations returns the maximum number of DataImportCrons created in one reconciliation,
+// or zero if the number is not limited
+func GetMaxDataImportCronCreations() (int, error) {
+	val := os.Getenv(MaxDataImportCronCreationsKey)
+	if val == "" {
+		return 0, nil
+	}
+	maxCreations, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", MaxDataImportCronCreationsKey, err)
+	}
+	if maxCreations < 0 {
+		return 0, fmt.Errorf("%s must not be negative", MaxDataImportCronCreationsKey)
+	}
+	return maxCreations, nil
+}
+
+// GetMaxConcurrentDataImports returns the maximum number of DataImportCron imports running at the same time,
+// or zero if the number is not limited
+func GetMaxConcurrentDataImports() (int, error) {
+	val := os.Getenv(MaxConcurrentDataImportsKey)
+	if val == "" {
+		return 0, nil
+	}
+	maxImports, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", MaxConcurrentDataImportsKey, err)
+	}
+	if maxImports < 0 {
+		return 0, fmt.Errorf("%s must not be negative", MaxConcurrentDataImportsKey)
+	}
+	return maxImports, nil
+}
+
+// PortRange is an inclusive range of network ports
+type PortRange struct {
+	Min int
+	Max int
+}
+
+func (p PortRange) Contains(port int) bool {
+	return port >= p.Min && port <= p.Max
+}
+
+func (p PortRange) String() string {
+	if p.Min == p.Max {
+		return strconv.Itoa(p.Min)
+	}
+	return fmt.Sprintf("%d-%d", p.Min, p.Max)
+}
+
+// GetInstancetypeURLAllowedPorts returns the ports allowed in the commonInstancetypes URL,
+// or nil if the ports are not restricted. The value is a comma separated list of ports
+// and port ranges, for example "22,443,8443-8445".
+func GetInstancetypeURLAllowedPorts() ([]PortRange, error) {
+	val := os.Getenv(InstancetypeURLAllowedPortsKey)
+	if val == "" {
+		return nil, nil
+	}
+
+	var portRanges []PortRange
+	for _, item := range strings.Split(val, ",") {
+		portRange, err := parsePortRange(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", InstancetypeURLAllowedPortsKey, err)
+		}
+		portRanges = append(portRanges, portRange)
+	}
+	return portRanges, nil
+}
+
+// GetInstancetypeURLPolicy returns the regular expression, that the commonInstancetypes URL has to match,
+// or nil if the URL is not restricted. The expression has to match the whole URL.
+func GetInstancetypeURLPolicy() (*regexp.Regexp, error) {
+	val := os.Getenv(InstancetypeURLPolicyKey)
+	if val == "" {
+		return nil, nil
+	}
+
+	policy, err := regexp.Compile("^(?:" + val + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", InstancetypeURLPolicyKey, err)
+	}
+	return policy, nil
+}
+
+func parsePortRange(val string) (PortRange, error) {
+	minStr, maxStr, isRange := strings.Cut(val, "-")
+	if !isRange {
+		maxStr = minStr
+	}
+
+	minPort, err := parsePort(minStr)
+	if err != nil {
+		return PortRange{}, err
+	}
+	maxPort, err := parsePort(maxStr)
+	if err != nil {
+		return PortRange{}, err
+	}
+	if minPort > maxPort {
+		return PortRange{}, fmt.Errorf("invalid port range %q, the first port must not be greater than the last port", val)
+	}
+	return PortRange{Min: minPort, Max: maxPort}, nil
+}
+
+func parsePort(val string) (int, error) {
+	port, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q: %w", val, err)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %d, it must be between 1 and 65535", port)
+	}
+	return port, nil
+}
+
 func EnvOrDefault(envName string, defVal string) string {
 	val := os.Getenv(envName)
 	if val == "" {
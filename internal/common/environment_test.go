@@ -2,9 +2,12 @@ package common
 
 import (
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var _ = Describe("environments", func() {
@@ -46,4 +49,311 @@ var _ = Describe("environments", func() {
 		Expect(res).To(Equal(DeafultTektonTasksDiskVirtIMG), "TEKTON_TASKS_DISK_VIRT_IMG should equal")
 	})
 
+	It("should return correct value for DATA_IMPORT_CRON_MIN_INTERVAL when variable is set", func() {
+		os.Setenv(DataImportCronMinIntervalKey, "30m")
+		res, err := GetDataImportCronMinInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(30*time.Minute), "DATA_IMPORT_CRON_MIN_INTERVAL should equal")
+		os.Unsetenv(DataImportCronMinIntervalKey)
+	})
+
+	It("should return correct value for DATA_IMPORT_CRON_MIN_INTERVAL when variable is not set", func() {
+		res, err := GetDataImportCronMinInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(DefaultDataImportCronMinInterval), "DATA_IMPORT_CRON_MIN_INTERVAL should equal")
+	})
+
+	It("should return error for invalid DATA_IMPORT_CRON_MIN_INTERVAL", func() {
+		os.Setenv(DataImportCronMinIntervalKey, "not-a-duration")
+		_, err := GetDataImportCronMinInterval()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(DataImportCronMinIntervalKey)
+	})
+
+	It("should return correct value for MAX_ADDITIONAL_NAMESPACES when variable is set", func() {
+		os.Setenv(MaxAdditionalNamespacesKey, "5")
+		res, err := GetMaxAdditionalNamespaces()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(5), "MAX_ADDITIONAL_NAMESPACES should equal")
+		os.Unsetenv(MaxAdditionalNamespacesKey)
+	})
+
+	It("should return correct value for MAX_ADDITIONAL_NAMESPACES when variable is not set", func() {
+		res, err := GetMaxAdditionalNamespaces()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(DefaultMaxAdditionalNamespaces), "MAX_ADDITIONAL_NAMESPACES should equal")
+	})
+
+	It("should return error for invalid MAX_ADDITIONAL_NAMESPACES", func() {
+		os.Setenv(MaxAdditionalNamespacesKey, "-1")
+		_, err := GetMaxAdditionalNamespaces()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(MaxAdditionalNamespacesKey, "many")
+		_, err = GetMaxAdditionalNamespaces()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(MaxAdditionalNamespacesKey)
+	})
+
+	It("should return correct value for REQUIRE_IMAGE_DIGEST when variable is set", func() {
+		os.Setenv(RequireImageDigestKey, "true")
+		res, err := GetRequireImageDigest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeTrue(), "REQUIRE_IMAGE_DIGEST should equal")
+		os.Unsetenv(RequireImageDigestKey)
+	})
+
+	It("should return false for REQUIRE_IMAGE_DIGEST when variable is not set", func() {
+		res, err := GetRequireImageDigest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeFalse(), "REQUIRE_IMAGE_DIGEST should equal")
+	})
+
+	It("should return error for invalid REQUIRE_IMAGE_DIGEST", func() {
+		os.Setenv(RequireImageDigestKey, "maybe")
+		_, err := GetRequireImageDigest()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(RequireImageDigestKey)
+	})
+
+	It("should return correct value for MAX_DATA_IMPORT_CRON_STORAGE when variable is set", func() {
+		os.Setenv(MaxDataImportCronStorageKey, "50Gi")
+		res, err := GetMaxDataImportCronStorage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Equal(resource.MustParse("50Gi"))).To(BeTrue(), "MAX_DATA_IMPORT_CRON_STORAGE should equal")
+		os.Unsetenv(MaxDataImportCronStorageKey)
+	})
+
+	It("should return nil for MAX_DATA_IMPORT_CRON_STORAGE when variable is not set", func() {
+		res, err := GetMaxDataImportCronStorage()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil(), "MAX_DATA_IMPORT_CRON_STORAGE should be nil")
+	})
+
+	It("should return error for invalid MAX_DATA_IMPORT_CRON_STORAGE", func() {
+		os.Setenv(MaxDataImportCronStorageKey, "0")
+		_, err := GetMaxDataImportCronStorage()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(MaxDataImportCronStorageKey, "lots")
+		_, err = GetMaxDataImportCronStorage()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(MaxDataImportCronStorageKey)
+	})
+
+	It("should return correct value for MAX_DATA_IMPORT_CRON_CREATIONS when variable is set", func() {
+		os.Setenv(MaxDataImportCronCreationsKey, "3")
+		res, err := GetMaxDataImportCronCreations()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(3), "MAX_DATA_IMPORT_CRON_CREATIONS should equal")
+		os.Unsetenv(MaxDataImportCronCreationsKey)
+	})
+
+	It("should return zero for MAX_DATA_IMPORT_CRON_CREATIONS when variable is not set", func() {
+		res, err := GetMaxDataImportCronCreations()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeZero(), "MAX_DATA_IMPORT_CRON_CREATIONS should be zero")
+	})
+
+	It("should return error for invalid MAX_DATA_IMPORT_CRON_CREATIONS", func() {
+		os.Setenv(MaxDataImportCronCreationsKey, "-1")
+		_, err := GetMaxDataImportCronCreations()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(MaxDataImportCronCreationsKey, "some")
+		_, err = GetMaxDataImportCronCreations()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(MaxDataImportCronCreationsKey)
+	})
+
+	It("should return correct value for MAX_CONCURRENT_DATA_IMPORTS when variable is set", func() {
+		os.Setenv(MaxConcurrentDataImportsKey, "2")
+		res, err := GetMaxConcurrentDataImports()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(2), "MAX_CONCURRENT_DATA_IMPORTS should equal")
+		os.Unsetenv(MaxConcurrentDataImportsKey)
+	})
+
+	It("should return zero for MAX_CONCURRENT_DATA_IMPORTS when variable is not set", func() {
+		res, err := GetMaxConcurrentDataImports()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeZero(), "MAX_CONCURRENT_DATA_IMPORTS should be zero")
+	})
+
+	It("should return error for invalid MAX_CONCURRENT_DATA_IMPORTS", func() {
+		os.Setenv(MaxConcurrentDataImportsKey, "-1")
+		_, err := GetMaxConcurrentDataImports()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(MaxConcurrentDataImportsKey, "some")
+		_, err = GetMaxConcurrentDataImports()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(MaxConcurrentDataImportsKey)
+	})
+
+	It("should return correct value for INSTANCETYPE_URL_ALLOWED_PORTS when variable is set", func() {
+		os.Setenv(InstancetypeURLAllowedPortsKey, "22, 443,8443-8445")
+		res, err := GetInstancetypeURLAllowedPorts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal([]PortRange{{Min: 22, Max: 22}, {Min: 443, Max: 443}, {Min: 8443, Max: 8445}}))
+		os.Unsetenv(InstancetypeURLAllowedPortsKey)
+	})
+
+	It("should return nil for INSTANCETYPE_URL_ALLOWED_PORTS when variable is not set", func() {
+		res, err := GetInstancetypeURLAllowedPorts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+	})
+
+	It("should return error for invalid INSTANCETYPE_URL_ALLOWED_PORTS", func() {
+		for _, val := range []string{"some", "0", "65536", "443,", "8445-8443", "8443-"} {
+			os.Setenv(InstancetypeURLAllowedPortsKey, val)
+			_, err := GetInstancetypeURLAllowedPorts()
+			Expect(err).To(HaveOccurred(), "value %q should be invalid", val)
+		}
+		os.Unsetenv(InstancetypeURLAllowedPortsKey)
+	})
+
+	It("should return anchored policy for INSTANCETYPE_URL_POLICY when variable is set", func() {
+		os.Setenv(InstancetypeURLPolicyKey, `https://github\.com/kubevirt/.*|oci://quay\.io/kubevirt/.*`)
+		res, err := GetInstancetypeURLPolicy()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.MatchString("https://github.com/kubevirt/common-instancetypes?ref=v1")).To(BeTrue())
+		Expect(res.MatchString("oci://quay.io/kubevirt/common-instancetypes@sha256:1234")).To(BeTrue())
+		Expect(res.MatchString("https://example.com/?https://github.com/kubevirt/")).To(BeFalse())
+		os.Unsetenv(InstancetypeURLPolicyKey)
+	})
+
+	It("should return nil for INSTANCETYPE_URL_POLICY when variable is not set", func() {
+		res, err := GetInstancetypeURLPolicy()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeNil())
+	})
+
+	It("should return error for invalid INSTANCETYPE_URL_POLICY", func() {
+		os.Setenv(InstancetypeURLPolicyKey, "https://(github.com")
+		_, err := GetInstancetypeURLPolicy()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(InstancetypeURLPolicyKey)
+	})
+
+	It("should return correct values for DataImportCron requeue intervals when variables are set", func() {
+		os.Setenv(DataImportCronActiveRequeueIntervalKey, "5s")
+		os.Setenv(DataImportCronSteadyRequeueIntervalKey, "1h")
+		active, err := GetDataImportCronActiveRequeueInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(active).To(Equal(5*time.Second), "DATA_IMPORT_CRON_ACTIVE_REQUEUE_INTERVAL should equal")
+		steady, err := GetDataImportCronSteadyRequeueInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(steady).To(Equal(time.Hour), "DATA_IMPORT_CRON_STEADY_REQUEUE_INTERVAL should equal")
+		os.Unsetenv(DataImportCronActiveRequeueIntervalKey)
+		os.Unsetenv(DataImportCronSteadyRequeueIntervalKey)
+	})
+
+	It("should return default values for DataImportCron requeue intervals when variables are not set", func() {
+		active, err := GetDataImportCronActiveRequeueInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(active).To(Equal(DefaultDataImportCronActiveRequeueInterval))
+		steady, err := GetDataImportCronSteadyRequeueInterval()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(steady).To(Equal(DefaultDataImportCronSteadyRequeueInterval))
+	})
+
+	It("should return error for invalid DataImportCron requeue intervals", func() {
+		os.Setenv(DataImportCronActiveRequeueIntervalKey, "0s")
+		_, err := GetDataImportCronActiveRequeueInterval()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(DataImportCronSteadyRequeueIntervalKey, "often")
+		_, err = GetDataImportCronSteadyRequeueInterval()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(DataImportCronActiveRequeueIntervalKey)
+		os.Unsetenv(DataImportCronSteadyRequeueIntervalKey)
+	})
+
+	It("should return correct value for DATA_IMPORT_CRON_MAX_BACKOFF when variable is set", func() {
+		os.Setenv(DataImportCronMaxBackoffKey, "30s")
+		res, err := GetDataImportCronMaxBackoff()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(30*time.Second), "DATA_IMPORT_CRON_MAX_BACKOFF should equal")
+		os.Unsetenv(DataImportCronMaxBackoffKey)
+	})
+
+	It("should return default value for DATA_IMPORT_CRON_MAX_BACKOFF when variable is not set", func() {
+		res, err := GetDataImportCronMaxBackoff()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(DefaultDataImportCronMaxBackoff))
+	})
+
+	It("should return error for invalid DATA_IMPORT_CRON_MAX_BACKOFF", func() {
+		os.Setenv(DataImportCronMaxBackoffKey, "0s")
+		_, err := GetDataImportCronMaxBackoff()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(DataImportCronMaxBackoffKey)
+	})
+
+	It("should return correct value for DATA_IMPORT_CRON_STARTUP_DELAY when variable is set", func() {
+		os.Setenv(DataImportCronStartupDelayKey, "2m")
+		res, err := GetDataImportCronStartupDelay()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(2*time.Minute), "DATA_IMPORT_CRON_STARTUP_DELAY should equal")
+		os.Unsetenv(DataImportCronStartupDelayKey)
+	})
+
+	It("should return zero for DATA_IMPORT_CRON_STARTUP_DELAY when variable is not set", func() {
+		res, err := GetDataImportCronStartupDelay()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeZero(), "DATA_IMPORT_CRON_STARTUP_DELAY should be zero")
+	})
+
+	It("should return error for invalid DATA_IMPORT_CRON_STARTUP_DELAY", func() {
+		os.Setenv(DataImportCronStartupDelayKey, "-1m")
+		_, err := GetDataImportCronStartupDelay()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(DataImportCronStartupDelayKey, "later")
+		_, err = GetDataImportCronStartupDelay()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(DataImportCronStartupDelayKey)
+	})
+
+	It("should return correct value for DEGRADED_GRACE_PERIOD when variable is set", func() {
+		os.Setenv(DegradedGracePeriodKey, "5m")
+		res, err := GetDegradedGracePeriod()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(5*time.Minute), "DEGRADED_GRACE_PERIOD should equal")
+		os.Unsetenv(DegradedGracePeriodKey)
+	})
+
+	It("should return zero for DEGRADED_GRACE_PERIOD when variable is not set", func() {
+		res, err := GetDegradedGracePeriod()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(BeZero(), "DEGRADED_GRACE_PERIOD should be zero")
+	})
+
+	It("should return error for invalid DEGRADED_GRACE_PERIOD", func() {
+		os.Setenv(DegradedGracePeriodKey, "-1m")
+		_, err := GetDegradedGracePeriod()
+		Expect(err).To(HaveOccurred())
+		os.Setenv(DegradedGracePeriodKey, "soon")
+		_, err = GetDegradedGracePeriod()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(DegradedGracePeriodKey)
+	})
+
+	It("should return correct value for SSP_MAX_SPEC_SIZE when variable is set", func() {
+		os.Setenv(SSPMaxSpecSizeKey, "2Ki")
+		res, err := GetSSPMaxSpecSize()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(int64(2048)), "SSP_MAX_SPEC_SIZE should equal")
+		os.Unsetenv(SSPMaxSpecSizeKey)
+	})
+
+	It("should return correct value for SSP_MAX_SPEC_SIZE when variable is not set", func() {
+		res, err := GetSSPMaxSpecSize()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res).To(Equal(int64(1024*1024)), "SSP_MAX_SPEC_SIZE should equal")
+	})
+
+	It("should return error for invalid SSP_MAX_SPEC_SIZE", func() {
+		os.Setenv(SSPMaxSpecSizeKey, "not-a-size")
+		_, err := GetSSPMaxSpecSize()
+		Expect(err).To(HaveOccurred())
+		os.Unsetenv(SSPMaxSpecSizeKey)
+	})
+
 })
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// imageRegistryPattern matches a registry host with an optional port and an optional path,
+// for example "registry.example.com:5000/mirror"
+var imageRegistryPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]*[a-zA-Z0-9])?(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+
+// ValidateImageRegistry checks that the registry can be used as a prefix of image references
+func ValidateImageRegistry(registry string) error {
+	if !imageRegistryPattern.MatchString(registry) {
+		return fmt.Errorf("invalid image registry %q, expected a registry host with an optional port and path, "+
+			"without a scheme, tag or digest, for example \"registry.example.com:5000/mirror\"", registry)
+	}
+	return nil
+}
+
+// imageReferencePattern matches an image reference with an optional registry, tag and digest,
+// following the grammar of github.com/distribution/reference
+var imageReferencePattern = regexp.MustCompile(`^` +
+	// Optional registry host with port
+	`(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?/)?` +
+	// Repository path
+	`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+	// Optional tag
+	`(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?` +
+	// Optional digest
+	`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?$`)
+
+// ValidateImageReference checks that the image is a well-formed image reference
+func ValidateImageReference(image string) error {
+	if !imageReferencePattern.MatchString(image) {
+		return fmt.Errorf("invalid image reference %q, expected a repository with an optional tag or digest, "+
+			"for example \"registry.example.com/kubevirt/image:v1.0.0\"", image)
+	}
+	return nil
+}
+
+// OverrideImageRegistry replaces the registry of the image reference with the passed registry.
+// If the image reference does not contain a registry, the passed registry is prepended.
+// The image is returned unchanged if the registry is empty.
+func OverrideImageRegistry(image string, registry string) string {
+	if registry == "" || image == "" {
+		return image
+	}
+	return registry + "/" + stripImageRegistry(image)
+}
+
+func stripImageRegistry(image string) string {
+	firstComponent, rest, found := strings.Cut(image, "/")
+	if !found {
+		return image
+	}
+	// The same heuristic as used by docker to distinguish a registry host from a repository path
+	if strings.ContainsAny(firstComponent, ".:") || firstComponent == "localhost" {
+		return rest
+	}
+	return image
+}
+
+// ComponentImage returns the image of an SSP component with applied registry override.
+func (r *Request) ComponentImage(image string) string {
+	return OverrideImageRegistry(image, r.Instance.Spec.ImageRegistryOverride)
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image registry override", func() {
+	DescribeTable("should rewrite image registry", func(image, registry, expected string) {
+		Expect(OverrideImageRegistry(image, registry)).To(Equal(expected))
+	},
+		Entry("with registry", "quay.io/kubevirt/validator:v1", "mirror.example.com", "mirror.example.com/kubevirt/validator:v1"),
+		Entry("with registry and port", "localhost:5000/kubevirt/validator:v1", "mirror.example.com", "mirror.example.com/kubevirt/validator:v1"),
+		Entry("with localhost registry", "localhost/kubevirt/validator:v1", "mirror.example.com", "mirror.example.com/kubevirt/validator:v1"),
+		Entry("with digest", "quay.io/kubevirt/validator@sha256:abcd", "mirror.example.com", "mirror.example.com/kubevirt/validator@sha256:abcd"),
+		Entry("without registry", "kubevirt/validator:v1", "mirror.example.com", "mirror.example.com/kubevirt/validator:v1"),
+		Entry("without registry and path", "validator:v1", "mirror.example.com", "mirror.example.com/validator:v1"),
+		Entry("with registry path", "quay.io/kubevirt/validator:v1", "mirror.example.com:5000/ns", "mirror.example.com:5000/ns/kubevirt/validator:v1"),
+		Entry("with empty registry", "quay.io/kubevirt/validator:v1", "", "quay.io/kubevirt/validator:v1"),
+		Entry("with empty image", "", "mirror.example.com", ""),
+	)
+
+	DescribeTable("should accept valid registry", func(registry string) {
+		Expect(ValidateImageRegistry(registry)).To(Succeed())
+	},
+		Entry("host", "mirror.example.com"),
+		Entry("host and port", "mirror.example.com:5000"),
+		Entry("host, port and path", "mirror.example.com:5000/kubevirt/mirror"),
+		Entry("localhost", "localhost"),
+	)
+
+	DescribeTable("should reject invalid registry", func(registry string) {
+		Expect(ValidateImageRegistry(registry)).ToNot(Succeed())
+	},
+		Entry("empty", ""),
+		Entry("with scheme", "https://mirror.example.com"),
+		Entry("with trailing slash", "mirror.example.com/"),
+		Entry("with tag", "mirror.example.com/image:v1"),
+		Entry("with digest", "mirror.example.com/image@sha256:abcd"),
+		Entry("with upper case path", "mirror.example.com/Mirror"),
+	)
+
+	const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	DescribeTable("should accept valid image reference", func(image string) {
+		Expect(ValidateImageReference(image)).To(Succeed())
+	},
+		Entry("with registry and tag", "quay.io/kubevirt/validator:v1.0.0"),
+		Entry("with registry port", "localhost:5000/kubevirt/validator:v1"),
+		Entry("with digest", "quay.io/kubevirt/validator@"+testDigest),
+		Entry("with tag and digest", "quay.io/kubevirt/validator:v1@"+testDigest),
+		Entry("without registry", "kubevirt/validator"),
+		Entry("without registry and path", "validator:latest"),
+	)
+
+	DescribeTable("should reject invalid image reference", func(image string) {
+		Expect(ValidateImageReference(image)).ToNot(Succeed())
+	},
+		Entry("empty", ""),
+		Entry("with empty repository", "quay.io/:v1"),
+		Entry("with only tag", ":v1"),
+		Entry("with empty tag", "quay.io/kubevirt/validator:"),
+		Entry("with invalid tag", "quay.io/kubevirt/validator:-v1"),
+		Entry("with too short digest", "quay.io/kubevirt/validator@sha256:abcd"),
+		Entry("with digest without algorithm", "quay.io/kubevirt/validator@0123456789abcdef0123456789abcdef"),
+		Entry("with upper case repository", "quay.io/kubevirt/Validator:v1"),
+		Entry("with scheme", "https://quay.io/kubevirt/validator:v1"),
+	)
+})
@@ -14,6 +14,9 @@ const (
 	AppKubernetesManagedByLabel = "app.kubernetes.io/managed-by"
 	AppKubernetesComponentLabel = "app.kubernetes.io/component"
 
+	// CustomManagedByLabel is set to the value configured in the SSP CR, in addition to AppKubernetesManagedByLabel
+	CustomManagedByLabel = "ssp.kubevirt.io/managed-by"
+
 	AppComponentTektonPipelines       AppComponent = "tektonPipelines"
 	AppComponentTektonTasks           AppComponent = "tektonTasks"
 	AppKubernetesManagedByValue       string       = "ssp-operator"
@@ -27,9 +30,10 @@ func (a AppComponent) String() string {
 }
 
 const (
-	AppComponentMonitoring AppComponent = "monitoring"
-	AppComponentSchedule   AppComponent = "schedule"
-	AppComponentTemplating AppComponent = "templating"
+	AppComponentMonitoring    AppComponent = "monitoring"
+	AppComponentSchedule      AppComponent = "schedule"
+	AppComponentTemplating    AppComponent = "templating"
+	AppComponentConfiguration AppComponent = "configuration"
 )
 
 // AddAppLabels to the provided obj
@@ -43,10 +47,42 @@ func AddAppLabels(requestInstance *ssp.SSP, name string, component AppComponent,
 	labels[AppKubernetesNameLabel] = name
 	labels[AppKubernetesComponentLabel] = component.String()
 	labels[AppKubernetesManagedByLabel] = AppKubernetesManagedByValue
+	if managedByValue := requestInstance.Spec.ManagedByLabelValue; managedByValue != "" {
+		labels[CustomManagedByLabel] = managedByValue
+	}
 
 	return obj
 }
 
+// addCommonMetadata adds labels and annotations from the CommonMetadata of the SSP CR to the found object.
+// Labels and annotations of the expected object are set by the operator, so they are not overwritten.
+func addCommonMetadata(requestInstance *ssp.SSP, expected, found client.Object) {
+	commonMetadata := requestInstance.Spec.CommonMetadata
+	if commonMetadata == nil {
+		return
+	}
+	found.SetLabels(mergeCommonMetadata(commonMetadata.Labels, expected.GetLabels(), found.GetLabels()))
+	found.SetAnnotations(mergeCommonMetadata(commonMetadata.Annotations, expected.GetAnnotations(), found.GetAnnotations()))
+}
+
+// mergeCommonMetadata returns a copy of the found map with the common values, that are not in the expected map.
+// A copy is returned, because the found map can be shared with the expected object.
+func mergeCommonMetadata(common, expected, found map[string]string) map[string]string {
+	if len(common) == 0 {
+		return found
+	}
+	merged := make(map[string]string, len(found)+len(common))
+	for key, value := range found {
+		merged[key] = value
+	}
+	for key, value := range common {
+		if _, isExpected := expected[key]; !isExpected {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
 func getOrCreateLabels(obj client.Object) map[string]string {
 	labels := obj.GetLabels()
 	if labels == nil {
@@ -75,4 +75,18 @@ var _ = Describe("AddAppLabels", func() {
 		labels := obj.GetLabels()
 		Expect(labels[AppKubernetesManagedByLabel]).To(Equal("ssp-operator"))
 	})
+
+	It("adds custom managed-by label", func() {
+		request.Instance.Spec.ManagedByLabelValue = "test-tooling"
+		obj := AddAppLabels(request.Instance, "test", AppComponent("testing"), &v1.ConfigMap{})
+
+		labels := obj.GetLabels()
+		Expect(labels[CustomManagedByLabel]).To(Equal("test-tooling"))
+		Expect(labels[AppKubernetesManagedByLabel]).To(Equal("ssp-operator"))
+	})
+
+	It("does not add custom managed-by label if not configured", func() {
+		obj := AddAppLabels(request.Instance, "test", AppComponent("testing"), &v1.ConfigMap{})
+		Expect(obj.GetLabels()).ToNot(HaveKey(CustomManagedByLabel))
+	})
 })
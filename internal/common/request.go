@@ -2,9 +2,11 @@ package common
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	osconfv1 "github.com/openshift/api/config/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -23,8 +25,30 @@ type Request struct {
 	TopologyMode   osconfv1.TopologyMode
 
 	CrdList crd_watch.CrdList
+
+	// EventRecorder records events on the SSP CR
+	EventRecorder record.EventRecorder
+
+	requeueAfter time.Duration
 }
 
 func (r *Request) IsSingleReplicaTopologyMode() bool {
 	return r.TopologyMode == osconfv1.SingleReplicaTopologyMode
 }
+
+// RequeueAfter requests that the reconciliation is repeated after the duration.
+// If it is called multiple times, the shortest duration is used.
+func (r *Request) RequeueAfter(after time.Duration) {
+	if after <= 0 {
+		return
+	}
+	if r.requeueAfter == 0 || after < r.requeueAfter {
+		r.requeueAfter = after
+	}
+}
+
+// GetRequeueAfter returns the duration after which the reconciliation should be repeated,
+// or zero if no requeue was requested.
+func (r *Request) GetRequeueAfter() time.Duration {
+	return r.requeueAfter
+}
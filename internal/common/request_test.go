@@ -0,0 +1,30 @@
+package common
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request", func() {
+	It("should not requeue by default", func() {
+		request := &Request{}
+		Expect(request.GetRequeueAfter()).To(BeZero())
+	})
+
+	It("should requeue after shortest requested duration", func() {
+		request := &Request{}
+		request.RequeueAfter(time.Minute)
+		request.RequeueAfter(time.Second)
+		request.RequeueAfter(time.Hour)
+		Expect(request.GetRequeueAfter()).To(Equal(time.Second))
+	})
+
+	It("should ignore non-positive duration", func() {
+		request := &Request{}
+		request.RequeueAfter(time.Minute)
+		request.RequeueAfter(0)
+		Expect(request.GetRequeueAfter()).To(Equal(time.Minute))
+	})
+})
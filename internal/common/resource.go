@@ -118,6 +118,14 @@ var (
 		Name: "ssp_operator_reconciling_properly",
 		Help: "Set to 1 if the reconcile process of all operands completes with no errors, and to 0 otherwise",
 	})
+	SSPReconcileTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kubevirt_ssp_reconcile_total",
+		Help: "The total number of reconciliations of the SSP CR started by the operator",
+	})
+	SSPStartTimeSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kubevirt_ssp_start_time_seconds",
+		Help: "The time when the operator was started, in seconds since the Unix epoch",
+	})
 )
 
 func (r *reconcileBuilder) NamespacedResource(resource client.Object) ReconcileBuilder {
@@ -190,6 +198,7 @@ func (r *reconcileBuilder) Reconcile() (ReconcileResult, error) {
 			// operator needs to update the resource
 			r.updateFunc(r.resource, found)
 		}
+		addCommonMetadata(r.request.Instance, r.resource, found)
 		return nil
 	}
 
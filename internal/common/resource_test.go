@@ -114,6 +114,103 @@ var _ = Describe("Resource", func() {
 			Expect(found.GetAnnotations()).To(HaveKey(libhandler.NamespacedNameAnnotation))
 		})
 
+		It("should set custom managed-by label", func() {
+			request.Instance.Spec.ManagedByLabelValue = "test-tooling"
+			_, err := CreateOrUpdate(&request).
+				NamespacedResource(newTestResource(namespace)).
+				WithAppLabels("test-operand", AppComponent("testing")).
+				Reconcile()
+			Expect(err).ToNot(HaveOccurred())
+
+			key := client.ObjectKeyFromObject(newTestResource(namespace))
+			found := &v1.Service{}
+			Expect(request.Client.Get(request.Context, key, found)).To(Succeed())
+
+			Expect(found.GetLabels()).To(HaveKeyWithValue(CustomManagedByLabel, "test-tooling"))
+			Expect(found.GetLabels()).To(HaveKeyWithValue(AppKubernetesManagedByLabel, AppKubernetesManagedByValue))
+		})
+
+		Context("common metadata", func() {
+			BeforeEach(func() {
+				request.Instance.Spec.CommonMetadata = &ssp.CommonMetadata{
+					Labels: map[string]string{
+						"example.com/cost-center": "1234",
+						"test-label":              "common-value",
+						AppKubernetesNameLabel:    "common-value",
+					},
+					Annotations: map[string]string{
+						"example.com/owner": "platform-team",
+						"test-annotation":   "common-value",
+					},
+				}
+			})
+
+			getFound := func() *v1.Service {
+				found := &v1.Service{}
+				ExpectWithOffset(1, request.Client.Get(request.Context, client.ObjectKeyFromObject(newTestResource(namespace)), found)).To(Succeed())
+				return found
+			}
+
+			It("should add common labels and annotations to created resource", func() {
+				_, err := CreateOrUpdate(&request).
+					NamespacedResource(newTestResource(namespace)).
+					WithAppLabels("test-operand", AppComponent("testing")).
+					Reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				found := getFound()
+				Expect(found.GetLabels()).To(HaveKeyWithValue("example.com/cost-center", "1234"))
+				Expect(found.GetAnnotations()).To(HaveKeyWithValue("example.com/owner", "platform-team"))
+			})
+
+			It("should not overwrite labels and annotations set by the operator", func() {
+				_, err := CreateOrUpdate(&request).
+					NamespacedResource(newTestResource(namespace)).
+					WithAppLabels("test-operand", AppComponent("testing")).
+					Reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				found := getFound()
+				Expect(found.GetLabels()).To(HaveKeyWithValue("test-label", "value1"))
+				Expect(found.GetLabels()).To(HaveKeyWithValue(AppKubernetesNameLabel, "test-operand"))
+				Expect(found.GetAnnotations()).To(HaveKeyWithValue("test-annotation", "value2"))
+			})
+
+			It("should add common labels and annotations to existing resource", func() {
+				Expect(request.Client.Create(request.Context, newTestResource(namespace))).To(Succeed())
+				request.VersionCache.Add(getFound())
+
+				_, err := createOrUpdateTestResource(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				found := getFound()
+				Expect(found.GetLabels()).To(HaveKeyWithValue("example.com/cost-center", "1234"))
+				Expect(found.GetAnnotations()).To(HaveKeyWithValue("example.com/owner", "platform-team"))
+			})
+
+			It("should update changed common label", func() {
+				_, err := createOrUpdateTestResource(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				request.Instance.Spec.CommonMetadata.Labels["example.com/cost-center"] = "5678"
+				_, err = createOrUpdateTestResource(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(getFound().GetLabels()).To(HaveKeyWithValue("example.com/cost-center", "5678"))
+			})
+
+			It("should not modify the expected resource", func() {
+				resource := newTestResource(namespace)
+				_, err := CreateOrUpdate(&request).
+					NamespacedResource(resource).
+					Reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(resource.GetLabels()).ToNot(HaveKey("example.com/cost-center"))
+				Expect(resource.GetAnnotations()).ToNot(HaveKey("example.com/owner"))
+			})
+		})
+
 		It("should not update resource with cached version", func() {
 			resource := newTestResource(namespace)
 			resource.Spec.Ports[0].Name = "changed-name"
@@ -11,7 +11,9 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	instancetypev1alpha2 "kubevirt.io/api/instancetype/v1alpha2"
+	sspv1beta1 "kubevirt.io/ssp-operator/api/v1beta1"
 	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	sspv1beta3 "kubevirt.io/ssp-operator/api/v1beta3"
 )
 
 var (
@@ -23,7 +25,9 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
 	utilruntime.Must(extv1.AddToScheme(Scheme))
 	utilruntime.Must(internalmeta.AddToScheme(Scheme))
+	utilruntime.Must(sspv1beta1.AddToScheme(Scheme))
 	utilruntime.Must(ssp.AddToScheme(Scheme))
+	utilruntime.Must(sspv1beta3.AddToScheme(Scheme))
 	utilruntime.Must(osconfv1.Install(Scheme))
 	utilruntime.Must(instancetypev1alpha2.AddToScheme(Scheme))
 }
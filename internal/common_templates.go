@@ -0,0 +1,13 @@
+package internal
+
+import (
+	"k8s.io/utils/pointer"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+// IsCommonTemplatesEnabled returns true if common templates are managed by the operator.
+// They are enabled, unless they are explicitly disabled in the SSP CR.
+func IsCommonTemplatesEnabled(sspObj *ssp.SSP) bool {
+	return pointer.BoolDeref(sspObj.Spec.CommonTemplates.Enabled, true)
+}
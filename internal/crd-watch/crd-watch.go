@@ -23,8 +23,12 @@ type CrdWatch struct {
 	AllCrdsAddedHandler   func()
 	SomeCrdRemovedHandler func()
 
+	// OptionalCrdChangedHandler is called when one of the optional CRDs is added or removed
+	OptionalCrdChangedHandler func()
+
 	lock         sync.Mutex
 	requiredCrds map[string]struct{}
+	optionalCrds map[string]struct{}
 	existingCrds map[string]struct{}
 	missingCrds  map[string]struct{}
 
@@ -42,11 +46,23 @@ func New(requiredCrds ...string) *CrdWatch {
 
 	return &CrdWatch{
 		requiredCrds: requiredCrdsMap,
+		optionalCrds: map[string]struct{}{},
 		existingCrds: map[string]struct{}{},
 		missingCrds:  missingCrds,
 	}
 }
 
+// AddOptionalCrds adds CRDs that are not required for the operator to work,
+// but whose addition or removal changes what the operator deploys.
+func (c *CrdWatch) AddOptionalCrds(crdNames ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, crdName := range crdNames {
+		c.optionalCrds[crdName] = struct{}{}
+	}
+}
+
 func (c *CrdWatch) Init(ctx context.Context, reader client.Reader) error {
 	if err := c.sync(ctx, reader); err != nil {
 		return err
@@ -179,6 +195,7 @@ func (c *CrdWatch) sync(ctx context.Context, reader client.Reader) error {
 
 func (c *CrdWatch) crdAdded(crdName string) {
 	c.existingCrds[crdName] = struct{}{}
+	c.optionalCrdChanged(crdName)
 	missingCountOld := len(c.missingCrds)
 	delete(c.missingCrds, crdName)
 
@@ -194,6 +211,7 @@ func (c *CrdWatch) crdAdded(crdName string) {
 
 func (c *CrdWatch) crdDeleted(crdName string) {
 	delete(c.existingCrds, crdName)
+	c.optionalCrdChanged(crdName)
 	if _, isRequired := c.requiredCrds[crdName]; !isRequired {
 		return
 	}
@@ -211,6 +229,16 @@ func (c *CrdWatch) crdDeleted(crdName string) {
 	}
 }
 
+func (c *CrdWatch) optionalCrdChanged(crdName string) {
+	if _, isOptional := c.optionalCrds[crdName]; !isOptional {
+		return
+	}
+	if !c.initialized || c.OptionalCrdChangedHandler == nil {
+		return
+	}
+	c.OptionalCrdChangedHandler()
+}
+
 var _ inject.Cache = &CrdWatch{}
 
 func (c *CrdWatch) InjectCache(cache ctrlcache.Cache) error {
@@ -24,6 +24,8 @@ var _ = Describe("CRD watch", func() {
 		crd1 = "required-crd-1"
 		crd2 = "required-crd-2"
 		crd3 = "required-crd-3"
+
+		optionalCrd = "optional-crd"
 	)
 
 	var (
@@ -56,6 +58,7 @@ var _ = Describe("CRD watch", func() {
 		fakeInformers = &informertest.FakeInformers{}
 
 		crdWatch = New(crd1, crd2, crd3)
+		crdWatch.AddOptionalCrds(optionalCrd)
 		Expect(crdWatch.Init(context.Background(), fakeClient)).To(Succeed())
 		Expect(crdWatch.InjectCache(fakeInformers)).To(Succeed())
 
@@ -159,6 +162,46 @@ var _ = Describe("CRD watch", func() {
 
 		Eventually(handlerCalled, 50*time.Millisecond).Should(BeClosed())
 	})
+
+	Context("OptionalCrdChangedHandler", func() {
+		It("should call handler when optional CRD is added", func() {
+			handlerCalled := make(chan struct{})
+			crdWatch.OptionalCrdChangedHandler = func() {
+				close(handlerCalled)
+			}
+
+			addCrdToFakeInformers(optionalCrd, fakeInformers)
+
+			Eventually(handlerCalled, 50*time.Millisecond).Should(BeClosed())
+			Expect(crdWatch.MissingCrds()).ToNot(ContainElement(optionalCrd))
+		})
+
+		It("should call handler when optional CRD is removed", func() {
+			addCrdToFakeInformers(optionalCrd, fakeInformers)
+
+			handlerCalled := make(chan struct{})
+			crdWatch.OptionalCrdChangedHandler = func() {
+				close(handlerCalled)
+			}
+
+			removeCrdFromFakeInformers(optionalCrd, fakeInformers)
+
+			Eventually(handlerCalled, 50*time.Millisecond).Should(BeClosed())
+		})
+
+		It("should not call handler when other CRD is added", func() {
+			var callCount int32
+			crdWatch.OptionalCrdChangedHandler = func() {
+				atomic.AddInt32(&callCount, 1)
+			}
+
+			addCrdToFakeInformers("not-optional-crd", fakeInformers)
+
+			Consistently(func() int32 {
+				return atomic.LoadInt32(&callCount)
+			}, 100*time.Millisecond).Should(BeZero())
+		})
+	})
 })
 
 func addConversionFunctions(s *runtime.Scheme) error {
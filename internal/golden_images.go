@@ -0,0 +1,20 @@
+package internal
+
+import (
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+// GetGoldenImagesNamespace returns the golden images namespace configured in the SSP CR,
+// or GoldenImagesNamespace if it is not configured.
+func GetGoldenImagesNamespace(sspObj *ssp.SSP) string {
+	if namespace := sspObj.Spec.CommonTemplates.GoldenImagesNamespace; namespace != "" {
+		return namespace
+	}
+	return GoldenImagesNamespace
+}
+
+// IsGoldenImagesNamespaceManaged returns true if the golden images namespace is created by the operator.
+// Any other namespace configured in the SSP CR is managed by the user.
+func IsGoldenImagesNamespaceManaged(sspObj *ssp.SSP) bool {
+	return GetGoldenImagesNamespace(sspObj) == GoldenImagesNamespace
+}
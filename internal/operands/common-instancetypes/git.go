@@ -0,0 +1,126 @@
+package common_instancetypes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"syscall"
+)
+
+// ErrGitRefNotFound is returned by GitLsRemote, if the repository was reached, but the ref does not exist
+var ErrGitRefNotFound = errors.New("ref not found")
+
+// commitHashRegexp matches abbreviated and full commit hashes, which are not listed by 'git ls-remote'
+var commitHashRegexp = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// IsCommitHash returns true, if the ref is an abbreviated or full commit hash.
+// Such refs cannot be looked up by 'git ls-remote'.
+func IsCommitHash(ref string) bool {
+	return commitHashRegexp.MatchString(ref)
+}
+
+// GitLsRemote runs 'git ls-remote' for the ref and returns its output.
+//
+// Git starts helper processes, like ssh or git-remote-https, that inherit the output pipe.
+// Killing only the git process when the context is done would leave the pipe open until the helpers exit,
+// so git runs in its own process group and the whole group is killed.
+func GitLsRemote(ctx context.Context, repoURL string, ref string) (string, error) {
+	cmd := exec.Command("git", "ls-remote", "--exit-code", repoURL, ref)
+	// Fail instead of waiting for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("git ls-remote failed: %w", err)
+	}
+
+	waitDone := make(chan error, 1)
+	go func() {
+		waitDone <- cmd.Wait()
+	}()
+
+	var err error
+	select {
+	case err = <-waitDone:
+	case <-ctx.Done():
+		// The negative PID sends the signal to the process group
+		_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		<-waitDone
+		return "", fmt.Errorf("git ls-remote failed: %w", ctx.Err())
+	}
+
+	if err == nil {
+		return output.String(), nil
+	}
+	// 'git ls-remote --exit-code' exits with code 2, if no matching refs are found in the remote repository
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+		return "", ErrGitRefNotFound
+	}
+	return "", fmt.Errorf("git ls-remote failed: %w: %s", err, strings.TrimSpace(output.String()))
+}
+
+// parseLsRemoteCommit returns the commit hash listed in the output of GitLsRemote.
+// Annotated tags are listed twice, the entry with the "^{}" suffix contains the commit the tag points to.
+func parseLsRemoteCommit(output string) string {
+	commit := ""
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.HasSuffix(fields[1], "^{}") {
+			return fields[0]
+		}
+		if commit == "" {
+			commit = fields[0]
+		}
+	}
+	return commit
+}
+
+// ParseKustomizeGitURL returns the repository URL and the ref of a remote kustomize target,
+// for example "https://github.com/kubevirt/common-instancetypes//VirtualMachineClusterInstancetypes?ref=v0.2.0".
+// The repository path ends before '//' or after '.git'. Otherwise, the first two path segments are used.
+func ParseKustomizeGitURL(rawURL string) (string, string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		// The parsing error is not included, because it contains the URL
+		return "", "", fmt.Errorf("commonInstancetypes URL cannot be parsed")
+	}
+
+	query := parsedURL.Query()
+	ref := query.Get("ref")
+	if ref == "" {
+		ref = query.Get("version")
+	}
+	if ref == "" {
+		return "", "", fmt.Errorf("%s is invalid, the ref of the remote kustomize target is empty", rawURL)
+	}
+
+	repoPath := parsedURL.Path
+	if index := strings.Index(strings.TrimPrefix(repoPath, "/"), "//"); index >= 0 {
+		repoPath = repoPath[:index+1]
+	} else if index := strings.Index(repoPath, ".git"); index >= 0 {
+		repoPath = repoPath[:index+len(".git")]
+	} else if segments := strings.SplitN(strings.TrimPrefix(repoPath, "/"), "/", 3); len(segments) >= 2 {
+		repoPath = "/" + segments[0] + "/" + segments[1]
+	}
+
+	repoURL := url.URL{
+		Scheme: parsedURL.Scheme,
+		User:   parsedURL.User,
+		Host:   parsedURL.Host,
+		Path:   repoPath,
+	}
+	return repoURL.String(), ref, nil
+}
@@ -0,0 +1,154 @@
+package common_instancetypes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	instancetypeapi "kubevirt.io/api/instancetype"
+	instancetypev1alpha2 "kubevirt.io/api/instancetype/v1alpha2"
+)
+
+const (
+	// OCIURLPrefix is the prefix of URLs referencing an OCI artifact with instancetypes and preferences,
+	// for example "oci://quay.io/org/common-instancetypes@sha256:..."
+	OCIURLPrefix = "oci://"
+
+	// maxOCIContentSize limits the size of downloaded manifests and layers
+	maxOCIContentSize = 32 * 1024 * 1024
+
+	ociFetchTimeout = 2 * time.Minute
+)
+
+// ParseOCIURL parses an oci:// URL, that has to reference the artifact by digest,
+// so the fetched content is always the same.
+func ParseOCIURL(rawURL string) (name.Digest, error) {
+	if !strings.HasPrefix(rawURL, OCIURLPrefix) {
+		return name.Digest{}, fmt.Errorf("URL does not start with %s", OCIURLPrefix)
+	}
+	reference := strings.TrimPrefix(rawURL, OCIURLPrefix)
+	if !strings.Contains(reference, "@") {
+		return name.Digest{}, fmt.Errorf("%s is invalid, an OCI artifact must be referenced by digest, for example %srepository@sha256:<digest>",
+			rawURL, OCIURLPrefix)
+	}
+	ociDigest, err := name.NewDigest(reference)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("%s is invalid: %w", rawURL, err)
+	}
+	return ociDigest, nil
+}
+
+// fetchResourcesFromOCI fetches instancetypes and preferences from layers of the OCI artifact.
+// Every layer has to contain YAML documents. Digests of the manifest and all layers are verified.
+// The registry is accessed anonymously, so only public artifacts can be fetched.
+func fetchResourcesFromOCI(rawURL string, transport http.RoundTripper) ([]instancetypev1alpha2.VirtualMachineClusterInstancetype, []instancetypev1alpha2.VirtualMachineClusterPreference, error) {
+	ociDigest, err := ParseOCIURL(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ociFetchTimeout)
+	defer cancel()
+
+	image, err := remote.Image(ociDigest,
+		remote.WithContext(ctx),
+		remote.WithAuth(authn.Anonymous),
+		remote.WithTransport(transport),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch manifest of %s: %w", ociDigest, err)
+	}
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get layers of %s: %w", ociDigest, err)
+	}
+
+	var (
+		instancetypes []instancetypev1alpha2.VirtualMachineClusterInstancetype
+		preferences   []instancetypev1alpha2.VirtualMachineClusterPreference
+	)
+	for _, layer := range layers {
+		layerDigest, err := layer.Digest()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get layer digest of %s: %w", ociDigest, err)
+		}
+		layerBytes, err := readOCILayer(layer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch layer %s of %s: %w", layerDigest, ociDigest, err)
+		}
+		layerInstancetypes, layerPreferences, err := decodeOCILayer(layerBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode layer %s of %s: %w", layerDigest, ociDigest, err)
+		}
+		instancetypes = append(instancetypes, layerInstancetypes...)
+		preferences = append(preferences, layerPreferences...)
+	}
+	return instancetypes, preferences, nil
+}
+
+// readOCILayer reads the layer as it is stored in the registry, because the layers of the artifact are not compressed.
+// The content is verified against the layer digest while it is read.
+func readOCILayer(layer v1.Layer) ([]byte, error) {
+	size, err := layer.Size()
+	if err != nil {
+		return nil, err
+	}
+	if size > maxOCIContentSize {
+		return nil, fmt.Errorf("content is larger than %d bytes", maxOCIContentSize)
+	}
+
+	reader, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(io.LimitReader(reader, maxOCIContentSize))
+}
+
+func decodeOCILayer(layer []byte) ([]instancetypev1alpha2.VirtualMachineClusterInstancetype, []instancetypev1alpha2.VirtualMachineClusterPreference, error) {
+	var (
+		instancetypes []instancetypev1alpha2.VirtualMachineClusterInstancetype
+		preferences   []instancetypev1alpha2.VirtualMachineClusterPreference
+	)
+	documents := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(layer)))
+	for {
+		document, err := documents.Read()
+		if err == io.EOF {
+			return instancetypes, preferences, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		typeMeta := metav1.TypeMeta{}
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return nil, nil, err
+		}
+		switch strings.ToLower(typeMeta.Kind) {
+		case instancetypeapi.ClusterSingularResourceName:
+			resources, err := decodeResources[instancetypev1alpha2.VirtualMachineClusterInstancetype](document)
+			if err != nil {
+				return nil, nil, err
+			}
+			instancetypes = append(instancetypes, resources...)
+		case instancetypeapi.ClusterSingularPreferenceResourceName:
+			resources, err := decodeResources[instancetypev1alpha2.VirtualMachineClusterPreference](document)
+			if err != nil {
+				return nil, nil, err
+			}
+			preferences = append(preferences, resources...)
+		}
+	}
+}
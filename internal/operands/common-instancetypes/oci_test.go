@@ -0,0 +1,184 @@
+package common_instancetypes
+
+import (
+	"fmt"
+	"io"
+	stdlog "log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+var _ = Describe("OCI source", func() {
+	const (
+		repository   = "kubevirt/common-instancetypes"
+		layerContent = `apiVersion: instancetype.kubevirt.io/v1alpha2
+kind: VirtualMachineClusterInstancetype
+metadata:
+  name: test-instancetype
+spec:
+  cpu:
+    guest: 1
+  memory:
+    guest: 1Gi
+---
+apiVersion: instancetype.kubevirt.io/v1alpha2
+kind: VirtualMachineClusterPreference
+metadata:
+  name: test-preference
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+	)
+
+	var (
+		server         *httptest.Server
+		manifestDigest v1.Hash
+		layerDigest    v1.Hash
+		requireToken   bool
+		modifyResponse func(path string) ([]byte, bool)
+	)
+
+	ociURL := func(d v1.Hash) string {
+		return fmt.Sprintf("%s%s/%s@%s", OCIURLPrefix, strings.TrimPrefix(server.URL, "http://"), repository, d)
+	}
+
+	BeforeEach(func() {
+		requireToken = false
+		modifyResponse = func(string) ([]byte, bool) { return nil, false }
+
+		registryHandler := registry.New(registry.Logger(stdlog.New(io.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/token" {
+				// Parameters after the scope, that contains a comma, have to be parsed as well
+				Expect(r.URL.Query().Get("service")).To(Equal("registry"))
+				_, _ = w.Write([]byte(`{"token":"anonymous-token"}`))
+				return
+			}
+			if requireToken && r.Header.Get("Authorization") != "Bearer anonymous-token" {
+				w.Header().Set("WWW-Authenticate",
+					fmt.Sprintf(`Bearer realm="%s/token",scope="repository:%s:pull,push",service="registry"`, server.URL, repository))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if data, ok := modifyResponse(r.URL.Path); ok {
+				_, _ = w.Write(data)
+				return
+			}
+			registryHandler.ServeHTTP(w, r)
+		}))
+		DeferCleanup(server.Close)
+
+		layer := static.NewLayer([]byte(layerContent), "application/yaml")
+		artifact, err := mutate.AppendLayers(mutate.MediaType(empty.Image, types.OCIManifestSchema1), layer)
+		Expect(err).ToNot(HaveOccurred())
+		manifestDigest, err = artifact.Digest()
+		Expect(err).ToNot(HaveOccurred())
+		layerDigest, err = layer.Digest()
+		Expect(err).ToNot(HaveOccurred())
+
+		reference, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/" + repository + ":latest")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(reference, artifact)).To(Succeed())
+	})
+
+	It("should fetch resources from OCI artifact", func() {
+		instancetypes, preferences, err := fetchResourcesFromOCI(ociURL(manifestDigest), http.DefaultTransport)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetypes).To(HaveLen(1))
+		Expect(instancetypes[0].Name).To(Equal("test-instancetype"))
+		Expect(preferences).To(HaveLen(1))
+		Expect(preferences[0].Name).To(Equal("test-preference"))
+	})
+
+	It("should fetch resources using an anonymous token", func() {
+		requireToken = true
+		instancetypes, preferences, err := fetchResourcesFromOCI(ociURL(manifestDigest), http.DefaultTransport)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetypes).To(HaveLen(1))
+		Expect(preferences).To(HaveLen(1))
+	})
+
+	It("should fetch resources from OCI URL in operand", func() {
+		operand := New("", "")
+		operand.OCITransport = http.DefaultTransport
+		instancetypes, preferences, err := operand.FetchResourcesFromURL(ociURL(manifestDigest))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(instancetypes).To(HaveLen(1))
+		Expect(preferences).To(HaveLen(1))
+	})
+
+	It("should fail if manifest does not match digest", func() {
+		otherDigest, _, err := v1.SHA256(strings.NewReader("other manifest"))
+		Expect(err).ToNot(HaveOccurred())
+		modifyResponse = func(path string) ([]byte, bool) {
+			if path != "/v2/"+repository+"/manifests/"+otherDigest.String() {
+				return nil, false
+			}
+			response, err := http.Get(server.URL + "/v2/" + repository + "/manifests/" + manifestDigest.String())
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+			manifest, err := io.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			return manifest, true
+		}
+
+		_, _, err = fetchResourcesFromOCI(ociURL(otherDigest), http.DefaultTransport)
+		Expect(err).To(MatchError(ContainSubstring("does not match requested digest")))
+	})
+
+	It("should fail if layer does not match digest", func() {
+		modifyResponse = func(path string) ([]byte, bool) {
+			if path != "/v2/"+repository+"/blobs/"+layerDigest.String() {
+				return nil, false
+			}
+			// The size is kept, so only the digest does not match
+			return []byte(strings.ToUpper(layerContent)), true
+		}
+
+		_, _, err := fetchResourcesFromOCI(ociURL(manifestDigest), http.DefaultTransport)
+		Expect(err).To(MatchError(ContainSubstring("failed to fetch layer " + layerDigest.String())))
+	})
+
+	It("should fail if manifest does not exist", func() {
+		missingDigest, _, err := v1.SHA256(strings.NewReader("missing"))
+		Expect(err).ToNot(HaveOccurred())
+		_, _, err = fetchResourcesFromOCI(ociURL(missingDigest), http.DefaultTransport)
+		Expect(err).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+	})
+
+	DescribeTable("should parse OCI URL", func(url string, expectedRepository string) {
+		ociDigest, err := ParseOCIURL(url)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ociDigest.Context().Name()).To(Equal(expectedRepository))
+	},
+		Entry("with registry", "oci://quay.io/foo/bar@sha256:"+strings.Repeat("a", 64), "quay.io/foo/bar"),
+		Entry("with registry port", "oci://registry.foo.com:5000/foo/bar@sha256:"+strings.Repeat("a", 64), "registry.foo.com:5000/foo/bar"),
+		Entry("with tag", "oci://quay.io/foo/bar:v1.0.0@sha256:"+strings.Repeat("a", 64), "quay.io/foo/bar"),
+	)
+
+	DescribeTable("should reject OCI URL", func(url string) {
+		_, err := ParseOCIURL(url)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("without oci:// prefix", "https://quay.io/foo/bar@sha256:"+strings.Repeat("a", 64)),
+		Entry("without digest", "oci://quay.io/foo/bar"),
+		Entry("with tag only", "oci://quay.io/foo/bar:latest"),
+		Entry("with invalid digest", "oci://quay.io/foo/bar@sha256:1234"),
+	)
+})
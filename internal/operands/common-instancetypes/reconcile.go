@@ -2,12 +2,20 @@ package common_instancetypes
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/kustomize/api/krusty"
@@ -15,8 +23,10 @@ import (
 	"sigs.k8s.io/kustomize/api/resource"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	instancetypeapi "kubevirt.io/api/instancetype"
 	instancetypev1alpha2 "kubevirt.io/api/instancetype/v1alpha2"
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/operands"
 )
@@ -24,6 +34,8 @@ import (
 // Define RBAC rules needed by this operand:
 // +kubebuilder:rbac:groups=instancetype.kubevirt.io,resources=virtualmachineclusterinstancetypes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=instancetype.kubevirt.io,resources=virtualmachineclusterpreferences,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
 
 const (
 	operandName                          = "common-instancetypes"
@@ -33,15 +45,30 @@ const (
 	ClusterPreferencesBundle             = "common-clusterpreferences-bundle.yaml"
 	virtualMachineClusterInstancetypeCrd = instancetypeapi.ClusterPluralResourceName + "." + instancetypeapi.GroupName
 	virtualMachineClusterPreferenceCrd   = instancetypeapi.ClusterPluralPreferenceResourceName + "." + instancetypeapi.GroupName
+
+	// ConditionSynced is the SSP condition reporting if the resources from the commonInstancetypes URL are applied
+	ConditionSynced conditionsv1.ConditionType = "CommonInstancetypesSynced"
+
+	// gitLsRemoteTimeout limits resolving the ref of the URL, so an unreachable repository does not block the reconciliation
+	gitLsRemoteTimeout = 30 * time.Second
+
+	reasonSynced      = "Synced"
+	reasonFetchFailed = "FetchFailed"
+	reasonSyncFailed  = "SyncFailed"
 )
 
 type CommonInstancetypes struct {
 	resourceURL                             string
+	resourceRevision                        string
 	virtualMachineClusterInstancetypeBundle string
 	virtualMachineClusterPreferenceBundle   string
 	virtualMachineClusterInstancetypes      []instancetypev1alpha2.VirtualMachineClusterInstancetype
 	virtualMachineClusterPreferences        []instancetypev1alpha2.VirtualMachineClusterPreference
+	defaultPreference                       *ssp.DefaultPreference
+	exportData                              map[string]string
 	KustomizeRunFunc                        func(filesys.FileSystem, string) (resmap.ResMap, error)
+	OCITransport                            http.RoundTripper
+	GitLsRemoteFunc                         func(context.Context, string, string) (string, error)
 }
 
 var _ operands.Operand = &CommonInstancetypes{}
@@ -66,7 +93,9 @@ func (c *CommonInstancetypes) WatchClusterTypes() []operands.WatchType {
 }
 
 func (c *CommonInstancetypes) WatchTypes() []operands.WatchType {
-	return nil
+	return []operands.WatchType{
+		{Object: &core.ConfigMap{}},
+	}
 }
 
 func New(virtualMachineClusterInstancetypeBundlePath, virtualMachineClusterPreferenceBundlePath string) *CommonInstancetypes {
@@ -75,6 +104,8 @@ func New(virtualMachineClusterInstancetypeBundlePath, virtualMachineClusterPrefe
 		virtualMachineClusterInstancetypeBundle: virtualMachineClusterInstancetypeBundlePath,
 		virtualMachineClusterPreferenceBundle:   virtualMachineClusterPreferenceBundlePath,
 		KustomizeRunFunc:                        k.Run,
+		OCITransport:                            remote.DefaultTransport,
+		GitLsRemoteFunc:                         GitLsRemote,
 	}
 }
 
@@ -141,6 +172,10 @@ func decodeResMapResources[C clusterType](r *resource.Resource) ([]C, error) {
 }
 
 func (c *CommonInstancetypes) FetchResourcesFromURL(URL string) ([]instancetypev1alpha2.VirtualMachineClusterInstancetype, []instancetypev1alpha2.VirtualMachineClusterPreference, error) {
+	if strings.HasPrefix(URL, OCIURLPrefix) {
+		return fetchResourcesFromOCI(URL, c.OCITransport)
+	}
+
 	resmapFromURL, err := c.generateResourcesFromURL(URL)
 	if err != nil {
 		return nil, nil, err
@@ -238,29 +273,106 @@ func (c *CommonInstancetypes) reconcileRemovedResources(request *common.Request,
 }
 
 func (c *CommonInstancetypes) reconcileFromURL(request *common.Request) ([]common.ReconcileResult, error) {
+	URL := *request.Instance.Spec.CommonInstancetypes.URL
+	results, failureReason, err := c.syncFromURL(request)
+	if err != nil {
+		// Forget the cached URL, so the sync is retried in the next reconciliation
+		c.resourceURL = ""
+		setSyncedCondition(request, core.ConditionFalse, failureReason, fmt.Sprintf("Failed to sync from %s: %v", URL, err))
+		return nil, err
+	}
+	setSyncedCondition(request, core.ConditionTrue, reasonSynced, fmt.Sprintf("Applied revision %s from %s", c.resourceRevision, URL))
+	return results, nil
+}
+
+// syncFromURL applies resources from the URL. On failure, it also returns the reason for the synced condition.
+func (c *CommonInstancetypes) syncFromURL(request *common.Request) ([]common.ReconcileResult, string, error) {
 	// TODO - In the future we should handle cases where the URL remains the same but the provided resources change.
-	if c.resourceURL != "" && c.resourceURL == *request.Instance.Spec.CommonInstancetypes.URL {
+	if c.resourceURL != "" && c.resourceURL == *request.Instance.Spec.CommonInstancetypes.URL &&
+		equality.Semantic.DeepEqual(c.defaultPreference, request.Instance.Spec.CommonInstancetypes.DefaultPreference) {
 		request.Logger.Info(fmt.Sprintf("Skipping reconcile of common-instancetypes from URL %s, force with a restart of the service.", *request.Instance.Spec.CommonInstancetypes.URL))
-		return nil, nil
+		funcs := []common.ReconcileFunc{c.reconcileExportConfigMap}
+		if defaultPreference := newDefaultPreference(request); defaultPreference != nil {
+			funcs = append(funcs, reconcileVirtualMachineClusterPreferenceFunc(defaultPreference))
+		}
+		results, err := common.CollectResourceStatus(request, funcs...)
+		return results, reasonSyncFailed, err
 	}
 
 	// Cache the URL so we can check if it changes with future reconcile attempts above
 	c.resourceURL = *request.Instance.Spec.CommonInstancetypes.URL
 	request.Logger.Info(fmt.Sprintf("Reconciling common-instancetypes from URL %s", c.resourceURL))
-	clusterInstancetypesFromURL, clusterPreferencesFromURL, err := c.FetchResourcesFromURL(c.resourceURL)
+	var clusterInstancetypesFromURL []instancetypev1alpha2.VirtualMachineClusterInstancetype
+	var clusterPreferencesFromURL []instancetypev1alpha2.VirtualMachineClusterPreference
+	err := withSSHKey(request, c.resourceURL, func() error {
+		// The revision is resolved before fetching, so a ref moved in the meantime is not reported with older resources
+		c.resourceRevision = c.resolveRevision(request, c.resourceURL)
+		var fetchErr error
+		clusterInstancetypesFromURL, clusterPreferencesFromURL, fetchErr = c.FetchResourcesFromURL(c.resourceURL)
+		return fetchErr
+	})
 	if err != nil {
-		return nil, err
+		return nil, reasonFetchFailed, err
+	}
+
+	clusterPreferencesFromURL, err = c.withDefaultPreference(request, clusterPreferencesFromURL)
+	if err != nil {
+		return nil, reasonSyncFailed, err
 	}
 
 	// Remove any resources no longer provided by the URL, this should only happen when switching from the internal bundle to external URL for now.
 	if err = c.reconcileRemovedResources(request, clusterInstancetypesFromURL, clusterPreferencesFromURL); err != nil {
-		return nil, err
+		return nil, reasonSyncFailed, err
 	}
 
 	// Generate the normal set of reconcile funcs to create or update the provided resources
-	c.virtualMachineClusterInstancetypes = clusterInstancetypesFromURL
-	c.virtualMachineClusterPreferences = clusterPreferencesFromURL
-	return common.CollectResourceStatus(request, c.reconcileFuncs()...)
+	if err = c.setResources(clusterInstancetypesFromURL, clusterPreferencesFromURL); err != nil {
+		return nil, reasonSyncFailed, err
+	}
+	results, err := common.CollectResourceStatus(request, c.reconcileFuncs()...)
+	return results, reasonSyncFailed, err
+}
+
+func setSyncedCondition(request *common.Request, status core.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&request.Instance.Status.Conditions, conditionsv1.Condition{
+		Type:    ConditionSynced,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// resolveRevision returns the revision pinned by the URL, which is the digest of an OCI artifact,
+// or the commit of the ref or version of a remote kustomize target.
+// If the ref cannot be resolved to a commit, the ref itself is returned.
+func (c *CommonInstancetypes) resolveRevision(request *common.Request, rawURL string) string {
+	if strings.HasPrefix(rawURL, OCIURLPrefix) {
+		digest, err := ParseOCIURL(rawURL)
+		if err != nil {
+			return ""
+		}
+		return digest.DigestStr()
+	}
+
+	repoURL, ref, err := ParseKustomizeGitURL(rawURL)
+	if err != nil {
+		return ""
+	}
+	if IsCommitHash(ref) {
+		return ref
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context, gitLsRemoteTimeout)
+	defer cancel()
+	output, err := c.GitLsRemoteFunc(ctx, repoURL, ref)
+	if err != nil {
+		request.Logger.Info("Could not resolve ref of common-instancetypes URL to a commit", "ref", ref, "error", err.Error())
+		return ref
+	}
+	if commit := parseLsRemoteCommit(output); commit != "" {
+		return commit
+	}
+	return ref
 }
 
 func (c *CommonInstancetypes) reconcileFromBundle(request *common.Request) ([]common.ReconcileResult, error) {
@@ -270,20 +382,88 @@ func (c *CommonInstancetypes) reconcileFromBundle(request *common.Request) ([]co
 		return nil, err
 	}
 
+	clusterPreferencesFromBundle, err = c.withDefaultPreference(request, clusterPreferencesFromBundle)
+	if err != nil {
+		return nil, err
+	}
+
 	// Remove any resources no longer provided by the bundle
 	if err = c.reconcileRemovedResources(request, clusterInstancetypesFromBundle, clusterPreferencesFromBundle); err != nil {
 		return nil, err
 	}
 
-	c.virtualMachineClusterInstancetypes = clusterInstancetypesFromBundle
-	c.virtualMachineClusterPreferences = clusterPreferencesFromBundle
+	if err = c.setResources(clusterInstancetypesFromBundle, clusterPreferencesFromBundle); err != nil {
+		return nil, err
+	}
 	return common.CollectResourceStatus(request, c.reconcileFuncs()...)
 }
 
+// withDefaultPreference appends the default preference configured in the SSP CR to the provided preferences
+func (c *CommonInstancetypes) withDefaultPreference(request *common.Request, preferences []instancetypev1alpha2.VirtualMachineClusterPreference) ([]instancetypev1alpha2.VirtualMachineClusterPreference, error) {
+	c.defaultPreference = nil
+	defaultPreference := newDefaultPreference(request)
+	if defaultPreference == nil {
+		return preferences, nil
+	}
+
+	for _, preference := range preferences {
+		if preference.Name == defaultPreference.Name {
+			return nil, fmt.Errorf("default preference %s has the same name as a provided VirtualMachineClusterPreference", defaultPreference.Name)
+		}
+	}
+
+	c.defaultPreference = request.Instance.Spec.CommonInstancetypes.DefaultPreference.DeepCopy()
+	return append(preferences, *defaultPreference), nil
+}
+
+func newDefaultPreference(request *common.Request) *instancetypev1alpha2.VirtualMachineClusterPreference {
+	if request.Instance.Spec.CommonInstancetypes == nil || request.Instance.Spec.CommonInstancetypes.DefaultPreference == nil {
+		return nil
+	}
+	defaultPreference := request.Instance.Spec.CommonInstancetypes.DefaultPreference
+	preference := &instancetypev1alpha2.VirtualMachineClusterPreference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultPreference.Name,
+		},
+	}
+	if defaultPreference.PreferredCPUTopology != "" {
+		preference.Spec.CPU = &instancetypev1alpha2.CPUPreferences{
+			PreferredCPUTopology: instancetypev1alpha2.PreferredCPUTopology(defaultPreference.PreferredCPUTopology),
+		}
+	}
+	if defaultPreference.PreferredDiskBus != "" || defaultPreference.PreferredInterfaceModel != "" {
+		preference.Spec.Devices = &instancetypev1alpha2.DevicePreferences{
+			PreferredDiskBus:        kubevirtv1.DiskBus(defaultPreference.PreferredDiskBus),
+			PreferredInterfaceModel: defaultPreference.PreferredInterfaceModel,
+		}
+	}
+	if defaultPreference.PreferredMachineType != "" {
+		preference.Spec.Machine = &instancetypev1alpha2.MachinePreferences{
+			PreferredMachineType: defaultPreference.PreferredMachineType,
+		}
+	}
+	return preference
+}
+
+func (c *CommonInstancetypes) setResources(instancetypes []instancetypev1alpha2.VirtualMachineClusterInstancetype, preferences []instancetypev1alpha2.VirtualMachineClusterPreference) error {
+	// The export is rendered before reconcile, so it does not include labels and annotations added by the operator
+	exportData, err := newExportData(instancetypes, preferences)
+	if err != nil {
+		return err
+	}
+
+	c.virtualMachineClusterInstancetypes = instancetypes
+	c.virtualMachineClusterPreferences = preferences
+	c.exportData = exportData
+	return nil
+}
+
 func (c *CommonInstancetypes) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
 	if request.Instance.Spec.CommonInstancetypes != nil && request.Instance.Spec.CommonInstancetypes.URL != nil {
 		return c.reconcileFromURL(request)
 	}
+	// The synced condition reports only resources from the URL
+	conditionsv1.RemoveStatusCondition(&request.Instance.Status.Conditions, ConditionSynced)
 	return c.reconcileFromBundle(request)
 }
 
@@ -310,12 +490,38 @@ func (c *CommonInstancetypes) Cleanup(request *common.Request) ([]common.Cleanup
 }
 
 func (c *CommonInstancetypes) reconcileFuncs() []common.ReconcileFunc {
-	funcs := []common.ReconcileFunc{}
+	funcs := []common.ReconcileFunc{c.reconcileExportConfigMap}
 	funcs = append(funcs, c.reconcileVirtualMachineClusterInstancetypesFuncs()...)
 	funcs = append(funcs, c.reconcileVirtualMachineClusterPreferencesFuncs()...)
 	return funcs
 }
 
+func (c *CommonInstancetypes) reconcileExportConfigMap(request *common.Request) (common.ReconcileResult, error) {
+	if !exportEnabled(request) {
+		// Cleanup reads the ConfigMap from the cache and deletes it only if it is owned by the SSP CR,
+		// so an unrelated ConfigMap with the same name is kept.
+		configMap := newExportConfigMap(request.Namespace, nil)
+		if _, err := common.Cleanup(request, configMap); err != nil {
+			return common.ReconcileResult{}, err
+		}
+		return common.ReconcileResult{
+			Resource: configMap,
+		}, nil
+	}
+
+	return common.CreateOrUpdate(request).
+		NamespacedResource(newExportConfigMap(request.Namespace, c.exportData)).
+		WithAppLabels(operandName, operandComponent).
+		UpdateFunc(func(newRes, foundRes client.Object) {
+			foundRes.(*core.ConfigMap).Data = newRes.(*core.ConfigMap).Data
+		}).
+		Reconcile()
+}
+
+func exportEnabled(request *common.Request) bool {
+	return request.Instance.Spec.FeatureGates != nil && request.Instance.Spec.FeatureGates.ExportCommonInstancetypes
+}
+
 func (c *CommonInstancetypes) reconcileVirtualMachineClusterInstancetypesFuncs() []common.ReconcileFunc {
 	funcs := make([]common.ReconcileFunc, 0, len(c.virtualMachineClusterInstancetypes))
 	for i := range c.virtualMachineClusterInstancetypes {
@@ -333,13 +539,16 @@ func (c *CommonInstancetypes) reconcileVirtualMachineClusterInstancetypesFuncs()
 func (c *CommonInstancetypes) reconcileVirtualMachineClusterPreferencesFuncs() []common.ReconcileFunc {
 	funcs := make([]common.ReconcileFunc, 0, len(c.virtualMachineClusterPreferences))
 	for i := range c.virtualMachineClusterPreferences {
-		clusterPreference := &c.virtualMachineClusterPreferences[i]
-		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
-			return common.CreateOrUpdate(request).
-				ClusterResource(clusterPreference).
-				WithAppLabels(operandName, operandComponent).
-				Reconcile()
-		})
+		funcs = append(funcs, reconcileVirtualMachineClusterPreferenceFunc(&c.virtualMachineClusterPreferences[i]))
 	}
 	return funcs
 }
+
+func reconcileVirtualMachineClusterPreferenceFunc(clusterPreference *instancetypev1alpha2.VirtualMachineClusterPreference) common.ReconcileFunc {
+	return func(request *common.Request) (common.ReconcileResult, error) {
+		return common.CreateOrUpdate(request).
+			ClusterResource(clusterPreference).
+			WithAppLabels(operandName, operandComponent).
+			Reconcile()
+	}
+}
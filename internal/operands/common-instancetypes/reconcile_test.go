@@ -4,12 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	core "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	"k8s.io/apimachinery/pkg/api/equality"
 	internalmeta "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
@@ -26,6 +31,7 @@ import (
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 
+	kubevirtv1 "kubevirt.io/api/core/v1"
 	instancetypeapi "kubevirt.io/api/instancetype"
 	instancetypev1alpha2 "kubevirt.io/api/instancetype/v1alpha2"
 	ssp "kubevirt.io/ssp-operator/api/v1beta2"
@@ -79,11 +85,16 @@ var _ = Describe("Common-Instancetypes operand", func() {
 	BeforeEach(func() {
 		operand = New(instancetypePath, preferencePath)
 		Expect(err).ToNot(HaveOccurred())
+		// Refs of URLs are not resolved using the network in unit tests
+		operand.GitLsRemoteFunc = func(_ context.Context, _ string, _ string) (string, error) {
+			return "", ErrGitRefNotFound
+		}
 
 		Expect(internalmeta.AddToScheme(scheme.Scheme)).To(Succeed())
 		Expect(apiextensions.AddToScheme(scheme.Scheme)).To(Succeed())
 		Expect(addConversionFunctions(scheme.Scheme)).To(Succeed())
 		Expect(instancetypev1alpha2.AddToScheme(scheme.Scheme)).To(Succeed())
+		Expect(ssp.AddToScheme(scheme.Scheme)).To(Succeed())
 
 		client := fake.NewClientBuilder().Build()
 
@@ -279,6 +290,183 @@ var _ = Describe("Common-Instancetypes operand", func() {
 		assertResoucesDoNotExist(request, virtualMachineClusterInstancetypes, virtualMachineClusterPreferences)
 	})
 
+	Context("applied revision", func() {
+		const url = "https://foo.com/org/repo//bar?ref=v1.0.0"
+
+		getSyncedMessage := func() string {
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionSynced)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(core.ConditionTrue))
+			return condition.Message
+		}
+
+		BeforeEach(func() {
+			mockResMap, _, _, err := newMockResources(1, 1)
+			Expect(err).ToNot(HaveOccurred())
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				return mockResMap, nil
+			}
+			request.Instance.Spec.CommonInstancetypes = &ssp.CommonInstancetypes{
+				URL: pointer.String(url),
+			}
+		})
+
+		It("should report commit of the ref", func() {
+			var lsRemoteRepo, lsRemoteRef string
+			operand.GitLsRemoteFunc = func(_ context.Context, repoURL string, ref string) (string, error) {
+				lsRemoteRepo, lsRemoteRef = repoURL, ref
+				return "0123456789abcdef0123456789abcdef01234567\trefs/tags/v1.0.0\n", nil
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lsRemoteRepo).To(Equal("https://foo.com/org/repo"))
+			Expect(lsRemoteRef).To(Equal("v1.0.0"))
+			Expect(getSyncedMessage()).To(Equal("Applied revision 0123456789abcdef0123456789abcdef01234567 from " + url))
+		})
+
+		It("should report commit pointed to by an annotated tag", func() {
+			operand.GitLsRemoteFunc = func(_ context.Context, _ string, _ string) (string, error) {
+				return "0123456789abcdef0123456789abcdef01234567\trefs/tags/v1.0.0\n" +
+					"76543210fedcba9876543210fedcba9876543210\trefs/tags/v1.0.0^{}\n", nil
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getSyncedMessage()).To(HavePrefix("Applied revision 76543210fedcba9876543210fedcba9876543210 "))
+		})
+
+		It("should report the ref, if it cannot be resolved", func() {
+			operand.GitLsRemoteFunc = func(_ context.Context, _ string, _ string) (string, error) {
+				return "", fmt.Errorf("repository not reachable")
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getSyncedMessage()).To(HavePrefix("Applied revision v1.0.0 "))
+		})
+
+		It("should not resolve a commit hash", func() {
+			const commit = "0123456789abcdef0123456789abcdef01234567"
+			operand.GitLsRemoteFunc = func(_ context.Context, _ string, _ string) (string, error) {
+				Fail("commit hash should not be resolved")
+				return "", nil
+			}
+			request.Instance.Spec.CommonInstancetypes.URL = pointer.String("https://foo.com/org/repo//bar?ref=" + commit)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getSyncedMessage()).To(HavePrefix("Applied revision " + commit + " "))
+		})
+	})
+
+	Context("SSH key", func() {
+		const (
+			url        = "ssh://git@foo.com/org/repo//bar?ref=v1.0.0"
+			secretName = "ssh-key"
+		)
+
+		var (
+			mockResMap *MockResMap
+			secret     *core.Secret
+		)
+
+		// readSSHCommandFile returns the content of the file passed to the option of the GIT_SSH_COMMAND
+		readSSHCommandFile := func(sshCommand, option string) string {
+			_, path, found := strings.Cut(sshCommand, option+"'")
+			Expect(found).To(BeTrue(), sshCommand)
+			path, _, _ = strings.Cut(path, "'")
+			content, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			return string(content)
+		}
+
+		BeforeEach(func() {
+			mockResMap, _, _, err = newMockResources(1, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			secret = &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					core.SSHAuthPrivateKey: []byte("private-key"),
+				},
+			}
+			request.UncachedReader = request.Client
+			request.Instance.Spec.CommonInstancetypes = &ssp.CommonInstancetypes{
+				URL:           pointer.String(url),
+				SSHSecretName: secretName,
+			}
+		})
+
+		It("should fetch using the private key from the Secret", func() {
+			Expect(request.Client.Create(request.Context, secret)).To(Succeed())
+
+			var sshCommand, privateKey string
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				sshCommand = os.Getenv("GIT_SSH_COMMAND")
+				privateKey = readSSHCommandFile(sshCommand, "-i ")
+				return mockResMap, nil
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(privateKey).To(Equal("private-key"))
+			Expect(sshCommand).To(ContainSubstring("StrictHostKeyChecking=no"))
+			Expect(os.Getenv("GIT_SSH_COMMAND")).To(BeEmpty())
+		})
+
+		It("should verify the host key, if the Secret contains known hosts", func() {
+			secret.Data[SSHKnownHostsKey] = []byte("foo.com ssh-ed25519 AAAA")
+			Expect(request.Client.Create(request.Context, secret)).To(Succeed())
+
+			var knownHosts string
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				sshCommand := os.Getenv("GIT_SSH_COMMAND")
+				Expect(sshCommand).To(ContainSubstring("StrictHostKeyChecking=yes"))
+				knownHosts = readSSHCommandFile(sshCommand, "UserKnownHostsFile=")
+				return mockResMap, nil
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(knownHosts).To(Equal("foo.com ssh-ed25519 AAAA"))
+		})
+
+		It("should use the private key to resolve the ref", func() {
+			Expect(request.Client.Create(request.Context, secret)).To(Succeed())
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				return mockResMap, nil
+			}
+
+			var sshCommand string
+			operand.GitLsRemoteFunc = func(_ context.Context, _ string, _ string) (string, error) {
+				sshCommand = os.Getenv("GIT_SSH_COMMAND")
+				return "", ErrGitRefNotFound
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sshCommand).To(ContainSubstring("-i '"))
+		})
+
+		It("should fail, if the Secret does not exist", func() {
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				Fail("resources should not be fetched")
+				return nil, nil
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).To(MatchError(ContainSubstring("failed to get SSH Secret")))
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionSynced)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Reason).To(Equal(reasonFetchFailed))
+		})
+	})
+
 	It("should create and cleanup resources when an external URL changes", func() {
 		// Generate a mock ResMap and resources for the test
 		mockResMap, originalInstancetypes, originalPreferences, err := newMockResources(0, 10)
@@ -349,6 +537,228 @@ var _ = Describe("Common-Instancetypes operand", func() {
 		ExpectResourceExists(instancetype, request)
 		ExpectResourceExists(preference, request)
 	})
+	Context("default preference", func() {
+		const defaultPreferenceName = "test-default-preference"
+
+		var defaultPreference *instancetypev1alpha2.VirtualMachineClusterPreference
+
+		BeforeEach(func() {
+			defaultPreference = newVirtualMachineClusterPreference(defaultPreferenceName)
+			request.Instance.Spec.CommonInstancetypes = &ssp.CommonInstancetypes{
+				DefaultPreference: &ssp.DefaultPreference{
+					Name:                 defaultPreferenceName,
+					PreferredCPUTopology: string(instancetypev1alpha2.PreferSockets),
+				},
+			}
+		})
+
+		getDefaultPreference := func() *instancetypev1alpha2.VirtualMachineClusterPreference {
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(defaultPreference), defaultPreference)).To(Succeed())
+			return defaultPreference
+		}
+
+		It("should create default preference", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			preference := getDefaultPreference()
+			Expect(preference.Labels).To(HaveKeyWithValue(common.AppKubernetesNameLabel, operandName))
+			Expect(preference.Spec.CPU).ToNot(BeNil())
+			Expect(preference.Spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1alpha2.PreferSockets))
+			Expect(preference.Spec.Devices).To(BeNil())
+			Expect(preference.Spec.Machine).To(BeNil())
+		})
+
+		It("should map all configured preferences", func() {
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.PreferredDiskBus = "virtio"
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.PreferredInterfaceModel = "e1000e"
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.PreferredMachineType = "q35"
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			preference := getDefaultPreference()
+			Expect(preference.Spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1alpha2.PreferSockets))
+			Expect(preference.Spec.Devices).ToNot(BeNil())
+			Expect(preference.Spec.Devices.PreferredDiskBus).To(Equal(kubevirtv1.DiskBusVirtio))
+			Expect(preference.Spec.Devices.PreferredInterfaceModel).To(Equal("e1000e"))
+			Expect(preference.Spec.Machine).ToNot(BeNil())
+			Expect(preference.Spec.Machine.PreferredMachineType).To(Equal("q35"))
+		})
+
+		It("should update default preference when SSP changes", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.PreferredCPUTopology = string(instancetypev1alpha2.PreferCores)
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getDefaultPreference().Spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1alpha2.PreferCores))
+		})
+
+		It("should revert user modifications of default preference", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			preference := getDefaultPreference()
+			preference.Spec.CPU = nil
+			Expect(request.Client.Update(request.Context, preference)).To(Succeed())
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getDefaultPreference().Spec.CPU).ToNot(BeNil())
+		})
+
+		It("should remove default preference when it is removed from SSP", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceExists(defaultPreference, request)
+
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference = nil
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceNotExists(defaultPreference, request)
+		})
+
+		It("should update default preference when reconciling from an unchanged URL", func() {
+			mockResMap, _, _, err := newMockResources(1, 1)
+			Expect(err).ToNot(HaveOccurred())
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				return mockResMap, nil
+			}
+			request.Instance.Spec.CommonInstancetypes.URL = pointer.String("https://foo.com/bar?ref=1")
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.PreferredCPUTopology = string(instancetypev1alpha2.PreferCores)
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getDefaultPreference().Spec.CPU.PreferredCPUTopology).To(Equal(instancetypev1alpha2.PreferCores))
+		})
+
+		It("should fail if default preference has the same name as a provided preference", func() {
+			mockResMap, _, preferences, err := newMockResources(0, 1)
+			Expect(err).ToNot(HaveOccurred())
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				return mockResMap, nil
+			}
+			request.Instance.Spec.CommonInstancetypes.URL = pointer.String("https://foo.com/bar?ref=1")
+			request.Instance.Spec.CommonInstancetypes.DefaultPreference.Name = preferences[0].Name
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).To(MatchError(ContainSubstring("has the same name as a provided VirtualMachineClusterPreference")))
+		})
+
+		It("should cleanup default preference", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceExists(defaultPreference, request)
+
+			_, err = operand.Cleanup(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceNotExists(defaultPreference, request)
+		})
+	})
+
+	Context("export ConfigMap", func() {
+		var (
+			exportConfigMap *core.ConfigMap
+
+			virtualMachineClusterInstancetypes []instancetypev1alpha2.VirtualMachineClusterInstancetype
+			virtualMachineClusterPreferences   []instancetypev1alpha2.VirtualMachineClusterPreference
+		)
+
+		BeforeEach(func() {
+			exportConfigMap = &core.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ExportConfigMapName,
+					Namespace: namespace,
+				},
+			}
+
+			var mockResMap *MockResMap
+			mockResMap, virtualMachineClusterInstancetypes, virtualMachineClusterPreferences, err = newMockResources(3, 2)
+			Expect(err).ToNot(HaveOccurred())
+
+			operand.KustomizeRunFunc = func(_ filesys.FileSystem, _ string) (resmap.ResMap, error) {
+				return mockResMap, nil
+			}
+			request.Instance.Spec.CommonInstancetypes = &ssp.CommonInstancetypes{
+				URL: pointer.String("https://foo.com/bar?ref=1"),
+			}
+		})
+
+		It("should not create export ConfigMap by default", func() {
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(exportConfigMap, request)
+		})
+
+		It("should export applied resources to ConfigMap", func() {
+			request.Instance.Spec.FeatureGates = &ssp.FeatureGates{
+				ExportCommonInstancetypes: true,
+			}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(exportConfigMap), exportConfigMap)).To(Succeed())
+			Expect(exportConfigMap.Data).To(HaveLen(2))
+
+			exportedInstancetypes, err := decodeResources[instancetypev1alpha2.VirtualMachineClusterInstancetype]([]byte(exportConfigMap.Data[ExportClusterInstancetypesKey]))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exportedInstancetypes).To(HaveLen(len(virtualMachineClusterInstancetypes)))
+			for i, exported := range exportedInstancetypes {
+				Expect(exported.Kind).To(Equal("VirtualMachineClusterInstancetype"))
+				Expect(exported.APIVersion).To(Equal(instancetypev1alpha2.SchemeGroupVersion.String()))
+				Expect(exported.Name).To(Equal(virtualMachineClusterInstancetypes[i].Name))
+				Expect(equality.Semantic.DeepEqual(exported.Spec, virtualMachineClusterInstancetypes[i].Spec)).To(BeTrue())
+				Expect(exported.Labels).ToNot(HaveKey(common.AppKubernetesManagedByLabel))
+			}
+
+			exportedPreferences, err := decodeResources[instancetypev1alpha2.VirtualMachineClusterPreference]([]byte(exportConfigMap.Data[ExportClusterPreferencesKey]))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exportedPreferences).To(HaveLen(len(virtualMachineClusterPreferences)))
+			for i, exported := range exportedPreferences {
+				Expect(exported.Kind).To(Equal("VirtualMachineClusterPreference"))
+				Expect(exported.APIVersion).To(Equal(instancetypev1alpha2.SchemeGroupVersion.String()))
+				Expect(exported.Name).To(Equal(virtualMachineClusterPreferences[i].Name))
+				Expect(equality.Semantic.DeepEqual(exported.Spec, virtualMachineClusterPreferences[i].Spec)).To(BeTrue())
+				Expect(exported.Labels).ToNot(HaveKey(common.AppKubernetesManagedByLabel))
+			}
+		})
+
+		It("should remove export ConfigMap when disabled", func() {
+			request.Instance.Spec.FeatureGates = &ssp.FeatureGates{
+				ExportCommonInstancetypes: true,
+			}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceExists(exportConfigMap, request)
+
+			request.Instance.Spec.FeatureGates.ExportCommonInstancetypes = false
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceNotExists(exportConfigMap, request)
+		})
+
+		It("should not remove export ConfigMap not owned by SSP CR", func() {
+			exportConfigMap.Data = map[string]string{"foo": "bar"}
+			Expect(request.Client.Create(request.Context, exportConfigMap)).To(Succeed())
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(exportConfigMap, request)
+		})
+	})
 })
 
 func addConversionFunctions(s *runtime.Scheme) error {
@@ -0,0 +1,93 @@
+package common_instancetypes
+
+import (
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	instancetypev1alpha2 "kubevirt.io/api/instancetype/v1alpha2"
+)
+
+const (
+	ExportConfigMapName = "common-instancetypes-export"
+
+	ExportClusterInstancetypesKey = "common-clusterinstancetypes.yaml"
+	ExportClusterPreferencesKey   = "common-clusterpreferences.yaml"
+
+	clusterInstancetypeKind = "VirtualMachineClusterInstancetype"
+	clusterPreferenceKind   = "VirtualMachineClusterPreference"
+)
+
+func newExportConfigMap(namespace string, data map[string]string) *core.ConfigMap {
+	return &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ExportConfigMapName,
+			Namespace: namespace,
+		},
+		Data: data,
+	}
+}
+
+// newExportData renders the manifests as multi-document YAML, that can be applied to another cluster
+func newExportData(instancetypes []instancetypev1alpha2.VirtualMachineClusterInstancetype, preferences []instancetypev1alpha2.VirtualMachineClusterPreference) (map[string]string, error) {
+	exportedInstancetypes := make([]interface{}, 0, len(instancetypes))
+	for i := range instancetypes {
+		exportedInstancetypes = append(exportedInstancetypes, &instancetypev1alpha2.VirtualMachineClusterInstancetype{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: instancetypev1alpha2.SchemeGroupVersion.String(),
+				Kind:       clusterInstancetypeKind,
+			},
+			ObjectMeta: exportedObjectMeta(&instancetypes[i].ObjectMeta),
+			Spec:       instancetypes[i].Spec,
+		})
+	}
+
+	exportedPreferences := make([]interface{}, 0, len(preferences))
+	for i := range preferences {
+		exportedPreferences = append(exportedPreferences, &instancetypev1alpha2.VirtualMachineClusterPreference{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: instancetypev1alpha2.SchemeGroupVersion.String(),
+				Kind:       clusterPreferenceKind,
+			},
+			ObjectMeta: exportedObjectMeta(&preferences[i].ObjectMeta),
+			Spec:       preferences[i].Spec,
+		})
+	}
+
+	instancetypesYaml, err := marshalManifests(exportedInstancetypes)
+	if err != nil {
+		return nil, err
+	}
+	preferencesYaml, err := marshalManifests(exportedPreferences)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		ExportClusterInstancetypesKey: instancetypesYaml,
+		ExportClusterPreferencesKey:   preferencesYaml,
+	}, nil
+}
+
+// exportedObjectMeta keeps only metadata that is meaningful in another cluster
+func exportedObjectMeta(objectMeta *metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        objectMeta.Name,
+		Labels:      objectMeta.Labels,
+		Annotations: objectMeta.Annotations,
+	}
+}
+
+func marshalManifests(manifests []interface{}) (string, error) {
+	documents := make([]string, 0, len(manifests))
+	for _, manifest := range manifests {
+		document, err := yaml.Marshal(manifest)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, string(document))
+	}
+	return strings.Join(documents, "---\n"), nil
+}
@@ -0,0 +1,79 @@
+package common_instancetypes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+const (
+	// SSHKnownHostsKey is the optional key of the SSH Secret, that contains the known host keys of the remote
+	SSHKnownHostsKey = "known_hosts"
+
+	gitSSHCommandEnv = "GIT_SSH_COMMAND"
+)
+
+// withSSHKey runs the function, while git uses the private key from the SSH Secret of the SSP CR.
+// If the URL does not use the 'ssh://' scheme, the function is run without changes.
+//
+// Kustomize runs git with the environment of the operator process, so GIT_SSH_COMMAND is set
+// for the process and restored afterwards. Only one SSP CR is reconciled at a time,
+// so fetches of common-instancetypes do not run concurrently.
+func withSSHKey(request *common.Request, rawURL string, f func() error) error {
+	instancetypes := request.Instance.Spec.CommonInstancetypes
+	if !strings.HasPrefix(rawURL, "ssh://") || instancetypes == nil || instancetypes.SSHSecretName == "" {
+		return f()
+	}
+
+	secret := &core.Secret{}
+	key := client.ObjectKey{Namespace: request.Namespace, Name: instancetypes.SSHSecretName}
+	if err := request.UncachedReader.Get(request.Context, key, secret); err != nil {
+		return fmt.Errorf("failed to get SSH Secret %s: %w", key, err)
+	}
+	privateKey := secret.Data[core.SSHAuthPrivateKey]
+	if len(privateKey) == 0 {
+		return fmt.Errorf("SSH Secret %s does not contain the %s key", key, core.SSHAuthPrivateKey)
+	}
+
+	keyDir, err := os.MkdirTemp("", "common-instancetypes-ssh-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(keyDir)
+
+	keyPath := filepath.Join(keyDir, "id")
+	if err := os.WriteFile(keyPath, privateKey, 0600); err != nil {
+		return err
+	}
+
+	sshCommand := fmt.Sprintf("ssh -i '%s' -o IdentitiesOnly=yes", keyPath)
+	if knownHosts := secret.Data[SSHKnownHostsKey]; len(knownHosts) > 0 {
+		knownHostsPath := filepath.Join(keyDir, SSHKnownHostsKey)
+		if err := os.WriteFile(knownHostsPath, knownHosts, 0600); err != nil {
+			return err
+		}
+		sshCommand += fmt.Sprintf(" -o UserKnownHostsFile='%s' -o StrictHostKeyChecking=yes", knownHostsPath)
+	} else {
+		sshCommand += " -o UserKnownHostsFile=/dev/null -o StrictHostKeyChecking=no"
+	}
+
+	oldCommand, wasSet := os.LookupEnv(gitSSHCommandEnv)
+	if err := os.Setenv(gitSSHCommandEnv, sshCommand); err != nil {
+		return err
+	}
+	defer func() {
+		if wasSet {
+			_ = os.Setenv(gitSSHCommandEnv, oldCommand)
+		} else {
+			_ = os.Unsetenv(gitSSHCommandEnv)
+		}
+	}()
+
+	return f()
+}
@@ -0,0 +1,35 @@
+package common_templates
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	BundleDir = "data/common-templates-bundle/"
+
+	bundleFilePrefix = "common-templates-"
+	bundleFileSuffix = ".yaml"
+)
+
+// BundlePath returns the path of the common templates bundle with the version
+func BundlePath(dir, version string) string {
+	return filepath.Join(dir, bundleFilePrefix+version+bundleFileSuffix)
+}
+
+// BundledVersions returns the sorted versions of all common templates bundles in the directory
+func BundledVersions(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, bundleFilePrefix+"*"+bundleFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(paths))
+	for _, path := range paths {
+		fileName := filepath.Base(path)
+		versions = append(versions, strings.TrimSuffix(strings.TrimPrefix(fileName, bundleFilePrefix), bundleFileSuffix))
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
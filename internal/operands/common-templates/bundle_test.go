@@ -0,0 +1,26 @@
+package common_templates
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bundled versions", func() {
+	It("should list versions of all bundles in directory", func() {
+		dir := GinkgoT().TempDir()
+		for _, fileName := range []string{"common-templates-v0.25.0.yaml", "common-templates-v0.24.0.yaml", "other-file.yaml"} {
+			Expect(os.WriteFile(filepath.Join(dir, fileName), nil, 0644)).To(Succeed())
+		}
+
+		versions, err := BundledVersions(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions).To(Equal([]string{"v0.24.0", "v0.25.0"}))
+	})
+
+	It("should return bundle path for version", func() {
+		Expect(BundlePath(BundleDir, "v0.25.0")).To(Equal("data/common-templates-bundle/common-templates-v0.25.0.yaml"))
+	})
+})
@@ -1,11 +1,15 @@
 package common_templates
 
 const (
-	TemplateVersionLabel         = "template.kubevirt.io/version"
-	TemplateTypeLabel            = "template.kubevirt.io/type"
-	TemplateTypeLabelBaseValue   = "base"
-	TemplateOsLabelPrefix        = "os.template.kubevirt.io/"
-	TemplateFlavorLabelPrefix    = "flavor.template.kubevirt.io/"
-	TemplateWorkloadLabelPrefix  = "workload.template.kubevirt.io/"
-	TemplateDeprecatedAnnotation = "template.kubevirt.io/deprecated"
+	TemplateVersionLabel          = "template.kubevirt.io/version"
+	TemplateTypeLabel             = "template.kubevirt.io/type"
+	TemplateTypeLabelBaseValue    = "base"
+	TemplateOsLabelPrefix         = "os.template.kubevirt.io/"
+	TemplateFlavorLabelPrefix     = "flavor.template.kubevirt.io/"
+	TemplateWorkloadLabelPrefix   = "workload.template.kubevirt.io/"
+	TemplateDeprecatedAnnotation  = "template.kubevirt.io/deprecated"
+	TemplateValidationsAnnotation = "validations"
 )
+
+// SupportedWorkloads are the workloads that can be used to filter common templates
+var SupportedWorkloads = []string{"desktop", "highperformance", "server"}
@@ -3,21 +3,32 @@ package common_templates
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	templatev1 "github.com/openshift/api/template/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/pointer"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
+	libhandler "github.com/operator-framework/operator-lib/handler"
 	"github.com/prometheus/client_golang/prometheus"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/operands"
+	template_bundle "kubevirt.io/ssp-operator/internal/template-bundle"
+	"kubevirt.io/ssp-operator/internal/template-validator/validation"
 )
 
 var (
@@ -25,6 +36,10 @@ var (
 		Name: "total_restored_common_templates",
 		Help: "The total number of common templates restored by the operator back to their original state",
 	})
+	TemplatesInNamespace = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubevirt_ssp_templates_in_namespace",
+		Help: "The number of common templates managed by the operator in a namespace",
+	}, []string{"namespace"})
 )
 
 // Define RBAC rules needed by this operand:
@@ -34,6 +49,7 @@ var templateKubevirtIoPattern = regexp.MustCompile(`^(.*\.)?template\.kubevirt\.
 
 func init() {
 	utilruntime.Must(templatev1.Install(common.Scheme))
+	utilruntime.Must(cdiv1beta1.AddToScheme(common.Scheme))
 }
 
 func WatchClusterTypes() []operands.WatchType {
@@ -43,18 +59,77 @@ func WatchClusterTypes() []operands.WatchType {
 }
 
 type commonTemplates struct {
-	templatesBundle   []templatev1.Template
+	// bundles contains templates of all embedded versions, keyed by version
+	bundles map[string]*templatesBundle
+}
+
+type templatesBundle struct {
+	version           string
+	templates         []templatev1.Template
 	deployedTemplates map[string]bool
 }
 
 var _ operands.Operand = &commonTemplates{}
 
-func New(templates []templatev1.Template) operands.Operand {
+// New creates the operand, which deploys templates of the current Version.
+// The pinnableBundles contain templates of other embedded versions, keyed by version.
+// They are deployed instead, if they are pinned by an annotation on the SSP CR.
+func New(templates []templatev1.Template, pinnableBundles map[string][]templatev1.Template) operands.Operand {
+	bundles := map[string]*templatesBundle{
+		Version: newTemplatesBundle(Version, templates),
+	}
+	for version, bundleTemplates := range pinnableBundles {
+		if version == Version {
+			continue
+		}
+		bundles[version] = newTemplatesBundle(version, bundleTemplates)
+	}
+	return &commonTemplates{bundles: bundles}
+}
+
+func newTemplatesBundle(version string, templates []templatev1.Template) *templatesBundle {
 	deployedTemplates := make(map[string]bool)
 	for _, t := range templates {
 		deployedTemplates[t.Name] = true
 	}
-	return &commonTemplates{templatesBundle: templates, deployedTemplates: deployedTemplates}
+	return &templatesBundle{
+		version:           version,
+		templates:         templates,
+		deployedTemplates: deployedTemplates,
+	}
+}
+
+// selectBundle returns the bundle pinned by the annotation on the SSP CR.
+// The bundle of the current Version is returned, if no version is pinned or the pinned version is not available.
+func (c *commonTemplates) selectBundle(request *common.Request) *templatesBundle {
+	pinnedVersion, isPinned := request.Instance.GetAnnotations()[ssp.PinTemplateVersionAnnotation]
+	if !isPinned || pinnedVersion == Version {
+		return c.bundles[Version]
+	}
+	if bundle, ok := c.bundles[pinnedVersion]; ok {
+		return bundle
+	}
+	request.Logger.Info(fmt.Sprintf("Pinned common templates version %s is not available, using version %s", pinnedVersion, Version))
+	return c.bundles[Version]
+}
+
+// filterBundleByWorkloads returns a bundle containing only templates for one of the included workloads.
+// The passed bundle is returned, if no workloads are included.
+func filterBundleByWorkloads(bundle *templatesBundle, includedWorkloads []string) *templatesBundle {
+	if len(includedWorkloads) == 0 {
+		return bundle
+	}
+
+	var templates []templatev1.Template
+	for _, template := range bundle.templates {
+		for _, workload := range includedWorkloads {
+			if template.Labels[TemplateWorkloadLabelPrefix+workload] == "true" {
+				templates = append(templates, template)
+				break
+			}
+		}
+	}
+	return newTemplatesBundle(bundle.version, templates)
 }
 
 func (c *commonTemplates) Name() string {
@@ -66,6 +141,13 @@ const (
 	operandComponent = common.AppComponentTemplating
 )
 
+// ConditionTemplatesUpToDate is the SSP condition reporting if all common templates are at the bundled version
+const ConditionTemplatesUpToDate conditionsv1.ConditionType = "TemplatesUpToDate"
+
+// ConditionCommonTemplatesDeployed is the SSP condition reporting if all bundled templates were applied
+// to the common templates namespace. It is true only after all templates were reconciled successfully.
+const ConditionCommonTemplatesDeployed conditionsv1.ConditionType = "CommonTemplatesDeployed"
+
 func (c *commonTemplates) WatchClusterTypes() []operands.WatchType {
 	return WatchClusterTypes()
 }
@@ -75,16 +157,93 @@ func (c *commonTemplates) WatchTypes() []operands.WatchType {
 }
 
 func (c *commonTemplates) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
-	reconcileTemplatesResults, err := common.CollectResourceStatus(request, reconcileTemplatesFuncs(c.templatesBundle)...)
+	if !internal.IsCommonTemplatesEnabled(request.Instance) {
+		// Existing templates are left as they are, they are not managed anymore
+		request.Logger.V(1).Info("Common templates are disabled, skipping their reconciliation")
+		conditionsv1.RemoveStatusCondition(&request.Instance.Status.Conditions, ConditionCommonTemplatesDeployed)
+		conditionsv1.RemoveStatusCondition(&request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+		request.Instance.Status.DataSourceTemplateRefs = nil
+		TemplatesInNamespace.Reset()
+		return nil, nil
+	}
+
+	fullBundle := c.selectBundle(request)
+	bundle := filterBundleByWorkloads(fullBundle, request.Instance.Spec.CommonTemplates.IncludedWorkloads)
+
+	dataSourceTemplateRefs, err := template_bundle.DataSourceTemplateRefs(bundle.templates)
+	if err != nil {
+		return nil, err
+	}
+	request.Instance.Status.DataSourceTemplateRefs = dataSourceTemplateRefs
+
+	// The condition stays false if reconciliation of templates fails
+	if err := checkTemplatesUpToDate(request, bundle); err != nil {
+		return nil, err
+	}
+	if err := checkTemplatesDeployed(request, bundle); err != nil {
+		return nil, err
+	}
+
+	canaryResults, err := reconcileCanaryTemplates(request, bundle)
 	if err != nil {
 		return nil, err
 	}
 
+	excludedResults, err := removeExcludedTemplates(request, fullBundle, bundle)
+	if err != nil {
+		return nil, err
+	}
+	canaryResults = append(canaryResults, excludedResults...)
+
+	if !isPromoted(request, bundle.version) {
+		canaryNamespace := request.Instance.Spec.CommonTemplates.CanaryNamespace
+		request.Logger.V(1).Info(fmt.Sprintf("Common templates %s are deployed only to canary namespace %s", bundle.version, canaryNamespace))
+		setTemplatesUpToDateCondition(request, v1.ConditionFalse, "PromotionPending",
+			fmt.Sprintf("Common templates %s are deployed to canary namespace %s, set annotation %s=%s on the SSP CR to promote them",
+				bundle.version, canaryNamespace, ssp.PromoteTemplatesAnnotation, bundle.version))
+		if err := updateTemplatesInNamespaceMetric(request); err != nil {
+			return nil, err
+		}
+		return canaryResults, nil
+	}
+
+	reconcileTemplatesResults, err := common.CollectResourceStatus(request,
+		reconcileTemplatesFuncs(bundle.templates, request.Instance.Spec.CommonTemplates.Namespace)...)
+	if err != nil {
+		// Report the templates applied before the failure
+		if checkErr := checkTemplatesDeployed(request, bundle); checkErr != nil {
+			request.Logger.Error(checkErr, "Failed to count deployed common templates")
+		}
+		return nil, err
+	}
+	setCommonTemplatesDeployedCondition(request, v1.ConditionTrue, "Deployed",
+		fmt.Sprintf("%d of %d common templates are deployed", len(bundle.templates), len(bundle.templates)))
+	setTemplatesUpToDateCondition(request, v1.ConditionTrue, "UpToDate",
+		fmt.Sprintf("All common templates are at version %s", bundle.version))
+
+	var dataSourceResults []common.ReconcileResult
+	if pointer.BoolDeref(request.Instance.Spec.CommonTemplates.WaitForDataSources, false) {
+		dataSourceResults, err = checkDataSourcesReady(request, dataSourceTemplateRefs)
+		if err != nil {
+			return nil, err
+		}
+		if len(dataSourceResults) > 0 {
+			notReady := make([]string, 0, len(dataSourceResults))
+			for _, result := range dataSourceResults {
+				notReady = append(notReady, result.Resource.GetName())
+			}
+			setTemplatesUpToDateCondition(request, v1.ConditionFalse, "WaitingForDataSources",
+				fmt.Sprintf("Common templates are at version %s, waiting for DataSources to be ready: %s",
+					bundle.version, strings.Join(notReady, ", ")))
+		}
+	}
+
 	if !isUpgradingNow(request) {
 		incrementTemplatesRestoredMetric(reconcileTemplatesResults, request.Logger)
 	}
 
-	oldTemplateFuncs, err := c.reconcileOlderTemplates(request)
+	// Excluded templates were removed, so they are not deprecated as older templates
+	oldTemplateFuncs, err := reconcileOlderTemplates(request, fullBundle)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +253,308 @@ func (c *commonTemplates) Reconcile(request *common.Request) ([]common.Reconcile
 		return nil, err
 	}
 
-	return append(reconcileTemplatesResults, oldTemplatesResults...), nil
+	if err := repairTemplatesOwnership(request); err != nil {
+		return nil, err
+	}
+
+	if err := updateTemplatesInNamespaceMetric(request); err != nil {
+		return nil, err
+	}
+
+	results := append(canaryResults, reconcileTemplatesResults...)
+	results = append(results, dataSourceResults...)
+	return append(results, oldTemplatesResults...), nil
+}
+
+// checkDataSourcesReady returns a not available result for each DataSource referenced by templates,
+// that does not exist or is not ready yet. The results are sorted by DataSource name.
+func checkDataSourcesReady(request *common.Request, dataSourceTemplateRefs map[string][]string) ([]common.ReconcileResult, error) {
+	names := make([]string, 0, len(dataSourceTemplateRefs))
+	for name := range dataSourceTemplateRefs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	namespace := internal.GetGoldenImagesNamespace(request.Instance)
+	var results []common.ReconcileResult
+	for _, name := range names {
+		dataSource := &cdiv1beta1.DataSource{}
+		err := request.Client.Get(request.Context, client.ObjectKey{Namespace: namespace, Name: name}, dataSource)
+		if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return nil, err
+		}
+		if err == nil && isDataSourceReady(dataSource) {
+			continue
+		}
+
+		dataSource.SetGroupVersionKind(cdiv1beta1.SchemeGroupVersion.WithKind("DataSource"))
+		dataSource.SetNamespace(namespace)
+		dataSource.SetName(name)
+
+		msg := fmt.Sprintf("DataSource is not ready, it is referenced by templates: %s",
+			strings.Join(dataSourceTemplateRefs[name], ", "))
+		results = append(results, common.ReconcileResult{
+			Status: common.ResourceStatus{
+				Progressing:  &msg,
+				NotAvailable: &msg,
+			},
+			Resource: dataSource,
+		})
+	}
+	return results, nil
+}
+
+func isDataSourceReady(dataSource *cdiv1beta1.DataSource) bool {
+	for _, condition := range dataSource.Status.Conditions {
+		if condition.Type == cdiv1beta1.DataSourceReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isPromoted returns true if the bundled templates can be deployed to the common templates namespace.
+// If a canary namespace is configured, the bundle version has to be approved by an annotation on the SSP CR.
+func isPromoted(request *common.Request, version string) bool {
+	if request.Instance.Spec.CommonTemplates.CanaryNamespace == "" {
+		return true
+	}
+	return request.Instance.GetAnnotations()[ssp.PromoteTemplatesAnnotation] == version
+}
+
+// reconcileCanaryTemplates deploys the bundled templates to the canary namespace, if it is configured.
+// Bundled templates in other namespaces than the common templates namespace and the canary namespace
+// are removed, so a previous canary namespace is cleaned up.
+func reconcileCanaryTemplates(request *common.Request, bundle *templatesBundle) ([]common.ReconcileResult, error) {
+	canaryNamespace := request.Instance.Spec.CommonTemplates.CanaryNamespace
+
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []common.ReconcileFunc
+	for i := range managedTemplates.Items {
+		template := &managedTemplates.Items[i]
+		if template.Namespace == request.Instance.Spec.CommonTemplates.Namespace || template.Namespace == canaryNamespace {
+			continue
+		}
+		if !bundle.deployedTemplates[template.Name] || !common.CheckOwnerAnnotation(template, request.Instance) {
+			continue
+		}
+		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+			err := request.Client.Delete(request.Context, template)
+			if err != nil && !errors.IsNotFound(err) {
+				return common.ReconcileResult{}, err
+			}
+			return common.ReconcileResult{
+				Resource: template,
+			}, nil
+		})
+	}
+
+	if canaryNamespace != "" {
+		// Copies are used, so the canary results do not share objects with the common templates namespace
+		canaryBundle := make([]templatev1.Template, 0, len(bundle.templates))
+		for i := range bundle.templates {
+			canaryBundle = append(canaryBundle, *bundle.templates[i].DeepCopy())
+		}
+		funcs = append(funcs, reconcileTemplatesFuncs(canaryBundle, canaryNamespace)...)
+	}
+	return common.CollectResourceStatus(request, funcs...)
+}
+
+// removeExcludedTemplates removes templates of the full bundle, that are not included in the filtered bundle,
+// from the common templates namespace and the canary namespace.
+func removeExcludedTemplates(request *common.Request, fullBundle, bundle *templatesBundle) ([]common.ReconcileResult, error) {
+	if len(fullBundle.templates) == len(bundle.templates) {
+		return nil, nil
+	}
+
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	commonTemplates := request.Instance.Spec.CommonTemplates
+	var funcs []common.ReconcileFunc
+	for i := range managedTemplates.Items {
+		template := &managedTemplates.Items[i]
+		if template.Namespace != commonTemplates.Namespace && template.Namespace != commonTemplates.CanaryNamespace {
+			continue
+		}
+		if !fullBundle.deployedTemplates[template.Name] || bundle.deployedTemplates[template.Name] {
+			continue
+		}
+		if !common.CheckOwnerAnnotation(template, request.Instance) {
+			continue
+		}
+		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+			err := request.Client.Delete(request.Context, template)
+			if err != nil && !errors.IsNotFound(err) {
+				return common.ReconcileResult{}, err
+			}
+			request.Logger.Info(fmt.Sprintf("Removed template of excluded workload: %s/%s", template.Namespace, template.Name))
+			return common.ReconcileResult{
+				Resource:        template,
+				OperationResult: common.OperationResultDeleted,
+			}, nil
+		})
+	}
+	return common.CollectResourceStatus(request, funcs...)
+}
+
+// repairTemplatesOwnership ensures that all templates managed by the operator are owned by the SSP CR.
+// Templates can be in a different namespace than the SSP CR, so the owner is tracked by owner annotations.
+// An ownerReference pointing to the SSP CR from a different namespace would cause removal by the garbage collector.
+func repairTemplatesOwnership(request *common.Request) error {
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.InNamespace(request.Instance.Spec.CommonTemplates.Namespace),
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range managedTemplates.Items {
+		template := &managedTemplates.Items[i]
+		if !template.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if len(template.GetOwnerReferences()) == 0 && common.CheckOwnerAnnotation(template, request.Instance) {
+			continue
+		}
+
+		patch := client.MergeFrom(template.DeepCopy())
+		template.SetOwnerReferences(nil)
+		if err := libhandler.SetOwnerAnnotations(request.Instance, template); err != nil {
+			return err
+		}
+		if err := request.Client.Patch(request.Context, template, patch); err != nil {
+			return err
+		}
+		request.Logger.Info(fmt.Sprintf("Repaired owner of template: %s", template.GetName()))
+	}
+	return nil
+}
+
+// checkTemplatesUpToDate sets the TemplatesUpToDate condition to false,
+// if any existing bundled template has a different version than the bundle.
+func checkTemplatesUpToDate(request *common.Request, bundle *templatesBundle) error {
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.InNamespace(request.Instance.Spec.CommonTemplates.Namespace),
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	outdatedCount := 0
+	for _, template := range managedTemplates.Items {
+		if bundle.deployedTemplates[template.Name] && template.Labels[TemplateVersionLabel] != bundle.version {
+			outdatedCount++
+		}
+	}
+
+	if outdatedCount > 0 {
+		setTemplatesUpToDateCondition(request, v1.ConditionFalse, "Outdated",
+			fmt.Sprintf("%d common templates are not at version %s", outdatedCount, bundle.version))
+	}
+	return nil
+}
+
+// checkTemplatesDeployed sets the CommonTemplatesDeployed condition to false,
+// if not all bundled templates exist at the bundle version in the common templates namespace.
+func checkTemplatesDeployed(request *common.Request, bundle *templatesBundle) error {
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.InNamespace(request.Instance.Spec.CommonTemplates.Namespace),
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+			TemplateVersionLabel:               bundle.version,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	deployedCount := 0
+	for _, template := range managedTemplates.Items {
+		if bundle.deployedTemplates[template.Name] {
+			deployedCount++
+		}
+	}
+
+	if deployedCount < len(bundle.templates) {
+		setCommonTemplatesDeployedCondition(request, v1.ConditionFalse, "Deploying",
+			fmt.Sprintf("%d of %d common templates are deployed", deployedCount, len(bundle.templates)))
+	}
+	return nil
+}
+
+func setCommonTemplatesDeployedCondition(request *common.Request, status v1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&request.Instance.Status.Conditions, conditionsv1.Condition{
+		Type:    ConditionCommonTemplatesDeployed,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+func setTemplatesUpToDateCondition(request *common.Request, status v1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&request.Instance.Status.Conditions, conditionsv1.Condition{
+		Type:    ConditionTemplatesUpToDate,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// updateTemplatesInNamespaceMetric sets the number of managed templates in each namespace.
+// Namespaces without templates are removed from the metric.
+func updateTemplatesInNamespaceMetric(request *common.Request) error {
+	managedTemplates := &templatev1.TemplateList{}
+	err := request.Client.List(request.Context, managedTemplates,
+		client.MatchingLabels{
+			common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+			common.AppKubernetesNameLabel:      operandName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	templatesCount := map[string]int{}
+	for _, template := range managedTemplates.Items {
+		templatesCount[template.Namespace]++
+	}
+
+	TemplatesInNamespace.Reset()
+	for namespace, count := range templatesCount {
+		TemplatesInNamespace.WithLabelValues(namespace).Set(float64(count))
+	}
+	return nil
 }
 
 func isUpgradingNow(request *common.Request) bool {
@@ -116,6 +576,12 @@ func incrementTemplatesRestoredMetric(reconcileResults []common.ReconcileResult,
 }
 
 func (c *commonTemplates) Cleanup(request *common.Request) ([]common.CleanupResult, error) {
+	if !internal.IsCommonTemplatesEnabled(request.Instance) {
+		// Templates are not managed by the operator, so they are not removed
+		TemplatesInNamespace.Reset()
+		return nil, nil
+	}
+
 	var objects []client.Object
 	namespace := request.Instance.Spec.CommonTemplates.Namespace
 
@@ -129,12 +595,49 @@ func (c *commonTemplates) Cleanup(request *common.Request) ([]common.CleanupResu
 		objects = append(objects, &obj)
 	}
 
-	for index := range c.templatesBundle {
-		c.templatesBundle[index].ObjectMeta.Namespace = namespace
-		objects = append(objects, &c.templatesBundle[index])
+	// Templates of all bundles are removed, because a different version could have been pinned before
+	templates := c.allTemplates()
+	for index := range templates {
+		templates[index].ObjectMeta.Namespace = namespace
+		objects = append(objects, &templates[index])
+	}
+
+	if canaryNamespace := request.Instance.Spec.CommonTemplates.CanaryNamespace; canaryNamespace != "" {
+		for index := range templates {
+			canaryTemplate := templates[index].DeepCopy()
+			canaryTemplate.Namespace = canaryNamespace
+			objects = append(objects, canaryTemplate)
+		}
+	}
+
+	results, err := common.DeleteAll(request, objects...)
+	if err != nil {
+		return nil, err
+	}
+	TemplatesInNamespace.Reset()
+	return results, nil
+}
+
+// allTemplates returns copies of templates from all bundles. Templates with the same name are included only once.
+func (c *commonTemplates) allTemplates() []templatev1.Template {
+	versions := make([]string, 0, len(c.bundles))
+	for version := range c.bundles {
+		versions = append(versions, version)
 	}
+	sort.Strings(versions)
 
-	return common.DeleteAll(request, objects...)
+	var templates []templatev1.Template
+	names := map[string]bool{}
+	for _, version := range versions {
+		for _, template := range c.bundles[version].templates {
+			if names[template.Name] {
+				continue
+			}
+			names[template.Name] = true
+			templates = append(templates, *template.DeepCopy())
+		}
+	}
+	return templates
 }
 
 func getDeprecatedTemplates(request *common.Request) (*templatev1.TemplateList, error) {
@@ -159,25 +662,25 @@ func getDeprecatedTemplatesLabelSelector() labels.Selector {
 	return labels.NewSelector().Add(*deprecatedRequirement)
 }
 
-func getOldTemplatesLabelSelector() labels.Selector {
+func getOldTemplatesLabelSelector(version string) labels.Selector {
 	baseRequirement, err := labels.NewRequirement(TemplateTypeLabel, selection.Equals, []string{TemplateTypeLabelBaseValue})
 	if err != nil {
 		panic(fmt.Sprintf("Failed creating label selector for '%s=%s'", TemplateTypeLabel, TemplateTypeLabelBaseValue))
 	}
 
 	// Only fetching older templates  to prevent duplication of API calls
-	versionRequirement, err := labels.NewRequirement(TemplateVersionLabel, selection.NotEquals, []string{Version})
+	versionRequirement, err := labels.NewRequirement(TemplateVersionLabel, selection.NotEquals, []string{version})
 	if err != nil {
-		panic(fmt.Sprintf("Failed creating label selector for '%s!=%s'", TemplateVersionLabel, Version))
+		panic(fmt.Sprintf("Failed creating label selector for '%s!=%s'", TemplateVersionLabel, version))
 	}
 
 	return labels.NewSelector().Add(*baseRequirement, *versionRequirement)
 }
 
-func (c *commonTemplates) reconcileOlderTemplates(request *common.Request) ([]common.ReconcileFunc, error) {
+func reconcileOlderTemplates(request *common.Request, bundle *templatesBundle) ([]common.ReconcileFunc, error) {
 	existingTemplates := &templatev1.TemplateList{}
 	err := request.Client.List(request.Context, existingTemplates, &client.ListOptions{
-		LabelSelector: getOldTemplatesLabelSelector(),
+		LabelSelector: getOldTemplatesLabelSelector(bundle.version),
 		Namespace:     request.Instance.Spec.CommonTemplates.Namespace,
 	})
 
@@ -186,7 +689,7 @@ func (c *commonTemplates) reconcileOlderTemplates(request *common.Request) ([]co
 		return nil, err
 	}
 
-	templatesVersion, err := semver.ParseTolerant(Version)
+	templatesVersion, err := semver.ParseTolerant(bundle.version)
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +698,7 @@ func (c *commonTemplates) reconcileOlderTemplates(request *common.Request) ([]co
 	for i := range existingTemplates.Items {
 		template := &existingTemplates.Items[i]
 
-		if _, ok := c.deployedTemplates[template.Name]; ok {
+		if _, ok := bundle.deployedTemplates[template.Name]; ok {
 			continue
 		}
 
@@ -223,6 +726,12 @@ func (c *commonTemplates) reconcileOlderTemplates(request *common.Request) ([]co
 						}
 					}
 					foundTemplate.Labels[TemplateDeprecatedAnnotation] = "true"
+
+					// Older templates are not in the bundle, so a malformed annotation cannot be restored
+					if removeMalformedValidations(foundTemplate) {
+						request.Logger.Info(fmt.Sprintf("Removed malformed %s annotation from template: %s",
+							TemplateValidationsAnnotation, foundTemplate.GetName()))
+					}
 				}).
 				Reconcile()
 		})
@@ -231,12 +740,11 @@ func (c *commonTemplates) reconcileOlderTemplates(request *common.Request) ([]co
 	return funcs, nil
 }
 
-func reconcileTemplatesFuncs(templatesBundle []templatev1.Template) []common.ReconcileFunc {
+func reconcileTemplatesFuncs(templatesBundle []templatev1.Template, namespace string) []common.ReconcileFunc {
 	funcs := make([]common.ReconcileFunc, 0, len(templatesBundle))
 	for i := range templatesBundle {
 		template := &templatesBundle[i]
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
-			namespace := request.Instance.Spec.CommonTemplates.Namespace
 			template.ObjectMeta.Namespace = namespace
 			return common.CreateOrUpdate(request).
 				ClusterResource(template).
@@ -276,8 +784,24 @@ func syncPredefinedAnnotationsAndLabels(foundTemplate, newTemplate *templatev1.T
 	}
 }
 
+// removeMalformedValidations removes the validations annotation, if the template validator cannot parse it.
+// It returns true if the annotation was removed.
+func removeMalformedValidations(template *templatev1.Template) bool {
+	validations, exists := template.Annotations[TemplateValidationsAnnotation]
+	if !exists {
+		return false
+	}
+	if _, err := validation.ParseRules([]byte(validations)); err == nil {
+		return false
+	}
+	delete(template.Annotations, TemplateValidationsAnnotation)
+	return true
+}
+
 func isPredefinedKey(key string) bool {
 	return key == "description" ||
+		// The template validator relies on this annotation, so it must match the bundle
+		key == TemplateValidationsAnnotation ||
 		key == "tags" ||
 		key == "iconClass" ||
 		strings.HasPrefix(key, "openshift.io/") ||
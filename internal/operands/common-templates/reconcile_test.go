@@ -2,6 +2,7 @@ package common_templates
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -9,11 +10,15 @@ import (
 	. "github.com/onsi/gomega"
 
 	templatev1 "github.com/openshift/api/template/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -21,8 +26,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal"
 	"kubevirt.io/ssp-operator/internal/common"
 	"kubevirt.io/ssp-operator/internal/operands"
+	template_bundle "kubevirt.io/ssp-operator/internal/template-bundle"
 	. "kubevirt.io/ssp-operator/internal/test-utils"
 )
 
@@ -36,6 +43,8 @@ const (
 	testFlavorLabel   = TemplateFlavorLabelPrefix + "test"
 	testWorkflowLabel = TemplateWorkloadLabelPrefix + "server"
 	futureVersion     = "v999.999.999"
+
+	testValidations = `[{"name": "minimal-required-memory", "path": "jsonpath::.spec.domain.memory.guest", "rule": "integer", "message": "This VM requires more memory.", "min": 1073741824}]`
 )
 
 func TestTemplates(t *testing.T) {
@@ -53,7 +62,7 @@ var _ = Describe("Common-Templates operand", func() {
 
 	BeforeEach(func() {
 		testTemplates = getTestTemplates()
-		operand = New(testTemplates)
+		operand = New(testTemplates, nil)
 
 		client := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
 		request = common.Request{
@@ -103,6 +112,175 @@ var _ = Describe("Common-Templates operand", func() {
 		Expect(value).To(BeZero())
 	})
 
+	Context("enabled field", func() {
+		It("should create templates when common templates are explicitly enabled", func() {
+			request.Instance.Spec.CommonTemplates.Enabled = pointer.Bool(true)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			for _, template := range testTemplates {
+				template.Namespace = namespace
+				ExpectResourceExists(&template, request)
+			}
+		})
+
+		It("should not create templates when common templates are disabled", func() {
+			request.Instance.Spec.CommonTemplates.Enabled = pointer.Bool(false)
+			request.Instance.Spec.CommonTemplates.Namespace = ""
+
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			templates := &templatev1.TemplateList{}
+			Expect(request.Client.List(request.Context, templates)).To(Succeed())
+			Expect(templates.Items).To(BeEmpty())
+		})
+
+		It("should not update or remove existing templates when common templates are disabled", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := testTemplates[0].DeepCopy()
+			template.Namespace = namespace
+			foundTemplate := getTemplate(request, template)
+			foundTemplate.Labels[TemplateVersionLabel] = "changed"
+			Expect(request.Client.Update(request.Context, foundTemplate)).To(Succeed())
+
+			request.Instance.Spec.CommonTemplates.Enabled = pointer.Bool(false)
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplate(request, template).Labels).To(HaveKeyWithValue(TemplateVersionLabel, "changed"))
+			for _, template := range testTemplates {
+				template.Namespace = namespace
+				ExpectResourceExists(&template, request)
+			}
+
+			_, err = operand.Cleanup(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceExists(template, request)
+		})
+
+		It("should remove template conditions when common templates are disabled", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCommonTemplatesDeployed)).ToNot(BeNil())
+			Expect(conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)).ToNot(BeNil())
+
+			request.Instance.Spec.CommonTemplates.Enabled = pointer.Bool(false)
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCommonTemplatesDeployed)).To(BeNil())
+			Expect(conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)).To(BeNil())
+		})
+	})
+
+	Context("DataSource template references", func() {
+		BeforeEach(func() {
+			bundle, err := template_bundle.ReadBundle("../../template-bundle/template-bundle-test.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			operand = New(bundle.Templates, nil)
+		})
+
+		It("should report templates referencing each DataSource", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(request.Instance.Status.DataSourceTemplateRefs).To(Equal(map[string][]string{
+				"centos-stream8": {"centos-stream8-desktop-large", "centos-stream8-server-medium"},
+				"win10":          {"windows10-desktop-medium"},
+			}))
+		})
+
+		It("should report only templates of included workloads", func() {
+			request.Instance.Spec.CommonTemplates.IncludedWorkloads = []string{"server"}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(request.Instance.Status.DataSourceTemplateRefs).To(Equal(map[string][]string{
+				"centos-stream8": {"centos-stream8-server-medium"},
+			}))
+		})
+	})
+
+	Context("wait for DataSources", func() {
+		createDataSource := func(name string, ready v1.ConditionStatus) {
+			dataSource := &cdiv1beta1.DataSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: internal.GetGoldenImagesNamespace(request.Instance),
+				},
+				Status: cdiv1beta1.DataSourceStatus{
+					Conditions: []cdiv1beta1.DataSourceCondition{{
+						Type:           cdiv1beta1.DataSourceReady,
+						ConditionState: cdiv1beta1.ConditionState{Status: ready},
+					}},
+				},
+			}
+			Expect(request.Client.Create(request.Context, dataSource)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			bundle, err := template_bundle.ReadBundle("../../template-bundle/template-bundle-test.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			operand = New(bundle.Templates, nil)
+		})
+
+		It("should not wait for DataSources by default", func() {
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			for _, result := range results {
+				Expect(result.IsSuccess()).To(BeTrue())
+			}
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(v1.ConditionTrue))
+		})
+
+		It("should not be ready while DataSources are missing or not ready", func() {
+			request.Instance.Spec.CommonTemplates.WaitForDataSources = pointer.Bool(true)
+			createDataSource("centos-stream8", v1.ConditionFalse)
+
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var notReady []string
+			for _, result := range results {
+				if result.Resource.GetObjectKind().GroupVersionKind().Kind != "DataSource" {
+					continue
+				}
+				Expect(result.Status.NotAvailable).ToNot(BeNil())
+				Expect(result.Status.Progressing).ToNot(BeNil())
+				notReady = append(notReady, result.Resource.GetName())
+			}
+			Expect(notReady).To(Equal([]string{"centos-stream8", "win10"}))
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(v1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("WaitingForDataSources"))
+			Expect(condition.Message).To(ContainSubstring("centos-stream8, win10"))
+		})
+
+		It("should be ready when all DataSources are ready", func() {
+			request.Instance.Spec.CommonTemplates.WaitForDataSources = pointer.Bool(true)
+			createDataSource("centos-stream8", v1.ConditionTrue)
+			createDataSource("win10", v1.ConditionTrue)
+
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			for _, result := range results {
+				Expect(result.IsSuccess()).To(BeTrue())
+			}
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(v1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("UpToDate"))
+		})
+	})
+
 	It("should reconcile predefined labels", func() {
 		const (
 			defaultOsLabel = "template.kubevirt.io/default-os-variant"
@@ -276,6 +454,608 @@ var _ = Describe("Common-Templates operand", func() {
 
 			}
 		})
+
+		It("should remove malformed validations annotation from old templates", func() {
+			oldTpl.Annotations[TemplateValidationsAnnotation] = "[{malformed"
+			Expect(request.Client.Update(request.Context, oldTpl)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplate(request, oldTpl).Annotations).ToNot(HaveKey(TemplateValidationsAnnotation))
+		})
+
+		It("should keep valid validations annotation on old templates", func() {
+			oldTpl.Annotations[TemplateValidationsAnnotation] = testValidations
+			Expect(request.Client.Update(request.Context, oldTpl)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplate(request, oldTpl).Annotations).To(HaveKeyWithValue(TemplateValidationsAnnotation, testValidations))
+		})
+	})
+
+	Context("validations annotation", func() {
+		BeforeEach(func() {
+			testTemplates[0].Annotations = map[string]string{
+				TemplateValidationsAnnotation: testValidations,
+			}
+			operand = New(testTemplates, nil)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		setValidations := func(template *templatev1.Template, validations string) {
+			foundTemplate := getTemplate(request, template)
+			if foundTemplate.Annotations == nil {
+				foundTemplate.Annotations = map[string]string{}
+			}
+			foundTemplate.Annotations[TemplateValidationsAnnotation] = validations
+			Expect(request.Client.Update(request.Context, foundTemplate)).To(Succeed())
+		}
+
+		It("should repair malformed validations annotation", func() {
+			setValidations(&testTemplates[0], "[{malformed")
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplate(request, &testTemplates[0]).Annotations).To(HaveKeyWithValue(TemplateValidationsAnnotation, testValidations))
+		})
+
+		It("should remove validations annotation not present in the bundle", func() {
+			setValidations(&testTemplates[1], "[{malformed")
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplate(request, &testTemplates[1]).Annotations).ToNot(HaveKey(TemplateValidationsAnnotation))
+		})
+	})
+
+	Context("templates ownership", func() {
+		strippedOwnerReference := func() metav1.OwnerReference {
+			return metav1.OwnerReference{
+				APIVersion: ssp.GroupVersion.String(),
+				Kind:       "SSP",
+				Name:       "stale-owner",
+				UID:        "stale-uid",
+			}
+		}
+
+		expectOwnedBySsp := func(key client.ObjectKey) {
+			template := &templatev1.Template{}
+			ExpectWithOffset(1, request.Client.Get(request.Context, key, template)).To(Succeed())
+			ExpectWithOffset(1, template.GetOwnerReferences()).To(BeEmpty())
+			ExpectWithOffset(1, common.CheckOwnerAnnotation(template, request.Instance)).To(BeTrue())
+		}
+
+		It("should repair owner of a managed template", func() {
+			managedTemplate := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "managed-template",
+					Namespace: namespace,
+					Labels: map[string]string{
+						common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+						common.AppKubernetesNameLabel:      operandName,
+					},
+					OwnerReferences: []metav1.OwnerReference{strippedOwnerReference()},
+				},
+			}
+			Expect(request.Client.Create(request.Context, managedTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectOwnedBySsp(client.ObjectKeyFromObject(managedTemplate))
+		})
+
+		It("should repair owner of a bundled template with stripped owner annotations", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := getTemplate(request, &testTemplates[0])
+			delete(template.Annotations, libhandler.TypeAnnotation)
+			delete(template.Annotations, libhandler.NamespacedNameAnnotation)
+			Expect(request.Client.Update(request.Context, template)).To(Succeed())
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectOwnedBySsp(client.ObjectKeyFromObject(template))
+		})
+
+		It("should not change templates not managed by the operator", func() {
+			userTemplate := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "user-template",
+					Namespace:       namespace,
+					OwnerReferences: []metav1.OwnerReference{strippedOwnerReference()},
+				},
+			}
+			Expect(request.Client.Create(request.Context, userTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			foundTemplate := &templatev1.Template{}
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(userTemplate), foundTemplate)).To(Succeed())
+			Expect(foundTemplate.GetOwnerReferences()).To(Equal([]metav1.OwnerReference{strippedOwnerReference()}))
+			Expect(foundTemplate.GetAnnotations()).ToNot(HaveKey(libhandler.TypeAnnotation))
+		})
+	})
+
+	Context("TemplatesUpToDate condition", func() {
+		expectCondition := func(status v1.ConditionStatus, message string) {
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			ExpectWithOffset(1, condition).ToNot(BeNil())
+			ExpectWithOffset(1, condition.Status).To(Equal(status))
+			ExpectWithOffset(1, condition.Message).To(Equal(message))
+		}
+
+		BeforeEach(func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should be true when templates are reconciled", func() {
+			expectCondition(v1.ConditionTrue, "All common templates are at version "+Version)
+		})
+
+		It("should be false until templates from previous version are updated", func() {
+			// Simulate templates deployed by the previous operator version
+			for i := range testTemplates {
+				template := getTemplate(request, &testTemplates[i])
+				template.Labels[TemplateVersionLabel] = "v0.0.1"
+				Expect(request.Client.Update(request.Context, template)).To(Succeed())
+			}
+			request.VersionCache = common.VersionCache{}
+
+			// Templates cannot be updated yet
+			workingClient := request.Client
+			request.Client = failingUpdateClient{Client: workingClient}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).To(HaveOccurred())
+			expectCondition(v1.ConditionFalse, fmt.Sprintf("%d common templates are not at version %s", len(testTemplates), Version))
+
+			request.Client = workingClient
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(v1.ConditionTrue, "All common templates are at version "+Version)
+		})
+
+		It("should ignore templates not in the bundle", func() {
+			oldTemplate := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "removed-template",
+					Namespace: namespace,
+					Labels: map[string]string{
+						common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+						common.AppKubernetesNameLabel:      operandName,
+						TemplateVersionLabel:               "v0.0.1",
+					},
+				},
+			}
+			Expect(request.Client.Create(request.Context, oldTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(v1.ConditionTrue, "All common templates are at version "+Version)
+		})
+	})
+
+	Context("CommonTemplatesDeployed condition", func() {
+		expectCondition := func(status v1.ConditionStatus, message string) {
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCommonTemplatesDeployed)
+			ExpectWithOffset(1, condition).ToNot(BeNil())
+			ExpectWithOffset(1, condition.Status).To(Equal(status))
+			ExpectWithOffset(1, condition.Message).To(Equal(message))
+		}
+
+		It("should be true when all templates are reconciled", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(v1.ConditionTrue, fmt.Sprintf("%d of %d common templates are deployed", len(testTemplates), len(testTemplates)))
+		})
+
+		It("should flip from false to true after all templates are reconciled", func() {
+			workingClient := request.Client
+			request.Client = &failingCreateClient{Client: workingClient, allowedCreates: 1}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).To(HaveOccurred())
+			expectCondition(v1.ConditionFalse, fmt.Sprintf("1 of %d common templates are deployed", len(testTemplates)))
+
+			request.Client = workingClient
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(v1.ConditionTrue, fmt.Sprintf("%d of %d common templates are deployed", len(testTemplates), len(testTemplates)))
+		})
+
+		It("should be false when templates are at a different version", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				template := getTemplate(request, &testTemplates[i])
+				template.Labels[TemplateVersionLabel] = "v0.0.1"
+				Expect(request.Client.Update(request.Context, template)).To(Succeed())
+			}
+			request.VersionCache = common.VersionCache{}
+			request.Client = failingUpdateClient{Client: request.Client}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).To(HaveOccurred())
+			expectCondition(v1.ConditionFalse, fmt.Sprintf("0 of %d common templates are deployed", len(testTemplates)))
+		})
+
+		It("should be false while templates are pending promotion", func() {
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = "canary"
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(v1.ConditionFalse, fmt.Sprintf("0 of %d common templates are deployed", len(testTemplates)))
+		})
+	})
+
+	Context("pinned template version", func() {
+		const pinnedVersion = "v0.24.0"
+
+		var pinnedTemplates []templatev1.Template
+
+		setPinnedVersion := func(version string) {
+			request.Instance.Annotations = map[string]string{
+				ssp.PinTemplateVersionAnnotation: version,
+			}
+			request.VersionCache = common.VersionCache{}
+		}
+
+		expectTemplatesAtVersion := func(templates []templatev1.Template, version string) {
+			for i := range templates {
+				template := templates[i].DeepCopy()
+				template.Namespace = namespace
+				ExpectWithOffset(1, getTemplate(request, template).Labels).To(HaveKeyWithValue(TemplateVersionLabel, version))
+			}
+		}
+
+		BeforeEach(func() {
+			pinnedTemplates = getTestTemplates()
+			for i := range pinnedTemplates {
+				pinnedTemplates[i].Labels[TemplateVersionLabel] = pinnedVersion
+			}
+			operand = New(testTemplates, map[string][]templatev1.Template{
+				pinnedVersion: pinnedTemplates,
+			})
+		})
+
+		It("should deploy latest version when no version is pinned", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectTemplatesAtVersion(testTemplates, Version)
+		})
+
+		It("should deploy pinned version", func() {
+			setPinnedVersion(pinnedVersion)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectTemplatesAtVersion(pinnedTemplates, pinnedVersion)
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(v1.ConditionTrue))
+			Expect(condition.Message).To(Equal("All common templates are at version " + pinnedVersion))
+		})
+
+		It("should not deprecate templates of pinned version", func() {
+			setPinnedVersion(pinnedVersion)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range pinnedTemplates {
+				template := pinnedTemplates[i].DeepCopy()
+				template.Namespace = namespace
+				Expect(getTemplate(request, template).Labels).ToNot(HaveKey(TemplateDeprecatedAnnotation))
+			}
+		})
+
+		It("should retain pinned version after templates of latest version were deployed", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectTemplatesAtVersion(testTemplates, Version)
+
+			setPinnedVersion(pinnedVersion)
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectTemplatesAtVersion(pinnedTemplates, pinnedVersion)
+		})
+
+		It("should deploy latest version when pinned version is not available", func() {
+			setPinnedVersion("v0.1.0")
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			expectTemplatesAtVersion(testTemplates, Version)
+		})
+	})
+
+	Context("canary namespace", func() {
+		const canaryNamespace = "canary-ns"
+
+		templateIn := func(template *templatev1.Template, namespace string) *templatev1.Template {
+			copied := template.DeepCopy()
+			copied.Namespace = namespace
+			copied.ResourceVersion = ""
+			return copied
+		}
+
+		expectCondition := func(status v1.ConditionStatus, reason string) {
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionTemplatesUpToDate)
+			ExpectWithOffset(1, condition).ToNot(BeNil())
+			ExpectWithOffset(1, condition.Status).To(Equal(status))
+			ExpectWithOffset(1, condition.Reason).To(Equal(reason))
+		}
+
+		BeforeEach(func() {
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = canaryNamespace
+		})
+
+		It("should deploy templates only to canary namespace before promotion", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceExists(templateIn(&testTemplates[i], canaryNamespace), request)
+				ExpectResourceNotExists(templateIn(&testTemplates[i], namespace), request)
+			}
+			expectCondition(v1.ConditionFalse, "PromotionPending")
+		})
+
+		It("should not deprecate templates from previous version before promotion", func() {
+			previousTemplate := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "previous-template",
+					Namespace: namespace,
+					Labels: map[string]string{
+						TemplateVersionLabel: "v0.0.1",
+						TemplateTypeLabel:    TemplateTypeLabelBaseValue,
+						testOsLabel:          "true",
+					},
+				},
+			}
+			Expect(request.Client.Create(request.Context, previousTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedTemplate := getTemplate(request, previousTemplate)
+			Expect(updatedTemplate.Labels).ToNot(HaveKey(TemplateDeprecatedAnnotation))
+			Expect(updatedTemplate.Labels).To(HaveKey(testOsLabel))
+		})
+
+		It("should not promote templates approved for a different version", func() {
+			request.Instance.Annotations = map[string]string{
+				ssp.PromoteTemplatesAnnotation: "v0.0.1",
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceNotExists(templateIn(&testTemplates[i], namespace), request)
+			}
+			expectCondition(v1.ConditionFalse, "PromotionPending")
+		})
+
+		It("should deploy templates to common templates namespace after promotion", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Annotations = map[string]string{
+				ssp.PromoteTemplatesAnnotation: Version,
+			}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceExists(templateIn(&testTemplates[i], canaryNamespace), request)
+				ExpectResourceExists(templateIn(&testTemplates[i], namespace), request)
+			}
+			expectCondition(v1.ConditionTrue, "UpToDate")
+		})
+
+		It("should remove canary templates when canary namespace is removed", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = ""
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceNotExists(templateIn(&testTemplates[i], canaryNamespace), request)
+				ExpectResourceExists(templateIn(&testTemplates[i], namespace), request)
+			}
+		})
+
+		It("should remove canary templates on cleanup", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = operand.Cleanup(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceNotExists(templateIn(&testTemplates[i], canaryNamespace), request)
+			}
+		})
+	})
+
+	Context("included workloads", func() {
+		templateIn := func(template *templatev1.Template, namespace string) *templatev1.Template {
+			copied := template.DeepCopy()
+			copied.Namespace = namespace
+			copied.ResourceVersion = ""
+			return copied
+		}
+
+		It("should deploy only templates of included workloads", func() {
+			request.Instance.Spec.CommonTemplates.IncludedWorkloads = []string{"server"}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(templateIn(&testTemplates[0], namespace), request)
+			ExpectResourceNotExists(templateIn(&testTemplates[1], namespace), request)
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCommonTemplatesDeployed)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(v1.ConditionTrue))
+			Expect(condition.Message).To(Equal("1 of 1 common templates are deployed"))
+		})
+
+		It("should deploy all templates when no workload is included", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testTemplates {
+				ExpectResourceExists(templateIn(&testTemplates[i], namespace), request)
+			}
+		})
+
+		It("should remove templates of excluded workloads", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.IncludedWorkloads = []string{"desktop"}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(templateIn(&testTemplates[0], namespace), request)
+			ExpectResourceExists(templateIn(&testTemplates[1], namespace), request)
+		})
+
+		It("should remove templates of excluded workloads from canary namespace", func() {
+			const canaryNamespace = "canary-ns"
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = canaryNamespace
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.IncludedWorkloads = []string{"desktop"}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(templateIn(&testTemplates[0], canaryNamespace), request)
+			ExpectResourceExists(templateIn(&testTemplates[1], canaryNamespace), request)
+		})
+
+		It("should not remove templates not managed by the operator", func() {
+			request.Instance.Spec.CommonTemplates.IncludedWorkloads = []string{"desktop"}
+
+			unmanagedTemplate := templateIn(&testTemplates[0], namespace)
+			Expect(request.Client.Create(request.Context, unmanagedTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(templateIn(&testTemplates[0], namespace), request)
+		})
+	})
+
+	Context("kubevirt_ssp_templates_in_namespace metric", func() {
+		const otherNamespace = "other-ns"
+
+		getTemplatesInNamespaceMetric := func(namespace string) float64 {
+			metric := &io_prometheus_client.Metric{}
+			ExpectWithOffset(1, TemplatesInNamespace.WithLabelValues(namespace).Write(metric)).To(Succeed())
+			return metric.GetGauge().GetValue()
+		}
+
+		createManagedTemplate := func(name, namespace string) {
+			template := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					Labels: map[string]string{
+						common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+						common.AppKubernetesNameLabel:      operandName,
+					},
+				},
+			}
+			ExpectWithOffset(1, request.Client.Create(request.Context, template)).To(Succeed())
+		}
+
+		countTemplatesInNamespaceMetrics := func() int {
+			ch := make(chan prometheus.Metric, 10)
+			TemplatesInNamespace.Collect(ch)
+			close(ch)
+			return len(ch)
+		}
+
+		BeforeEach(func() {
+			TemplatesInNamespace.Reset()
+		})
+
+		It("should count templates in each namespace", func() {
+			createManagedTemplate("other-template-1", otherNamespace)
+			createManagedTemplate("other-template-2", otherNamespace)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getTemplatesInNamespaceMetric(namespace)).To(Equal(float64(len(testTemplates))))
+			Expect(getTemplatesInNamespaceMetric(otherNamespace)).To(Equal(float64(2)))
+		})
+
+		It("should not count templates not managed by the operator", func() {
+			unmanagedTemplate := &templatev1.Template{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unmanaged-template",
+					Namespace: otherNamespace,
+				},
+			}
+			Expect(request.Client.Create(request.Context, unmanagedTemplate)).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(countTemplatesInNamespaceMetrics()).To(Equal(1))
+			Expect(getTemplatesInNamespaceMetric(namespace)).To(Equal(float64(len(testTemplates))))
+		})
+
+		It("should remove namespace without templates", func() {
+			const canaryNamespace = "canary-ns"
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = canaryNamespace
+			request.Instance.Annotations = map[string]string{
+				ssp.PromoteTemplatesAnnotation: Version,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getTemplatesInNamespaceMetric(canaryNamespace)).To(Equal(float64(len(testTemplates))))
+
+			request.Instance.Spec.CommonTemplates.CanaryNamespace = ""
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countTemplatesInNamespaceMetrics()).To(Equal(1))
+			Expect(getTemplatesInNamespaceMetric(namespace)).To(Equal(float64(len(testTemplates))))
+		})
 	})
 
 	Context("total_restored_common_templates metric", func() {
@@ -329,6 +1109,29 @@ var _ = Describe("Common-Templates operand", func() {
 	})
 })
 
+// failingUpdateClient fails all updates, simulating templates that cannot be reconciled
+type failingUpdateClient struct {
+	client.Client
+}
+
+func (f failingUpdateClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return fmt.Errorf("update failed")
+}
+
+// failingCreateClient fails creation of objects after the allowed number of creates
+type failingCreateClient struct {
+	client.Client
+	allowedCreates int
+}
+
+func (f *failingCreateClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if f.allowedCreates <= 0 {
+		return fmt.Errorf("create failed")
+	}
+	f.allowedCreates--
+	return f.Client.Create(ctx, obj, opts...)
+}
+
 func getTestTemplates() []templatev1.Template {
 	return []templatev1.Template{{
 		ObjectMeta: metav1.ObjectMeta{
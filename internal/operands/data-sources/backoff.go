@@ -0,0 +1,100 @@
+package data_sources
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// dataImportCronInitialBackoff is the delay before a DataImportCron is retried after the first failure
+	dataImportCronInitialBackoff = 5 * time.Second
+	// dataImportCronBackoffJitter is the maximal fraction of the delay, that is randomly added to it,
+	// so retries of multiple DataImportCrons are spread out
+	dataImportCronBackoffJitter = 0.2
+)
+
+// reconcileBackoff tracks DataImportCrons, whose reconciliation failed with a transient error.
+// The delay before the next retry grows exponentially with the number of consecutive failures.
+type reconcileBackoff struct {
+	clock   clock.PassiveClock
+	entries map[client.ObjectKey]*backoffEntry
+}
+
+type backoffEntry struct {
+	failures  int
+	retryTime time.Time
+	lastError error
+}
+
+func newReconcileBackoff() *reconcileBackoff {
+	return &reconcileBackoff{
+		clock:   clock.RealClock{},
+		entries: map[client.ObjectKey]*backoffEntry{},
+	}
+}
+
+// remaining returns the time until the DataImportCron can be retried and the error of its last failure.
+// Zero is returned if the DataImportCron can be reconciled now.
+func (b *reconcileBackoff) remaining(key client.ObjectKey) (time.Duration, error) {
+	entry, exists := b.entries[key]
+	if !exists {
+		return 0, nil
+	}
+	remaining := entry.retryTime.Sub(b.clock.Now())
+	if remaining <= 0 {
+		return 0, nil
+	}
+	return remaining, entry.lastError
+}
+
+// failed records a failure of the DataImportCron and returns the delay before it is retried
+func (b *reconcileBackoff) failed(key client.ObjectKey, err error, maxDelay time.Duration) time.Duration {
+	entry, exists := b.entries[key]
+	if !exists {
+		entry = &backoffEntry{}
+		b.entries[key] = entry
+	}
+	entry.failures++
+	entry.lastError = err
+
+	delay := dataImportCronInitialBackoff
+	for i := 1; i < entry.failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = wait.Jitter(delay, dataImportCronBackoffJitter)
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	entry.retryTime = b.clock.Now().Add(delay)
+	return delay
+}
+
+// succeeded resets the backoff of the DataImportCron
+func (b *reconcileBackoff) succeeded(key client.ObjectKey) {
+	delete(b.entries, key)
+}
+
+// retain forgets DataImportCrons, that are not in the keys
+func (b *reconcileBackoff) retain(keys map[client.ObjectKey]struct{}) {
+	for key := range b.entries {
+		if _, exists := keys[key]; !exists {
+			delete(b.entries, key)
+		}
+	}
+}
+
+// isTransientError returns true for errors, that are expected to resolve without intervention.
+// For example, when CDI is being upgraded, its webhooks or the API server can be temporarily unavailable.
+func isTransientError(err error) bool {
+	return errors.IsServiceUnavailable(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsTooManyRequests(err) ||
+		errors.IsInternalError(err) ||
+		errors.IsUnexpectedServerError(err)
+}
@@ -2,11 +2,19 @@ package data_sources
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	core "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/pointer"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -35,12 +43,21 @@ const (
 	operandComponent = common.AppComponentTemplating
 )
 
+// GoldenImagesNamespaceCreatedReason is the reason of the event recorded when the golden images namespace is created
+const GoldenImagesNamespaceCreatedReason = "GoldenImagesNamespaceCreated"
+
 const (
 	dataVolumeCrd     = "datavolumes.cdi.kubevirt.io"
 	dataSourceCrd     = "datasources.cdi.kubevirt.io"
 	dataImportCronCrd = "dataimportcrons.cdi.kubevirt.io"
 )
 
+// DataImportCronReady reports for each DataImportCronTemplate, if its managed DataSource is ready
+var DataImportCronReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kubevirt_ssp_data_import_cron_ready",
+	Help: "Indicates whether the DataSource managed by a DataImportCronTemplate is ready (1) or not (0)",
+}, []string{"name", "namespace"})
+
 func init() {
 	utilruntime.Must(cdiv1beta1.AddToScheme(common.Scheme))
 }
@@ -59,13 +76,16 @@ func WatchClusterTypes() []operands.WatchType {
 
 type dataSources struct {
 	sources []cdiv1beta1.DataSource
+	// cronBackoff delays retries of DataImportCrons, that failed to reconcile
+	cronBackoff *reconcileBackoff
 }
 
 var _ operands.Operand = &dataSources{}
 
 func New(sources []cdiv1beta1.DataSource) operands.Operand {
 	return &dataSources{
-		sources: sources,
+		sources:     sources,
+		cronBackoff: newReconcileBackoff(),
 	}
 }
 
@@ -88,18 +108,25 @@ type dataSourceInfo struct {
 }
 
 func (d *dataSources) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
-	funcs := []common.ReconcileFunc{
-		reconcileGoldenImagesNS,
+	var funcs []common.ReconcileFunc
+	if internal.IsGoldenImagesNamespaceManaged(request.Instance) {
+		funcs = append(funcs, reconcileGoldenImagesNS)
+	}
+	funcs = append(funcs,
 		reconcileViewRole,
 		reconcileViewRoleBinding,
 		reconcileEditRole,
-	}
+	)
 
 	dsAndCrons, err := d.getDataSourcesAndCrons(request)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := updateDataImportCronReadyMetric(request); err != nil {
+		return nil, err
+	}
+
 	dsFuncs, err := reconcileDataSources(dsAndCrons.dataSourceInfos, request)
 	if err != nil {
 		return nil, err
@@ -124,15 +151,168 @@ func (d *dataSources) Reconcile(request *common.Request) ([]common.ReconcileResu
 		return results, nil
 	}
 
-	dicFuncs, err := reconcileDataImportCrons(dsAndCrons.dataImportCrons, request)
+	if pointer.BoolDeref(request.Instance.Spec.CommonTemplates.PauseDataImports, false) {
+		request.Logger.V(1).Info("Data imports are paused, skipping DataImportCron reconciliation")
+		return results, nil
+	}
+
+	dicFuncs, err := reconcileDataImportCrons(dsAndCrons.dataImportCrons, d.cronBackoff, request)
 	if err != nil {
 		return nil, err
 	}
 
-	return common.CollectResourceStatus(request, dicFuncs...)
+	dicResults, err := common.CollectResourceStatus(request, dicFuncs...)
+	if err != nil {
+		return nil, err
+	}
+
+	ownedCrons, err := listAllOwnedDataImportCrons(request)
+	if err != nil {
+		return nil, err
+	}
+	updateRecentImports(request, ownedCrons)
+	if err := requeueForDataImportCrons(request, ownedCrons); err != nil {
+		return nil, err
+	}
+	return dicResults, nil
+}
+
+// updateDataImportCronReadyMetric sets the readiness of the DataSource managed by each DataImportCronTemplate.
+// The metric is reset first, so templates removed from the SSP CR are not reported anymore.
+func updateDataImportCronReadyMetric(request *common.Request) error {
+	ready := map[client.ObjectKey]bool{}
+	for _, cronTemplate := range request.Instance.Spec.CommonTemplates.DataImportCronTemplates {
+		cronNamespace := cronTemplate.Namespace
+		if cronNamespace == "" {
+			cronNamespace = internal.GetGoldenImagesNamespace(request.Instance)
+		}
+
+		dataSource := &cdiv1beta1.DataSource{}
+		err := request.Client.Get(request.Context, client.ObjectKey{
+			Name:      cronTemplate.Spec.ManagedDataSource,
+			Namespace: cronNamespace,
+		}, dataSource)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		readyCondition := getDataSourceReadyCondition(dataSource)
+		ready[client.ObjectKey{Name: cronTemplate.Name, Namespace: cronNamespace}] =
+			err == nil && readyCondition != nil && readyCondition.Status == core.ConditionTrue
+	}
+
+	DataImportCronReady.Reset()
+	for key, isReady := range ready {
+		value := 0.0
+		if isReady {
+			value = 1.0
+		}
+		DataImportCronReady.WithLabelValues(key.Name, key.Namespace).Set(value)
+	}
+	return nil
+}
+
+// requeueForDataImportCrons requests periodic reconciliation, so the status of DataImportCrons
+// is observed. Changes of the DataImportCron status do not trigger reconciliation.
+// The interval is shorter while an import is in progress.
+func requeueForDataImportCrons(request *common.Request, ownedCrons []cdiv1beta1.DataImportCron) error {
+	if len(ownedCrons) == 0 {
+		return nil
+	}
+
+	for i := range ownedCrons {
+		if isImportInProgress(&ownedCrons[i]) {
+			interval, err := common.GetDataImportCronActiveRequeueInterval()
+			if err != nil {
+				return err
+			}
+			request.RequeueAfter(interval)
+			return nil
+		}
+	}
+
+	interval, err := common.GetDataImportCronSteadyRequeueInterval()
+	if err != nil {
+		return err
+	}
+	request.RequeueAfter(interval)
+	return nil
+}
+
+// updateRecentImports adds results of imports observed on DataImportCrons to the SSP status.
+// Only the newest results are kept, so results of older imports are not added again.
+func updateRecentImports(request *common.Request, ownedCrons []cdiv1beta1.DataImportCron) {
+	recentImports := request.Instance.Status.RecentImports
+	for i := range ownedCrons {
+		for _, entry := range observedImports(&ownedCrons[i]) {
+			if !containsImportEntry(recentImports, entry) {
+				recentImports = append(recentImports, entry)
+			}
+		}
+	}
+
+	sort.SliceStable(recentImports, func(i, j int) bool {
+		return recentImports[j].Time.Before(&recentImports[i].Time)
+	})
+	if len(recentImports) > ssp.MaxRecentImports {
+		recentImports = recentImports[:ssp.MaxRecentImports]
+	}
+	request.Instance.Status.RecentImports = recentImports
+}
+
+// observedImports returns the results of the last successful and the last failed import of the DataImportCron
+func observedImports(cron *cdiv1beta1.DataImportCron) []ssp.ImportHistoryEntry {
+	var entries []ssp.ImportHistoryEntry
+	if cron.Status.LastImportTimestamp != nil {
+		entries = append(entries, ssp.ImportHistoryEntry{
+			Name:      cron.GetName(),
+			Namespace: cron.GetNamespace(),
+			Result:    ssp.ImportSucceeded,
+			Time:      *cron.Status.LastImportTimestamp,
+		})
+	}
+	for _, condition := range cron.Status.Conditions {
+		// CDI sets the phase of the failed import DataVolume as the reason
+		if condition.Type == cdiv1beta1.DataImportCronProgressing &&
+			condition.Status == core.ConditionFalse &&
+			condition.Reason == string(cdiv1beta1.Failed) {
+			entries = append(entries, ssp.ImportHistoryEntry{
+				Name:      cron.GetName(),
+				Namespace: cron.GetNamespace(),
+				Result:    ssp.ImportFailed,
+				Time:      condition.LastTransitionTime,
+				Message:   condition.Message,
+			})
+		}
+	}
+	return entries
+}
+
+func containsImportEntry(entries []ssp.ImportHistoryEntry, entry ssp.ImportHistoryEntry) bool {
+	for i := range entries {
+		if entries[i].Name == entry.Name &&
+			entries[i].Namespace == entry.Namespace &&
+			entries[i].Result == entry.Result &&
+			entries[i].Time.Equal(&entry.Time) {
+			return true
+		}
+	}
+	return false
+}
+
+func isImportInProgress(cron *cdiv1beta1.DataImportCron) bool {
+	for _, condition := range cron.Status.Conditions {
+		if condition.Type == cdiv1beta1.DataImportCronProgressing {
+			return condition.Status == core.ConditionTrue
+		}
+	}
+	return false
 }
 
 func (d *dataSources) Cleanup(request *common.Request) ([]common.CleanupResult, error) {
+	// DataImportCrons are being removed, so their readiness is not reported anymore
+	DataImportCronReady.Reset()
+
 	if request.CrdList.CrdExists(dataImportCronCrd) {
 		ownedCrons, err := listAllOwnedDataImportCrons(request)
 		if err != nil {
@@ -158,41 +338,57 @@ func (d *dataSources) Cleanup(request *common.Request) ([]common.CleanupResult,
 		}
 	}
 
+	goldenImagesNamespace := internal.GetGoldenImagesNamespace(request.Instance)
+
 	var objects []client.Object
 	if request.CrdList.CrdExists(dataSourceCrd) {
 		for i := range d.sources {
-			ds := d.sources[i]
-			ds.Namespace = internal.GoldenImagesNamespace
-			objects = append(objects, &ds)
+			ds := newGoldenImageDataSource(&d.sources[i], goldenImagesNamespace)
+			objects = append(objects, ds)
+
+			for _, namespace := range request.Instance.Spec.CommonTemplates.AdditionalNamespaces {
+				objects = append(objects, newDataSourceReplica(ds, namespace))
+			}
 		}
 	}
 
+	if internal.IsGoldenImagesNamespaceManaged(request.Instance) {
+		objects = append(objects, newGoldenImagesNS(goldenImagesNamespace))
+	}
 	objects = append(objects,
-		newGoldenImagesNS(internal.GoldenImagesNamespace),
-		newViewRole(internal.GoldenImagesNamespace),
-		newViewRoleBinding(internal.GoldenImagesNamespace),
+		newViewRole(goldenImagesNamespace),
+		newViewRoleBinding(goldenImagesNamespace),
 		newEditRole())
 
 	return common.DeleteAll(request, objects...)
 }
 
 func reconcileGoldenImagesNS(request *common.Request) (common.ReconcileResult, error) {
-	return common.CreateOrUpdate(request).
+	result, err := common.CreateOrUpdate(request).
 		ClusterResource(newGoldenImagesNS(internal.GoldenImagesNamespace)).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
+	if err != nil {
+		return result, err
+	}
+
+	if result.OperationResult == common.OperationResultCreated {
+		request.EventRecorder.Eventf(request.Instance, core.EventTypeNormal, GoldenImagesNamespaceCreatedReason,
+			"Created golden images namespace %s with labels: %s", result.Resource.GetName(), labels.FormatLabels(result.Resource.GetLabels()))
+	}
+	return result, nil
 }
 
 func reconcileViewRole(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
-		ClusterResource(newViewRole(internal.GoldenImagesNamespace)).
+		ClusterResource(newViewRole(internal.GetGoldenImagesNamespace(request.Instance))).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
 
 func reconcileViewRoleBinding(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
-		ClusterResource(newViewRoleBinding(internal.GoldenImagesNamespace)).
+		ClusterResource(newViewRoleBinding(internal.GetGoldenImagesNamespace(request.Instance))).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
@@ -210,12 +406,13 @@ type dataSourcesAndCrons struct {
 }
 
 func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourcesAndCrons, error) {
+	goldenImagesNamespace := internal.GetGoldenImagesNamespace(request.Instance)
 	cronTemplates := request.Instance.Spec.CommonTemplates.DataImportCronTemplates
 	cronByDataSource := make(map[client.ObjectKey]*ssp.DataImportCronTemplate, len(cronTemplates))
 	for i := range cronTemplates {
 		cron := &cronTemplates[i]
 		if cron.Namespace == "" {
-			cron.Namespace = internal.GoldenImagesNamespace
+			cron.Namespace = goldenImagesNamespace
 		}
 		cronByDataSource[client.ObjectKey{
 			Name:      cron.Spec.ManagedDataSource,
@@ -225,20 +422,19 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 
 	var dataSourceInfos []dataSourceInfo
 	for i := range d.sources {
-		dataSource := d.sources[i] // Make a copy
-		dataSource.Namespace = internal.GoldenImagesNamespace
-		autoUpdateEnabled, err := dataSourceAutoUpdateEnabled(&dataSource, cronByDataSource, request)
+		dataSource := newGoldenImageDataSource(&d.sources[i], goldenImagesNamespace)
+		autoUpdateEnabled, err := dataSourceAutoUpdateEnabled(dataSource, cronByDataSource, request)
 		if err != nil {
 			return dataSourcesAndCrons{}, err
 		}
 
 		var dicName string
-		if dic, ok := cronByDataSource[client.ObjectKeyFromObject(&dataSource)]; ok {
+		if dic, ok := cronByDataSource[client.ObjectKeyFromObject(dataSource)]; ok {
 			dicName = dic.GetName()
 		}
 
 		dataSourceInfos = append(dataSourceInfos, dataSourceInfo{
-			dataSource:         &dataSource,
+			dataSource:         dataSource,
 			autoUpdateEnabled:  autoUpdateEnabled,
 			dataImportCronName: dicName,
 		})
@@ -250,9 +446,17 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 		}
 	}
 
+	// DataImportCrons are kept in the order of templates, so they are created in a predictable order
 	dataImportCrons := make([]cdiv1beta1.DataImportCron, 0, len(cronByDataSource))
-	for _, cronTemplate := range cronByDataSource {
-		dataImportCrons = append(dataImportCrons, cronTemplate.AsDataImportCron())
+	for i := range cronTemplates {
+		cronTemplate := &cronTemplates[i]
+		if !isDataImportCronTemplateEnabled(cronTemplate) {
+			request.Logger.V(1).Info(fmt.Sprintf("DataImportCronTemplate %s is disabled", cronTemplate.GetName()))
+			continue
+		}
+		if cronByDataSource[client.ObjectKey{Name: cronTemplate.Spec.ManagedDataSource, Namespace: cronTemplate.Namespace}] == cronTemplate {
+			dataImportCrons = append(dataImportCrons, cronTemplate.AsDataImportCron())
+		}
 	}
 
 	return dataSourcesAndCrons{
@@ -263,6 +467,17 @@ func (d *dataSources) getDataSourcesAndCrons(request *common.Request) (dataSourc
 
 const dataImportCronLabel = "cdi.kubevirt.io/dataImportCron"
 
+// isDataImportCronTemplateEnabled returns false if the template is disabled by the annotation.
+// Invalid values are rejected by the webhook, so they are treated as if the annotation was not set.
+func isDataImportCronTemplateEnabled(cronTemplate *ssp.DataImportCronTemplate) bool {
+	value, exists := cronTemplate.GetAnnotations()[ssp.DataImportCronTemplateEnableAnnotation]
+	if !exists {
+		return true
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err != nil || enabled
+}
+
 func dataSourceAutoUpdateEnabled(dataSource *cdiv1beta1.DataSource, cronByDataSource map[client.ObjectKey]*ssp.DataImportCronTemplate, request *common.Request) (bool, error) {
 	objectKey := client.ObjectKeyFromObject(dataSource)
 	_, cronExists := cronByDataSource[objectKey]
@@ -337,24 +552,34 @@ func reconcileDataSources(dataSourceInfos []dataSourceInfo, request *common.Requ
 		return nil, err
 	}
 
-	dsNames := make(map[string]struct{}, len(dataSourceInfos))
+	additionalNamespaces := request.Instance.Spec.CommonTemplates.AdditionalNamespaces
+
+	dsKeys := make(map[client.ObjectKey]struct{}, len(dataSourceInfos)*(len(additionalNamespaces)+1))
 	var funcs []common.ReconcileFunc
 	for i := range dataSourceInfos {
 		dsInfo := dataSourceInfos[i] // Make a local copy
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
 			return reconcileDataSource(dsInfo, request)
 		})
-		dsNames[dsInfo.dataSource.GetName()] = struct{}{}
+		dsKeys[client.ObjectKeyFromObject(dsInfo.dataSource)] = struct{}{}
+
+		for _, namespace := range additionalNamespaces {
+			replica := newDataSourceReplica(dsInfo.dataSource, namespace)
+			funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+				return reconcileDataSourceReplica(replica, dsInfo, request)
+			})
+			dsKeys[client.ObjectKeyFromObject(replica)] = struct{}{}
+		}
 	}
 
-	// Remove owned DataSources that are not in the 'dataSourceInfos'
+	// Remove owned DataSources that are not in the 'dataSourceInfos',
+	// and replicas in namespaces that were removed from AdditionalNamespaces
 	for i := range ownedDataSources {
-		if _, isUsed := dsNames[ownedDataSources[i].GetName()]; isUsed {
+		if _, isUsed := dsKeys[client.ObjectKeyFromObject(&ownedDataSources[i])]; isUsed {
 			continue
 		}
 
 		dataSource := ownedDataSources[i] // Make local copy
-		dataSource.Namespace = internal.GoldenImagesNamespace
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
 			if !dataSource.GetDeletionTimestamp().IsZero() {
 				return common.ResourceDeletedResult(&dataSource, common.OperationResultDeleted), nil
@@ -367,7 +592,7 @@ func reconcileDataSources(dataSourceInfos []dataSourceInfo, request *common.Requ
 				}, nil
 			}
 			if err != nil {
-				request.Logger.Error(err, fmt.Sprintf("Error deleting \"%s\": %s", dataSource.GetName(), err))
+				request.Logger.Error(err, fmt.Sprintf("Error deleting \"%s/%s\": %s", dataSource.GetNamespace(), dataSource.GetName(), err))
 				return common.ReconcileResult{}, err
 			}
 
@@ -403,6 +628,28 @@ func reconcileDataSource(dsInfo dataSourceInfo, request *common.Request) (common
 		Reconcile()
 }
 
+func reconcileDataSourceReplica(replica *cdiv1beta1.DataSource, dsInfo dataSourceInfo, request *common.Request) (common.ReconcileResult, error) {
+	if dsInfo.autoUpdateEnabled {
+		// Source of auto-updated DataSource is managed by CDI, so the replica follows it.
+		foundDataSource := &cdiv1beta1.DataSource{}
+		err := request.Client.Get(request.Context, client.ObjectKeyFromObject(dsInfo.dataSource), foundDataSource)
+		if err != nil && !errors.IsNotFound(err) {
+			return common.ReconcileResult{}, err
+		}
+		if err == nil {
+			replica.Spec.Source = *foundDataSource.Spec.Source.DeepCopy()
+		}
+	}
+
+	return common.CreateOrUpdate(request).
+		ClusterResource(replica).
+		WithAppLabels(operandName, operandComponent).
+		UpdateFunc(func(newRes, foundRes client.Object) {
+			foundRes.(*cdiv1beta1.DataSource).Spec = newRes.(*cdiv1beta1.DataSource).Spec
+		}).
+		Reconcile()
+}
+
 func getDataSourceReadyCondition(dataSource *cdiv1beta1.DataSource) *cdiv1beta1.DataSourceCondition {
 	for i := range dataSource.Status.Conditions {
 		condition := &dataSource.Status.Conditions[i]
@@ -426,21 +673,263 @@ func reconcileDataImportCron(dataImportCron *cdiv1beta1.DataImportCron, request
 		Reconcile()
 }
 
-func reconcileDataImportCrons(dataImportCrons []cdiv1beta1.DataImportCron, request *common.Request) ([]common.ReconcileFunc, error) {
+const (
+	// NextCronTimeAnnotation is set by CDI to the time of the next scheduled check of the DataImportCron source.
+	// Setting it to the current time makes CDI check the source immediately.
+	//
+	// The annotation is internal to CDI, it is not part of the DataImportCron API. CDI does not provide
+	// another way to trigger or postpone a check of the source, so it is used to force a refresh
+	// and to suspend DataImportCrons. The functional tests verify that CDI still respects it.
+	NextCronTimeAnnotation = "cdi.kubevirt.io/storage.import.nextCronTime"
+
+	// forceRefreshProcessedAnnotation records on the DataImportCron the last value
+	// of the force refresh annotations, for which the refresh was done
+	forceRefreshProcessedAnnotation = "ssp.kubevirt.io/force-refresh-processed"
+)
+
+// setForceRefresh requests a check of the DataImportCron source, if the value of the force refresh annotation
+// on the SSP CR or on the DataImportCronTemplate changed since the last refresh.
+// A new DataImportCron is not refreshed, because its source is checked when it is created.
+func setForceRefresh(cron *cdiv1beta1.DataImportCron, request *common.Request) error {
+	var values []string
+	if value := request.Instance.GetAnnotations()[ssp.ForceRefreshAnnotation]; value != "" {
+		values = append(values, value)
+	}
+	if value := cron.GetAnnotations()[ssp.ForceRefreshAnnotation]; value != "" {
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	refreshValue := strings.Join(values, ",")
+
+	foundCron := &cdiv1beta1.DataImportCron{}
+	err := request.Client.Get(request.Context, client.ObjectKeyFromObject(cron), foundCron)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	// The annotations map can be shared with the DataImportCronTemplate, so it is copied before modification
+	annotations := make(map[string]string, len(cron.Annotations)+2)
+	for key, value := range cron.Annotations {
+		annotations[key] = value
+	}
+	cron.Annotations = annotations
+
+	if err == nil && foundCron.GetAnnotations()[forceRefreshProcessedAnnotation] != refreshValue {
+		request.Logger.Info(fmt.Sprintf("Forcing refresh of DataImportCron %s", cron.GetName()))
+		cron.Annotations[NextCronTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+	cron.Annotations[forceRefreshProcessedAnnotation] = refreshValue
+	return nil
+}
+
+const (
+	// dataImportSuspendedAnnotation records on the DataImportCron if it is suspended,
+	// because the limit of concurrent imports was reached
+	dataImportSuspendedAnnotation = "ssp.kubevirt.io/data-import-suspended"
+
+	// suspendedCronDelay is how far in the future the next check of a suspended DataImportCron is moved.
+	// The check is moved back to the current time when the DataImportCron is resumed.
+	suspendedCronDelay = 365 * 24 * time.Hour
+)
+
+func isDataImportCronSuspended(cron *cdiv1beta1.DataImportCron) bool {
+	suspended, err := strconv.ParseBool(cron.GetAnnotations()[dataImportSuspendedAnnotation])
+	return err == nil && suspended
+}
+
+// setSuspended suspends or resumes the DataImportCron. CDI does not check the source of a DataImportCron
+// before the time in the next cron time annotation, so a suspended DataImportCron does not start an import.
+// A resumed DataImportCron checks its source immediately.
+// DataImportCrons have no supported suspend field, so this depends on the CDI internal NextCronTimeAnnotation.
+func setSuspended(cron, foundCron *cdiv1beta1.DataImportCron, suspended bool, request *common.Request) {
+	wasSuspended := isDataImportCronSuspended(foundCron)
+	if suspended == wasSuspended {
+		return
+	}
+
+	// The annotations map can be shared with the DataImportCronTemplate, so it is copied before modification
+	annotations := make(map[string]string, len(cron.Annotations)+2)
+	for key, value := range cron.Annotations {
+		annotations[key] = value
+	}
+	cron.Annotations = annotations
+
+	now := time.Now().UTC()
+	if suspended {
+		request.Logger.Info(fmt.Sprintf("Suspending DataImportCron %s, limit of concurrent imports is reached", cron.GetName()))
+		cron.Annotations[NextCronTimeAnnotation] = now.Add(suspendedCronDelay).Format(time.RFC3339)
+	} else {
+		request.Logger.Info(fmt.Sprintf("Resuming DataImportCron %s", cron.GetName()))
+		cron.Annotations[NextCronTimeAnnotation] = now.Format(time.RFC3339)
+	}
+	cron.Annotations[dataImportSuspendedAnnotation] = strconv.FormatBool(suspended)
+}
+
+func reconcileDataImportCrons(dataImportCrons []cdiv1beta1.DataImportCron, backoff *reconcileBackoff, request *common.Request) ([]common.ReconcileFunc, error) {
 	ownedCrons, err := listAllOwnedDataImportCrons(request)
 	if err != nil {
 		return nil, err
 	}
 
+	maxCreations, err := common.GetMaxDataImportCronCreations()
+	if err != nil {
+		return nil, err
+	}
+
+	startupDelay, err := common.GetDataImportCronStartupDelay()
+	if err != nil {
+		return nil, err
+	}
+	installTime := request.Instance.GetCreationTimestamp()
+	now := time.Now()
+
+	maxImports, err := common.GetMaxConcurrentDataImports()
+	if err != nil {
+		return nil, err
+	}
+
+	maxBackoff, err := common.GetDataImportCronMaxBackoff()
+	if err != nil {
+		return nil, err
+	}
+
+	runningImports := 0
+	foundCrons := make(map[client.ObjectKey]*cdiv1beta1.DataImportCron, len(ownedCrons))
+	for i := range ownedCrons {
+		foundCrons[client.ObjectKeyFromObject(&ownedCrons[i])] = &ownedCrons[i]
+		if isImportInProgress(&ownedCrons[i]) {
+			runningImports++
+		}
+	}
+	// Each DataImportCron, that is not importing and is not suspended, can start an import,
+	// so it takes one of the free slots
+	importSlots := maxImports - runningImports
+
+	// Free slots are reserved first for DataImportCrons waiting to be created or resumed,
+	// so they are not starved by idle DataImportCrons
+	reservedSlots := map[client.ObjectKey]struct{}{}
+	if maxImports > 0 {
+		for i := range dataImportCrons {
+			cronKey := client.ObjectKeyFromObject(&dataImportCrons[i])
+			foundCron, exists := foundCrons[cronKey]
+			waiting := !exists || (!isImportInProgress(foundCron) && isDataImportCronSuspended(foundCron))
+			if importSlots > 0 && waiting {
+				reservedSlots[cronKey] = struct{}{}
+				importSlots--
+			}
+		}
+	}
+
 	crons := make(map[client.ObjectKey]struct{}, len(dataImportCrons))
 
 	var funcs []common.ReconcileFunc
+	creations := 0
+	postponed := false
+	anySuspended := false
 	for i := range dataImportCrons {
 		cron := dataImportCrons[i] // Make a local copy
+		cronKey := client.ObjectKeyFromObject(&cron)
+		crons[cronKey] = struct{}{}
+
+		foundCron, exists := foundCrons[cronKey]
+		if !exists {
+			// Right after installation, the first imports of all DataImportCrons would run at once,
+			// so their creation can be staggered.
+			if offset := dataImportCronStartupOffset(i, startupDelay, installTime, now); offset > 0 {
+				funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+					return postponedDataImportCronResult(&cron), nil
+				})
+				request.Logger.V(1).Info(fmt.Sprintf("Creation of DataImportCron %s is delayed by %s", cron.GetName(), offset))
+				request.RequeueAfter(offset)
+				continue
+			}
+
+			// Creating many DataImportCrons at once causes a spike of CDI load,
+			// so the number of creations in one reconciliation can be limited.
+			if maxCreations > 0 && creations >= maxCreations {
+				funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+					return postponedDataImportCronResult(&cron), nil
+				})
+				postponed = true
+				continue
+			}
+
+			// A new DataImportCron starts importing right after creation,
+			// so it is not created while the limit of concurrent imports is reached.
+			if _, reserved := reservedSlots[cronKey]; maxImports > 0 && !reserved {
+				funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
+					return postponedDataImportCronResult(&cron), nil
+				})
+				postponed = true
+				continue
+			}
+			creations++
+		}
+
+		suspended := false
+		if exists && maxImports > 0 && !isImportInProgress(foundCron) {
+			_, reserved := reservedSlots[cronKey]
+			switch {
+			case reserved:
+				// The DataImportCron is created or resumed
+			case isDataImportCronSuspended(foundCron):
+				suspended = true
+			case importSlots > 0:
+				// An active DataImportCron can start an import on its schedule, so it takes one of the free slots
+				importSlots--
+			default:
+				suspended = true
+			}
+			if suspended {
+				request.Logger.V(1).Info(fmt.Sprintf("DataImportCron %s is suspended, %d imports are running", cron.GetName(), runningImports))
+				anySuspended = true
+			}
+		}
+
 		funcs = append(funcs, func(request *common.Request) (common.ReconcileResult, error) {
-			return reconcileDataImportCron(&cron, request)
+			// Retrying right after a transient failure, for example while CDI is being upgraded,
+			// would only load the API server, so the DataImportCron is skipped until its backoff elapses.
+			if delay, lastErr := backoff.remaining(cronKey); delay > 0 {
+				request.RequeueAfter(delay)
+				return backoffDataImportCronResult(&cron, delay, lastErr), nil
+			}
+
+			result, err := func() (common.ReconcileResult, error) {
+				if err := setForceRefresh(&cron, request); err != nil {
+					return common.ReconcileResult{}, err
+				}
+				if exists {
+					setSuspended(&cron, foundCron, suspended, request)
+				}
+				return reconcileDataImportCron(&cron, request)
+			}()
+			if err != nil {
+				if !isTransientError(err) {
+					return common.ReconcileResult{}, err
+				}
+				delay := backoff.failed(cronKey, err, maxBackoff)
+				request.Logger.Info(fmt.Sprintf("Failed to reconcile DataImportCron %s, retrying in %s", cron.GetName(), delay),
+					"error", err.Error())
+				request.RequeueAfter(delay)
+				return backoffDataImportCronResult(&cron, delay, err), nil
+			}
+			backoff.succeeded(cronKey)
+			return result, nil
 		})
-		crons[client.ObjectKeyFromObject(&cron)] = struct{}{}
+	}
+	backoff.retain(crons)
+
+	if postponed || anySuspended {
+		interval, err := common.GetDataImportCronActiveRequeueInterval()
+		if err != nil {
+			return nil, err
+		}
+		if postponed {
+			request.Logger.V(1).Info(fmt.Sprintf("Created %d DataImportCrons, creation of the rest is postponed", creations))
+		}
+		request.RequeueAfter(interval)
 	}
 
 	// Remove owned DataImportCrons that are not in the 'dataImportCrons' parameter
@@ -465,9 +954,45 @@ func reconcileDataImportCrons(dataImportCrons []cdiv1beta1.DataImportCron, reque
 	return funcs, nil
 }
 
+// dataImportCronStartupOffset returns how long the creation of the DataImportCron at the index
+// has to be delayed. The DataImportCron at index N is created N times the startup delay after installation.
+func dataImportCronStartupOffset(index int, startupDelay time.Duration, installTime metav1.Time, now time.Time) time.Duration {
+	if startupDelay <= 0 || installTime.IsZero() {
+		return 0
+	}
+	return installTime.Add(time.Duration(index) * startupDelay).Sub(now)
+}
+
+func postponedDataImportCronResult(cron *cdiv1beta1.DataImportCron) common.ReconcileResult {
+	message := fmt.Sprintf("Creation of DataImportCron %s is postponed", cron.GetName())
+	return common.ReconcileResult{
+		Status: common.ResourceStatus{
+			Progressing: &message,
+		},
+		Resource: cron,
+	}
+}
+
+func backoffDataImportCronResult(cron *cdiv1beta1.DataImportCron, delay time.Duration, err error) common.ReconcileResult {
+	message := fmt.Sprintf("Reconciliation of DataImportCron %s failed, retrying in %s: %v", cron.GetName(), delay.Round(time.Second), err)
+	return common.ReconcileResult{
+		Status: common.ResourceStatus{
+			Progressing: &message,
+			Degraded:    &message,
+		},
+		Resource: cron,
+	}
+}
+
+// listAllOwnedDataSources lists owned DataSources in all namespaces,
+// so that replicas in namespaces removed from AdditionalNamespaces are found as well.
+// Only DataSources labeled by this operand are listed, other DataSources in the cluster are not relevant.
 func listAllOwnedDataSources(request *common.Request) ([]cdiv1beta1.DataSource, error) {
 	foundDataSources := &cdiv1beta1.DataSourceList{}
-	err := request.Client.List(request.Context, foundDataSources, client.InNamespace(internal.GoldenImagesNamespace))
+	err := request.Client.List(request.Context, foundDataSources, client.MatchingLabels{
+		common.AppKubernetesNameLabel:      operandName,
+		common.AppKubernetesManagedByLabel: common.AppKubernetesManagedByValue,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2,15 +2,23 @@ package data_sources
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	libhandler "github.com/operator-framework/operator-lib/handler"
+	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -35,12 +43,14 @@ var _ = Describe("Data-Sources operand", func() {
 	var (
 		testDataSources []cdiv1beta1.DataSource
 
-		operand operands.Operand
-		request common.Request
+		operand  operands.Operand
+		request  common.Request
+		recorder *record.FakeRecorder
 	)
 
 	BeforeEach(func() {
 		testDataSources = getDataSources()
+		recorder = record.NewFakeRecorder(10)
 
 		operand = New(testDataSources)
 
@@ -70,8 +80,9 @@ var _ = Describe("Data-Sources operand", func() {
 					},
 				},
 			},
-			Logger:       log,
-			VersionCache: common.VersionCache{},
+			Logger:        log,
+			VersionCache:  common.VersionCache{},
+			EventRecorder: recorder,
 		}
 	})
 
@@ -81,6 +92,29 @@ var _ = Describe("Data-Sources operand", func() {
 		ExpectResourceExists(newGoldenImagesNS(internal.GoldenImagesNamespace), request)
 	})
 
+	It("should record event when golden-images namespace is created", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var event string
+		Expect(recorder.Events).To(Receive(&event))
+		Expect(event).To(HavePrefix(v1.EventTypeNormal + " " + GoldenImagesNamespaceCreatedReason))
+		Expect(event).To(ContainSubstring("Created golden images namespace " + internal.GoldenImagesNamespace))
+		Expect(event).To(ContainSubstring(common.AppKubernetesNameLabel + "=" + operandName))
+
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).ToNot(Receive(), "event should be recorded only when namespace is created")
+	})
+
+	It("should not record event when golden-images namespace already exists", func() {
+		Expect(request.Client.Create(request.Context, newGoldenImagesNS(internal.GoldenImagesNamespace))).To(Succeed())
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
 	It("should create view role", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -108,6 +142,74 @@ var _ = Describe("Data-Sources operand", func() {
 		}
 	})
 
+	Context("with additional namespaces", func() {
+		const (
+			additionalNamespace1 = "additional-namespace-1"
+			additionalNamespace2 = "additional-namespace-2"
+		)
+
+		BeforeEach(func() {
+			request.Instance.Spec.CommonTemplates.AdditionalNamespaces = []string{additionalNamespace1, additionalNamespace2}
+		})
+
+		It("should replicate DataSources to additional namespaces", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, namespace := range []string{additionalNamespace1, additionalNamespace2} {
+				for i := range testDataSources {
+					replica := &cdiv1beta1.DataSource{}
+					key := client.ObjectKey{Name: testDataSources[i].Name, Namespace: namespace}
+					Expect(request.Client.Get(request.Context, key, replica)).To(Succeed())
+					Expect(replica.Spec).To(Equal(testDataSources[i].Spec))
+				}
+			}
+		})
+
+		It("should remove replicated DataSources when namespace is removed", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			request.Instance.Spec.CommonTemplates.AdditionalNamespaces = []string{additionalNamespace2}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := range testDataSources {
+				ExpectResourceNotExists(newDataSourceReplica(&testDataSources[i], additionalNamespace1), request)
+				ExpectResourceExists(newDataSourceReplica(&testDataSources[i], additionalNamespace2), request)
+				ExpectResourceExists(&testDataSources[i], request)
+			}
+		})
+
+		It("should keep DataSource in removed namespace, if not owned by SSP CR", func() {
+			notOwned := newDataSourceReplica(&testDataSources[0], additionalNamespace1)
+			notOwned.Name = "not-owned"
+			Expect(request.Client.Create(request.Context, notOwned)).To(Succeed())
+
+			request.Instance.Spec.CommonTemplates.AdditionalNamespaces = nil
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(notOwned, request)
+		})
+
+		It("should keep DataSource in removed namespace, if not labeled by the operand", func() {
+			unlabeled := newDataSourceReplica(&testDataSources[0], additionalNamespace1)
+			unlabeled.Name = "unlabeled"
+			Expect(libhandler.SetOwnerAnnotations(request.Instance, unlabeled)).To(Succeed())
+			Expect(request.Client.Create(request.Context, unlabeled)).To(Succeed())
+
+			request.Instance.Spec.CommonTemplates.AdditionalNamespaces = nil
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(unlabeled, request)
+		})
+	})
+
 	Context("with DataImportCron template", func() {
 		var (
 			cronTemplate ssp.DataImportCronTemplate
@@ -191,6 +293,105 @@ var _ = Describe("Data-Sources operand", func() {
 				ExpectResourceNotExists(&cron, request)
 			})
 
+			It("should remove DataImportCron if template is disabled and create it when enabled again", func() {
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+
+				setEnableAnnotation := func(value string) {
+					request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].Annotations = map[string]string{
+						ssp.DataImportCronTemplateEnableAnnotation: value,
+					}
+				}
+
+				setEnableAnnotation("true")
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceExists(&cron, request)
+
+				setEnableAnnotation("false")
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceNotExists(&cron, request)
+				Expect(request.Instance.Spec.CommonTemplates.DataImportCronTemplates).To(HaveLen(1))
+
+				setEnableAnnotation("true")
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceExists(&cron, request)
+			})
+
+			It("should not create DataImportCron if template is disabled", func() {
+				cronTemplate.Annotations = map[string]string{
+					ssp.DataImportCronTemplateEnableAnnotation: "false",
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceNotExists(&cron, request)
+			})
+
+			It("should record history of imports in status", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.Instance.Status.RecentImports).To(BeEmpty())
+
+				cron := &cdiv1beta1.DataImportCron{}
+				cronKey := client.ObjectKey{Name: cronTemplate.GetName(), Namespace: internal.GoldenImagesNamespace}
+				Expect(request.Client.Get(request.Context, cronKey, cron)).To(Succeed())
+
+				successTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+				cron.Status.LastImportTimestamp = &successTime
+				Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.Instance.Status.RecentImports).To(Equal([]ssp.ImportHistoryEntry{{
+					Name:      cronTemplate.GetName(),
+					Namespace: internal.GoldenImagesNamespace,
+					Result:    ssp.ImportSucceeded,
+					Time:      successTime,
+				}}))
+
+				Expect(request.Client.Get(request.Context, cronKey, cron)).To(Succeed())
+				failureTime := metav1.NewTime(time.Now().Truncate(time.Second))
+				cron.Status.Conditions = []cdiv1beta1.DataImportCronCondition{{
+					Type: cdiv1beta1.DataImportCronProgressing,
+					ConditionState: cdiv1beta1.ConditionState{
+						Status:             v1.ConditionFalse,
+						Reason:             string(cdiv1beta1.Failed),
+						Message:            "Import DataVolume phase Failed",
+						LastTransitionTime: failureTime,
+					},
+				}}
+				Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+				expectedHistory := []ssp.ImportHistoryEntry{{
+					Name:      cronTemplate.GetName(),
+					Namespace: internal.GoldenImagesNamespace,
+					Result:    ssp.ImportFailed,
+					Time:      failureTime,
+					Message:   "Import DataVolume phase Failed",
+				}, {
+					Name:      cronTemplate.GetName(),
+					Namespace: internal.GoldenImagesNamespace,
+					Result:    ssp.ImportSucceeded,
+					Time:      successTime,
+				}}
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.Instance.Status.RecentImports).To(Equal(expectedHistory))
+
+				// Repeated reconciliation does not add the same imports again
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.Instance.Status.RecentImports).To(Equal(expectedHistory))
+			})
+
 			It("should restore DataSource if DataImportCron template is removed", func() {
 				_, err := operand.Reconcile(&request)
 				Expect(err).ToNot(HaveOccurred())
@@ -219,6 +420,92 @@ var _ = Describe("Data-Sources operand", func() {
 			})
 		})
 
+		Context("with custom golden images namespace", func() {
+			const goldenImagesNamespace = "custom-golden-images"
+
+			BeforeEach(func() {
+				request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = goldenImagesNamespace
+				Expect(request.Client.Create(request.Context, newGoldenImagesNS(goldenImagesNamespace))).To(Succeed())
+			})
+
+			It("should create DataImportCron in custom golden images namespace", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = goldenImagesNamespace
+				ExpectResourceExists(&cron, request)
+
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceNotExists(&cron, request)
+			})
+
+			It("should create DataSources referencing PVCs in custom golden images namespace", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				for i := range testDataSources {
+					dataSource := &cdiv1beta1.DataSource{}
+					key := client.ObjectKey{Name: testDataSources[i].Name, Namespace: goldenImagesNamespace}
+					Expect(request.Client.Get(request.Context, key, dataSource)).To(Succeed())
+					if dataSource.Spec.Source.PVC != nil {
+						Expect(dataSource.Spec.Source.PVC.Namespace).To(Equal(goldenImagesNamespace))
+					}
+
+					ExpectResourceNotExists(&testDataSources[i], request)
+				}
+			})
+
+			It("should create roles in custom golden images namespace", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceExists(newViewRole(goldenImagesNamespace), request)
+				ExpectResourceExists(newViewRoleBinding(goldenImagesNamespace), request)
+			})
+
+			It("should not create default golden images namespace", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceNotExists(newGoldenImagesNS(internal.GoldenImagesNamespace), request)
+				Expect(recorder.Events).ToNot(Receive())
+			})
+
+			It("should move DataImportCron when custom golden images namespace is unset", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				request.Instance.Spec.CommonTemplates.GoldenImagesNamespace = ""
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{cronTemplate}
+				// The first reconciliation removes DataSources from the custom namespace,
+				// DataImportCrons are reconciled after that.
+				for i := 0; i < 2; i++ {
+					_, err = operand.Reconcile(&request)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceExists(&cron, request)
+
+				cron.Namespace = goldenImagesNamespace
+				ExpectResourceNotExists(&cron, request)
+			})
+
+			It("should not remove custom golden images namespace on cleanup", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				request.CrdList = fakeCrdList{}
+				_, err = operand.Cleanup(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				ExpectResourceExists(newGoldenImagesNS(goldenImagesNamespace), request)
+				ExpectResourceNotExists(newViewRole(goldenImagesNamespace), request)
+			})
+		})
+
 		Context("with existing PVC", func() {
 			var (
 				pvc *v1.PersistentVolumeClaim
@@ -278,27 +565,915 @@ var _ = Describe("Data-Sources operand", func() {
 			})
 		})
 
-		It("should keep DataImportCron, if not owned by SSP CR", func() {
-			cron := &cdiv1beta1.DataImportCron{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-cron",
+		Context("with source transport", func() {
+			getCreatedCron := func() *cdiv1beta1.DataImportCron {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := &cdiv1beta1.DataImportCron{}
+				Expect(request.Client.Get(request.Context, client.ObjectKey{
+					Name:      cronTemplate.GetName(),
 					Namespace: internal.GoldenImagesNamespace,
-				},
-				Spec: cdiv1beta1.DataImportCronSpec{},
+				}, cron)).To(Succeed())
+				return cron
 			}
 
-			err := request.Client.Create(request.Context, cron)
-			Expect(err).ToNot(HaveOccurred())
+			It("should map registry transport to DataVolume registry source", func() {
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].Source = &ssp.GoldenImageSource{
+					Transport: ssp.GoldenImageSourceRegistry,
+					URL:       "docker://quay.io/containerdisks/fedora:latest",
+					SecretRef: "image-credentials",
+				}
 
-			ExpectResourceExists(cron, request)
+				Expect(getCreatedCron().Spec.Template.Spec.Source).To(Equal(&cdiv1beta1.DataVolumeSource{
+					Registry: &cdiv1beta1.DataVolumeSourceRegistry{
+						URL:       pointer.String("docker://quay.io/containerdisks/fedora:latest"),
+						SecretRef: pointer.String("image-credentials"),
+					},
+				}))
+			})
 
-			_, err = operand.Reconcile(&request)
-			Expect(err).ToNot(HaveOccurred())
+			It("should not map unsupported transport", func() {
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].Source = &ssp.GoldenImageSource{
+					Transport: "s3",
+					URL:       "https://images.example.com/fedora.qcow2",
+				}
 
-			ExpectResourceExists(cron, request)
+				Expect(getCreatedCron().Spec.Template.Spec.Source).To(BeNil())
+			})
+
+			It("should keep DataVolume template source without transport", func() {
+				templateSource := &cdiv1beta1.DataVolumeSource{
+					Registry: &cdiv1beta1.DataVolumeSourceRegistry{
+						URL: pointer.String("docker://quay.io/containerdisks/centos:latest"),
+					},
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].Spec.Template.Spec.Source = templateSource
+
+				Expect(getCreatedCron().Spec.Template.Spec.Source).To(Equal(templateSource))
+			})
 		})
-	})
-})
+
+		Context("with force refresh annotation", func() {
+			const cdiNextCronTime = "2000-01-01T00:00:00Z"
+
+			getCron := func() *cdiv1beta1.DataImportCron {
+				cron := &cdiv1beta1.DataImportCron{}
+				key := client.ObjectKey{Name: cronTemplate.GetName(), Namespace: internal.GoldenImagesNamespace}
+				Expect(request.Client.Get(request.Context, key, cron)).To(Succeed())
+				return cron
+			}
+
+			// simulateCDI sets the next cron time, like CDI does after checking the source
+			simulateCDI := func() {
+				cron := getCron()
+				if cron.Annotations == nil {
+					cron.Annotations = map[string]string{}
+				}
+				cron.Annotations[NextCronTimeAnnotation] = cdiNextCronTime
+				Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+			}
+
+			isRefreshed := func() bool {
+				return getCron().GetAnnotations()[NextCronTimeAnnotation] != cdiNextCronTime
+			}
+
+			reconcile := func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				reconcile()
+				simulateCDI()
+			})
+
+			It("should not refresh without annotation", func() {
+				reconcile()
+				Expect(isRefreshed()).To(BeFalse())
+			})
+
+			It("should refresh once when annotation on SSP CR changes", func() {
+				request.Instance.SetAnnotations(map[string]string{
+					ssp.ForceRefreshAnnotation: "2023-05-01T10:00:00Z",
+				})
+				reconcile()
+				Expect(isRefreshed()).To(BeTrue())
+
+				simulateCDI()
+				reconcile()
+				Expect(isRefreshed()).To(BeFalse(), "unchanged value should not refresh again")
+
+				request.Instance.SetAnnotations(map[string]string{
+					ssp.ForceRefreshAnnotation: "2023-05-02T10:00:00Z",
+				})
+				reconcile()
+				Expect(isRefreshed()).To(BeTrue())
+			})
+
+			It("should refresh once when annotation on DataImportCronTemplate changes", func() {
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].SetAnnotations(map[string]string{
+					ssp.ForceRefreshAnnotation: "1",
+				})
+				reconcile()
+				Expect(isRefreshed()).To(BeTrue())
+
+				simulateCDI()
+				reconcile()
+				Expect(isRefreshed()).To(BeFalse(), "unchanged value should not refresh again")
+			})
+
+			It("should not modify annotations of DataImportCronTemplate", func() {
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].SetAnnotations(map[string]string{
+					ssp.ForceRefreshAnnotation: "1",
+				})
+				reconcile()
+				Expect(request.Instance.Spec.CommonTemplates.DataImportCronTemplates[0].GetAnnotations()).To(Equal(map[string]string{
+					ssp.ForceRefreshAnnotation: "1",
+				}))
+			})
+
+			It("should not refresh DataImportCron when it is created", func() {
+				request.Instance.SetAnnotations(map[string]string{
+					ssp.ForceRefreshAnnotation: "1",
+				})
+				Expect(request.Client.Delete(request.Context, getCron())).To(Succeed())
+
+				reconcile()
+				Expect(getCron().GetAnnotations()).ToNot(HaveKey(NextCronTimeAnnotation))
+				Expect(getCron().GetAnnotations()).To(HaveKeyWithValue(forceRefreshProcessedAnnotation, "1"))
+			})
+		})
+
+		Context("with paused data imports", func() {
+			BeforeEach(func() {
+				request.Instance.Spec.CommonTemplates.PauseDataImports = pointer.Bool(true)
+			})
+
+			It("should not create DataImportCron", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceNotExists(&cron, request)
+			})
+
+			It("should still reconcile DataSources", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, ds := range testDataSources {
+					ExpectResourceExists(&ds, request)
+				}
+			})
+
+			It("should not update or remove existing DataImportCron", func() {
+				request.Instance.Spec.CommonTemplates.PauseDataImports = nil
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceExists(&cron, request)
+
+				request.Instance.Spec.CommonTemplates.PauseDataImports = pointer.Bool(true)
+				updatedTemplate := cronTemplate.DeepCopy()
+				updatedTemplate.Spec.Schedule = "0 0 * * *"
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = []ssp.DataImportCronTemplate{*updatedTemplate}
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				foundCron := &cdiv1beta1.DataImportCron{}
+				Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(&cron), foundCron)).To(Succeed())
+				Expect(foundCron.Spec.Schedule).To(BeEmpty())
+
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = nil
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceExists(&cron, request)
+			})
+
+			It("should resume DataImportCron reconciliation when unpaused", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				cron := cronTemplate.AsDataImportCron()
+				cron.Namespace = internal.GoldenImagesNamespace
+				ExpectResourceNotExists(&cron, request)
+
+				request.Instance.Spec.CommonTemplates.PauseDataImports = pointer.Bool(false)
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				ExpectResourceExists(&cron, request)
+			})
+		})
+
+		Context("requeue", func() {
+			setCronProgressing := func(status v1.ConditionStatus) {
+				cron := &cdiv1beta1.DataImportCron{}
+				key := client.ObjectKey{Name: cronTemplate.GetName(), Namespace: internal.GoldenImagesNamespace}
+				Expect(request.Client.Get(request.Context, key, cron)).To(Succeed())
+				cron.Status.Conditions = []cdiv1beta1.DataImportCronCondition{{
+					Type: cdiv1beta1.DataImportCronProgressing,
+					ConditionState: cdiv1beta1.ConditionState{
+						Status: status,
+					},
+				}}
+				Expect(request.Client.Status().Update(request.Context, cron)).To(Succeed())
+			}
+
+			AfterEach(func() {
+				Expect(os.Unsetenv(common.DataImportCronActiveRequeueIntervalKey)).To(Succeed())
+				Expect(os.Unsetenv(common.DataImportCronSteadyRequeueIntervalKey)).To(Succeed())
+			})
+
+			It("should requeue with short interval while import is in progress", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				setCronProgressing(v1.ConditionTrue)
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronActiveRequeueInterval))
+			})
+
+			It("should requeue with long interval when imports are steady", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronSteadyRequeueInterval))
+
+				setCronProgressing(v1.ConditionFalse)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronSteadyRequeueInterval))
+			})
+
+			It("should use configured intervals", func() {
+				Expect(os.Setenv(common.DataImportCronActiveRequeueIntervalKey, "5s")).To(Succeed())
+				Expect(os.Setenv(common.DataImportCronSteadyRequeueIntervalKey, "1h")).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(Equal(time.Hour))
+
+				setCronProgressing(v1.ConditionTrue)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(Equal(5 * time.Second))
+			})
+
+			It("should not requeue while data imports are paused", func() {
+				request.Instance.Spec.CommonTemplates.PauseDataImports = pointer.Bool(true)
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(request.GetRequeueAfter()).To(BeZero())
+			})
+		})
+
+		Context("with failing DataImportCron reconciliation", func() {
+			var (
+				failingClient *failingCronClient
+				fakeClock     *clocktesting.FakeClock
+			)
+
+			BeforeEach(func() {
+				failingClient = &failingCronClient{
+					Client: request.Client,
+					err:    errors.NewServiceUnavailable("cdi is being upgraded"),
+				}
+				request.Client = failingClient
+
+				fakeClock = clocktesting.NewFakeClock(time.Now())
+				operand.(*dataSources).cronBackoff.clock = fakeClock
+			})
+
+			AfterEach(func() {
+				Expect(os.Unsetenv(common.DataImportCronMaxBackoffKey)).To(Succeed())
+			})
+
+			// reconcile runs the reconciliation with a copy of the request, so the requeue interval is not shared
+			reconcile := func() ([]common.ReconcileResult, time.Duration) {
+				req := request
+				results, err := operand.Reconcile(&req)
+				Expect(err).ToNot(HaveOccurred())
+				return results, req.GetRequeueAfter()
+			}
+
+			It("should requeue with growing intervals on transient errors", func() {
+				var intervals []time.Duration
+				for i := 0; i < 4; i++ {
+					results, requeueAfter := reconcile()
+					Expect(results).To(HaveLen(1))
+					Expect(results[0].Status.Degraded).To(HaveValue(ContainSubstring("cdi is being upgraded")))
+					intervals = append(intervals, requeueAfter)
+					fakeClock.Step(requeueAfter)
+				}
+
+				Expect(intervals[0]).To(BeNumerically(">=", dataImportCronInitialBackoff))
+				for i := 1; i < len(intervals); i++ {
+					Expect(intervals[i]).To(BeNumerically(">", intervals[i-1]))
+				}
+				Expect(failingClient.cronCreates).To(Equal(4))
+			})
+
+			It("should not retry before the backoff elapses", func() {
+				_, firstInterval := reconcile()
+				Expect(failingClient.cronCreates).To(Equal(1))
+
+				fakeClock.Step(time.Second)
+				_, requeueAfter := reconcile()
+				Expect(failingClient.cronCreates).To(Equal(1))
+				Expect(requeueAfter).To(Equal(firstInterval - time.Second))
+			})
+
+			It("should cap the interval at the configured maximum", func() {
+				Expect(os.Setenv(common.DataImportCronMaxBackoffKey, "12s")).To(Succeed())
+
+				var requeueAfter time.Duration
+				for i := 0; i < 5; i++ {
+					_, requeueAfter = reconcile()
+					Expect(requeueAfter).To(BeNumerically("<=", 12*time.Second))
+					fakeClock.Step(requeueAfter)
+				}
+				Expect(requeueAfter).To(Equal(12 * time.Second))
+			})
+
+			It("should reset the backoff after successful reconciliation", func() {
+				for i := 0; i < 3; i++ {
+					_, requeueAfter := reconcile()
+					fakeClock.Step(requeueAfter)
+				}
+
+				failingClient.err = nil
+				results, _ := reconcile()
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].IsSuccess()).To(BeTrue())
+				Expect(operand.(*dataSources).cronBackoff.entries).To(BeEmpty())
+
+				Expect(request.Client.Delete(request.Context, results[0].Resource)).To(Succeed())
+				failingClient.err = errors.NewServiceUnavailable("cdi is being upgraded")
+				_, requeueAfter := reconcile()
+				Expect(requeueAfter).To(BeNumerically("<", 2*dataImportCronInitialBackoff))
+			})
+
+			It("should fail immediately on errors that are not transient", func() {
+				failingClient.err = errors.NewBadRequest("invalid DataImportCron")
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).To(MatchError(ContainSubstring("invalid DataImportCron")))
+				Expect(operand.(*dataSources).cronBackoff.entries).To(BeEmpty())
+			})
+		})
+
+		Context("with limited DataImportCron creations", func() {
+			BeforeEach(func() {
+				Expect(os.Setenv(common.MaxDataImportCronCreationsKey, "2")).To(Succeed())
+
+				var cronTemplates []ssp.DataImportCronTemplate
+				for _, cronName := range []string{"cron-1", "cron-2", "cron-3"} {
+					cronTemplates = append(cronTemplates, ssp.DataImportCronTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: cronName,
+						},
+						Spec: cdiv1beta1.DataImportCronSpec{
+							ManagedDataSource: cronName,
+						},
+					})
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = cronTemplates
+			})
+
+			AfterEach(func() {
+				Expect(os.Unsetenv(common.MaxDataImportCronCreationsKey)).To(Succeed())
+			})
+
+			listCronNames := func() []string {
+				crons := &cdiv1beta1.DataImportCronList{}
+				Expect(request.Client.List(request.Context, crons, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+				var names []string
+				for _, cron := range crons.Items {
+					names = append(names, cron.Name)
+				}
+				return names
+			}
+
+			It("should create DataImportCrons in batches across reconciliations", func() {
+				results, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2"))
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronActiveRequeueInterval))
+
+				var progressing []string
+				for _, result := range results {
+					if result.Status.Progressing != nil {
+						progressing = append(progressing, *result.Status.Progressing)
+					}
+				}
+				Expect(progressing).To(ConsistOf("Creation of DataImportCron cron-3 is postponed"))
+
+				results, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3"))
+				for _, result := range results {
+					Expect(result.IsSuccess()).To(BeTrue())
+				}
+			})
+
+			It("should not count existing DataImportCrons", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(HaveLen(3))
+
+				for _, cronName := range []string{"cron-4", "cron-5"} {
+					request.Instance.Spec.CommonTemplates.DataImportCronTemplates = append(request.Instance.Spec.CommonTemplates.DataImportCronTemplates,
+						ssp.DataImportCronTemplate{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: cronName,
+							},
+							Spec: cdiv1beta1.DataImportCronSpec{
+								ManagedDataSource: cronName,
+							},
+						})
+				}
+
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3", "cron-4", "cron-5"))
+			})
+
+			It("should not limit creations when limit is zero", func() {
+				Expect(os.Setenv(common.MaxDataImportCronCreationsKey, "0")).To(Succeed())
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3"))
+			})
+		})
+
+		Context("with limited concurrent imports", func() {
+			BeforeEach(func() {
+				var cronTemplates []ssp.DataImportCronTemplate
+				for _, cronName := range []string{"cron-1", "cron-2", "cron-3"} {
+					cronTemplates = append(cronTemplates, ssp.DataImportCronTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: cronName,
+						},
+						Spec: cdiv1beta1.DataImportCronSpec{
+							ManagedDataSource: cronName,
+						},
+					})
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = cronTemplates
+			})
+
+			AfterEach(func() {
+				Expect(os.Unsetenv(common.MaxConcurrentDataImportsKey)).To(Succeed())
+			})
+
+			getCron := func(name string) *cdiv1beta1.DataImportCron {
+				cron := &cdiv1beta1.DataImportCron{}
+				key := client.ObjectKey{Name: name, Namespace: internal.GoldenImagesNamespace}
+				Expect(request.Client.Get(request.Context, key, cron)).To(Succeed())
+				return cron
+			}
+
+			setCronProgressing := func(name string, status v1.ConditionStatus) {
+				cron := getCron(name)
+				cron.Status.Conditions = []cdiv1beta1.DataImportCronCondition{{
+					Type: cdiv1beta1.DataImportCronProgressing,
+					ConditionState: cdiv1beta1.ConditionState{
+						Status: status,
+					},
+				}}
+				Expect(request.Client.Status().Update(request.Context, cron)).To(Succeed())
+			}
+
+			listCronNames := func() []string {
+				crons := &cdiv1beta1.DataImportCronList{}
+				Expect(request.Client.List(request.Context, crons, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+				var names []string
+				for _, cron := range crons.Items {
+					names = append(names, cron.Name)
+				}
+				return names
+			}
+
+			listSuspendedCronNames := func() []string {
+				crons := &cdiv1beta1.DataImportCronList{}
+				Expect(request.Client.List(request.Context, crons, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+				var names []string
+				for i := range crons.Items {
+					if isDataImportCronSuspended(&crons.Items[i]) {
+						names = append(names, crons.Items[i].Name)
+					}
+				}
+				return names
+			}
+
+			createAllCrons := func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3"))
+			}
+
+			It("should not create more DataImportCrons than free import slots", func() {
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "1")).To(Succeed())
+
+				results, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1"))
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronActiveRequeueInterval))
+
+				var progressing []string
+				for _, result := range results {
+					if result.Status.Progressing != nil {
+						progressing = append(progressing, *result.Status.Progressing)
+					}
+				}
+				Expect(progressing).To(ConsistOf(
+					"Creation of DataImportCron cron-2 is postponed",
+					"Creation of DataImportCron cron-3 is postponed",
+				))
+
+				setCronProgressing("cron-1", v1.ConditionTrue)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1"))
+
+				setCronProgressing("cron-1", v1.ConditionFalse)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2"))
+			})
+
+			It("should suspend idle DataImportCrons while the limit is reached", func() {
+				createAllCrons()
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "1")).To(Succeed())
+				setCronProgressing("cron-1", v1.ConditionTrue)
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-2", "cron-3"))
+				Expect(request.GetRequeueAfter()).To(Equal(common.DefaultDataImportCronActiveRequeueInterval))
+
+				nextCronTime, err := time.Parse(time.RFC3339, getCron("cron-2").GetAnnotations()[NextCronTimeAnnotation])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextCronTime).To(BeTemporally(">", time.Now().Add(24*time.Hour)))
+			})
+
+			It("should resume suspended DataImportCrons one at a time", func() {
+				createAllCrons()
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "1")).To(Succeed())
+				setCronProgressing("cron-1", v1.ConditionTrue)
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-2", "cron-3"))
+
+				// cron-2 takes the only free slot, so the idle cron-1 is suspended
+				setCronProgressing("cron-1", v1.ConditionFalse)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-1", "cron-3"))
+
+				nextCronTime, err := time.Parse(time.RFC3339, getCron("cron-2").GetAnnotations()[NextCronTimeAnnotation])
+				Expect(err).ToNot(HaveOccurred())
+				Expect(nextCronTime).To(BeTemporally("<=", time.Now()))
+
+				setCronProgressing("cron-2", v1.ConditionTrue)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-1", "cron-3"))
+			})
+
+			It("should not exceed the limit with running, suspended and idle DataImportCrons", func() {
+				createAllCrons()
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "1")).To(Succeed())
+				setCronProgressing("cron-1", v1.ConditionTrue)
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-2", "cron-3"))
+
+				// Resume cron-3 only, so cron-2 is suspended and cron-3 is idle
+				cron := getCron("cron-3")
+				cron.Annotations[dataImportSuspendedAnnotation] = "false"
+				Expect(request.Client.Update(request.Context, cron)).To(Succeed())
+
+				// With limit 2, cron-1 is running, so only one of cron-2 and cron-3 can be active
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "2")).To(Succeed())
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(ConsistOf("cron-3"))
+			})
+
+			It("should resume all DataImportCrons when the limit is removed", func() {
+				createAllCrons()
+				Expect(os.Setenv(common.MaxConcurrentDataImportsKey, "1")).To(Succeed())
+				setCronProgressing("cron-1", v1.ConditionTrue)
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(HaveLen(2))
+
+				Expect(os.Unsetenv(common.MaxConcurrentDataImportsKey)).To(Succeed())
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listSuspendedCronNames()).To(BeEmpty())
+			})
+		})
+
+		Context("with DataImportCron startup delay", func() {
+			const startupDelay = 10 * time.Minute
+
+			BeforeEach(func() {
+				Expect(os.Setenv(common.DataImportCronStartupDelayKey, startupDelay.String())).To(Succeed())
+
+				var cronTemplates []ssp.DataImportCronTemplate
+				for _, cronName := range []string{"cron-1", "cron-2", "cron-3"} {
+					cronTemplates = append(cronTemplates, ssp.DataImportCronTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: cronName,
+						},
+						Spec: cdiv1beta1.DataImportCronSpec{
+							ManagedDataSource: cronName,
+						},
+					})
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = cronTemplates
+			})
+
+			AfterEach(func() {
+				Expect(os.Unsetenv(common.DataImportCronStartupDelayKey)).To(Succeed())
+			})
+
+			listCronNames := func() []string {
+				crons := &cdiv1beta1.DataImportCronList{}
+				Expect(request.Client.List(request.Context, crons, client.InNamespace(internal.GoldenImagesNamespace))).To(Succeed())
+				var names []string
+				for _, cron := range crons.Items {
+					names = append(names, cron.Name)
+				}
+				return names
+			}
+
+			It("should stagger creation of DataImportCrons after installation", func() {
+				request.Instance.CreationTimestamp = metav1.Now()
+
+				results, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1"))
+				Expect(request.GetRequeueAfter()).To(BeNumerically("~", startupDelay, time.Minute))
+
+				var progressing []string
+				for _, result := range results {
+					if result.Status.Progressing != nil {
+						progressing = append(progressing, *result.Status.Progressing)
+					}
+				}
+				Expect(progressing).To(ConsistOf(
+					"Creation of DataImportCron cron-2 is postponed",
+					"Creation of DataImportCron cron-3 is postponed",
+				))
+			})
+
+			It("should create DataImportCrons whose startup delay elapsed", func() {
+				request.Instance.CreationTimestamp = metav1.NewTime(time.Now().Add(-startupDelay - time.Minute))
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2"))
+				Expect(request.GetRequeueAfter()).To(BeNumerically("~", startupDelay-time.Minute, time.Minute))
+			})
+
+			It("should not delay DataImportCrons when all startup delays elapsed", func() {
+				request.Instance.CreationTimestamp = metav1.NewTime(time.Now().Add(-3 * startupDelay))
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3"))
+			})
+
+			It("should not delay DataImportCrons when startup delay is zero", func() {
+				Expect(os.Setenv(common.DataImportCronStartupDelayKey, "0s")).To(Succeed())
+				request.Instance.CreationTimestamp = metav1.Now()
+
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(listCronNames()).To(ConsistOf("cron-1", "cron-2", "cron-3"))
+			})
+		})
+
+		Context("DataImportCron ready metric", func() {
+			const otherNamespace = "other-namespace"
+
+			scrapeReadyMetric := func() map[string]float64 {
+				registry := prometheus.NewRegistry()
+				ExpectWithOffset(1, registry.Register(DataImportCronReady)).To(Succeed())
+				families, err := registry.Gather()
+				ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+				values := map[string]float64{}
+				for _, family := range families {
+					ExpectWithOffset(1, family.GetName()).To(Equal("kubevirt_ssp_data_import_cron_ready"))
+					for _, metric := range family.GetMetric() {
+						labels := map[string]string{}
+						for _, label := range metric.GetLabel() {
+							labels[label.GetName()] = label.GetValue()
+						}
+						ExpectWithOffset(1, labels).To(HaveLen(2))
+						values[labels["namespace"]+"/"+labels["name"]] = metric.GetGauge().GetValue()
+					}
+				}
+				return values
+			}
+
+			setDataSourceReady := func(status v1.ConditionStatus) {
+				ds := &cdiv1beta1.DataSource{}
+				ExpectWithOffset(1, request.Client.Get(request.Context, client.ObjectKeyFromObject(&testDataSources[0]), ds)).To(Succeed())
+				ds.Status.Conditions = []cdiv1beta1.DataSourceCondition{{
+					Type:           cdiv1beta1.DataSourceReady,
+					ConditionState: cdiv1beta1.ConditionState{Status: status},
+				}}
+				ExpectWithOffset(1, request.Client.Update(request.Context, ds)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				DataImportCronReady.Reset()
+
+				otherCronTemplate := ssp.DataImportCronTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "other-cron",
+						Namespace: otherNamespace,
+					},
+					Spec: cdiv1beta1.DataImportCronSpec{
+						ManagedDataSource: "other-data-source",
+					},
+				}
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = append(
+					request.Instance.Spec.CommonTemplates.DataImportCronTemplates, otherCronTemplate)
+			})
+
+			It("should report templates without ready DataSource as not ready", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(scrapeReadyMetric()).To(Equal(map[string]float64{
+					internal.GoldenImagesNamespace + "/" + cronTemplate.Name: 0,
+					otherNamespace + "/other-cron":                           0,
+				}))
+			})
+
+			It("should follow the ready condition of DataSource", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				setDataSourceReady(v1.ConditionTrue)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scrapeReadyMetric()).To(HaveKeyWithValue(internal.GoldenImagesNamespace+"/"+cronTemplate.Name, float64(1)))
+
+				setDataSourceReady(v1.ConditionFalse)
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scrapeReadyMetric()).To(HaveKeyWithValue(internal.GoldenImagesNamespace+"/"+cronTemplate.Name, float64(0)))
+			})
+
+			It("should remove series of template removed from SSP CR", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scrapeReadyMetric()).To(HaveLen(2))
+
+				request.Instance.Spec.CommonTemplates.DataImportCronTemplates = request.Instance.Spec.CommonTemplates.DataImportCronTemplates[1:]
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(scrapeReadyMetric()).To(Equal(map[string]float64{
+					otherNamespace + "/other-cron": 0,
+				}))
+			})
+
+			It("should remove all series on cleanup", func() {
+				_, err := operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scrapeReadyMetric()).To(HaveLen(2))
+
+				request.CrdList = fakeCrdList{dataImportCronCrd: {}, dataSourceCrd: {}}
+				_, err = operand.Cleanup(&request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(scrapeReadyMetric()).To(BeEmpty())
+			})
+		})
+
+		It("should keep DataImportCron, if not owned by SSP CR", func() {
+			cron := &cdiv1beta1.DataImportCron{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cron",
+					Namespace: internal.GoldenImagesNamespace,
+				},
+				Spec: cdiv1beta1.DataImportCronSpec{},
+			}
+
+			err := request.Client.Create(request.Context, cron)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(cron, request)
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(cron, request)
+		})
+	})
+})
+
+type fakeCrdList map[string]struct{}
+
+func (f fakeCrdList) CrdExists(crdName string) bool {
+	_, exists := f[crdName]
+	return exists
+}
+
+func (f fakeCrdList) MissingCrds() []string {
+	return nil
+}
+
+var _ = Describe("Recent imports", func() {
+	It("should keep only the newest imports", func() {
+		request := &common.Request{
+			Instance: &ssp.SSP{},
+		}
+
+		baseTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		var crons []cdiv1beta1.DataImportCron
+		for i := 0; i < ssp.MaxRecentImports+2; i++ {
+			importTime := metav1.NewTime(baseTime.Add(time.Duration(i) * time.Hour))
+			crons = append(crons, cdiv1beta1.DataImportCron{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("cron-%d", i),
+					Namespace: internal.GoldenImagesNamespace,
+				},
+				Status: cdiv1beta1.DataImportCronStatus{
+					LastImportTimestamp: &importTime,
+				},
+			})
+		}
+
+		updateRecentImports(request, crons)
+		// Older imports are not added again
+		updateRecentImports(request, crons)
+
+		recentImports := request.Instance.Status.RecentImports
+		Expect(recentImports).To(HaveLen(ssp.MaxRecentImports))
+		Expect(recentImports[0].Name).To(Equal(fmt.Sprintf("cron-%d", ssp.MaxRecentImports+1)))
+		Expect(recentImports[ssp.MaxRecentImports-1].Name).To(Equal("cron-2"))
+	})
+})
+
+var _ = Describe("DataImportCron startup offset", func() {
+	const startupDelay = 5 * time.Minute
+
+	installTime := metav1.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	DescribeTable("should offset DataImportCron creation by its index", func(index int, elapsed, expected time.Duration) {
+		now := installTime.Add(elapsed)
+		Expect(dataImportCronStartupOffset(index, startupDelay, installTime, now)).To(Equal(expected))
+	},
+		Entry("first at installation", 0, time.Duration(0), time.Duration(0)),
+		Entry("second at installation", 1, time.Duration(0), 5*time.Minute),
+		Entry("third at installation", 2, time.Duration(0), 10*time.Minute),
+		Entry("third after some time", 2, 7*time.Minute, 3*time.Minute),
+		Entry("third after its delay", 2, 11*time.Minute, -1*time.Minute),
+	)
+
+	It("should not offset without startup delay", func() {
+		Expect(dataImportCronStartupOffset(3, 0, installTime, installTime.Time)).To(BeZero())
+	})
+
+	It("should not offset without installation time", func() {
+		Expect(dataImportCronStartupOffset(3, startupDelay, metav1.Time{}, time.Now())).To(BeZero())
+	})
+})
+
+// failingCronClient fails creation of DataImportCrons with the configured error
+type failingCronClient struct {
+	client.Client
+	err         error
+	cronCreates int
+}
+
+func (f *failingCronClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, isCron := obj.(*cdiv1beta1.DataImportCron); isCron {
+		f.cronCreates++
+		if f.err != nil {
+			return f.err
+		}
+	}
+	return f.Client.Create(ctx, obj, opts...)
+}
 
 func getDataSources() []cdiv1beta1.DataSource {
 	const name1 = "centos8"
@@ -5,6 +5,8 @@ import (
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+
+	"kubevirt.io/ssp-operator/internal"
 )
 
 const (
@@ -126,3 +128,24 @@ func newEditRole() *rbac.ClusterRole {
 		},
 	}
 }
+
+func newDataSourceReplica(dataSource *cdiv1beta1.DataSource, namespace string) *cdiv1beta1.DataSource {
+	return &cdiv1beta1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dataSource.Name,
+			Namespace: namespace,
+		},
+		Spec: *dataSource.Spec.DeepCopy(),
+	}
+}
+
+// newGoldenImageDataSource returns a copy of the DataSource from the template bundle placed in the golden images namespace.
+// The bundle references PVCs in the default golden images namespace, so the references are updated too.
+func newGoldenImageDataSource(dataSource *cdiv1beta1.DataSource, namespace string) *cdiv1beta1.DataSource {
+	ds := dataSource.DeepCopy()
+	ds.Namespace = namespace
+	if ds.Spec.Source.PVC != nil && ds.Spec.Source.PVC.Namespace == internal.GoldenImagesNamespace {
+		ds.Spec.Source.PVC.Namespace = namespace
+	}
+	return ds
+}
@@ -9,8 +9,8 @@ import (
 )
 
 // Define RBAC rules needed by this operand:
-// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules;servicemonitors;services,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io/v1,resources=role;rolebinding;serviceaccount,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules;servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods;endpoints,verbs=get;list;watch
 
 const prometheusRulesCrd = "prometheusrules.monitoring.coreos.com"
@@ -75,8 +75,13 @@ const (
 )
 
 func reconcilePrometheusMonitor(request *common.Request) (common.ReconcileResult, error) {
+	var additionalLabels map[string]string
+	if monitoring := request.Instance.Spec.Monitoring; monitoring != nil {
+		additionalLabels = monitoring.ServiceMonitorLabels
+	}
+
 	return common.CreateOrUpdate(request).
-		NamespacedResource(newServiceMonitorCR(request.Namespace)).
+		NamespacedResource(newServiceMonitorCR(request.Namespace, additionalLabels)).
 		WithAppLabels(operandName, operandComponent).
 		Reconcile()
 }
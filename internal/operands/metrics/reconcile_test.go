@@ -9,9 +9,11 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	. "kubevirt.io/ssp-operator/internal/test-utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -34,7 +36,7 @@ var _ = Describe("Metrics operand", func() {
 	)
 
 	BeforeEach(func() {
-		client := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
 		request = common.Request{
 			Request: reconcile.Request{
 				NamespacedName: types.NamespacedName{
@@ -42,7 +44,7 @@ var _ = Describe("Metrics operand", func() {
 					Name:      name,
 				},
 			},
-			Client:  client,
+			Client:  fakeClient,
 			Context: context.Background(),
 			Instance: &ssp.SSP{
 				TypeMeta: metav1.TypeMeta{
@@ -71,11 +73,31 @@ var _ = Describe("Metrics operand", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		ExpectResourceExists(prometheusRule, request)
-		ExpectResourceExists(newServiceMonitorCR(namespace), request)
+		ExpectResourceExists(newServiceMonitorCR(namespace, nil), request)
 		ExpectResourceExists(newMonitoringClusterRole(), request)
 		ExpectResourceExists(newMonitoringClusterRoleBinding(), request)
 	})
 
+	It("should add configured labels to ServiceMonitor", func() {
+		request.Instance.Spec.Monitoring = &ssp.Monitoring{
+			ServiceMonitorLabels: map[string]string{
+				"release":                         "prometheus",
+				"openshift.io/cluster-monitoring": "false",
+			},
+		}
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		serviceMonitor := &promv1.ServiceMonitor{}
+		Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(newServiceMonitorCR(namespace, nil)), serviceMonitor)).To(Succeed())
+
+		Expect(serviceMonitor.Labels).To(HaveKeyWithValue("release", "prometheus"))
+		for key, value := range ServiceMonitorLabels() {
+			Expect(serviceMonitor.Labels).To(HaveKeyWithValue(key, value))
+		}
+	})
+
 	DescribeTable("runbook URL template",
 		func(template string) {
 			if template != defaultRunbookURLTemplate {
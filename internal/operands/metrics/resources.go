@@ -218,12 +218,20 @@ func ServiceMonitorLabels() map[string]string {
 	}
 }
 
-func newServiceMonitorCR(namespace string) *promv1.ServiceMonitor {
+func newServiceMonitorCR(namespace string, additionalLabels map[string]string) *promv1.ServiceMonitor {
+	labels := make(map[string]string, len(additionalLabels))
+	for key, value := range additionalLabels {
+		labels[key] = value
+	}
+	for key, value := range ServiceMonitorLabels() {
+		labels[key] = value
+	}
+
 	return &promv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      PrometheusRuleName,
-			Labels:    ServiceMonitorLabels(),
+			Labels:    labels,
 		},
 		Spec: promv1.ServiceMonitorSpec{
 			NamespaceSelector: v1.NamespaceSelector{
@@ -0,0 +1,204 @@
+package operator_config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+	"kubevirt.io/ssp-operator/internal/operands"
+	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
+)
+
+// Define RBAC rules needed by this operand:
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	ConfigMapName = "ssp-operator-effective-config"
+
+	flagKeyPrefix        = "flag."
+	featureGateKeyPrefix = "feature-gate."
+	envKeyPrefix         = "env."
+
+	operatorVersionKey        = "version.operator"
+	commonTemplatesVersionKey = "version.common-templates"
+	tektonTasksVersionKey     = "version.tekton-tasks"
+)
+
+func WatchTypes() []operands.WatchType {
+	return []operands.WatchType{
+		{Object: &core.ConfigMap{}},
+	}
+}
+
+type operatorConfig struct {
+	flags map[string]string
+}
+
+var _ operands.Operand = &operatorConfig{}
+
+// New returns an operand that stores the effective operator configuration in a ConfigMap.
+// The flags parameter contains the command line flags the operator was started with.
+func New(flags map[string]string) operands.Operand {
+	return &operatorConfig{
+		flags: flags,
+	}
+}
+
+func (o *operatorConfig) Name() string {
+	return operandName
+}
+
+func (o *operatorConfig) WatchTypes() []operands.WatchType {
+	return WatchTypes()
+}
+
+func (o *operatorConfig) WatchClusterTypes() []operands.WatchType {
+	return nil
+}
+
+func (o *operatorConfig) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
+	return common.CollectResourceStatus(request, o.reconcileConfigMap)
+}
+
+func (o *operatorConfig) Cleanup(*common.Request) ([]common.CleanupResult, error) {
+	// The ConfigMap is namespaced and owned by the SSP CR,
+	// so it will be removed by the garbage collector.
+	return nil, nil
+}
+
+const (
+	operandName      = "operator-config"
+	operandComponent = common.AppComponentConfiguration
+)
+
+func (o *operatorConfig) reconcileConfigMap(request *common.Request) (common.ReconcileResult, error) {
+	config, err := o.effectiveConfig(request)
+	if err != nil {
+		return common.ReconcileResult{}, err
+	}
+	return common.CreateOrUpdate(request).
+		NamespacedResource(newConfigMap(request.Namespace, config)).
+		WithAppLabels(operandName, operandComponent).
+		Reconcile()
+}
+
+// effectiveConfig returns the versions, command line flags, settings from environment variables
+// and feature gates the operator runs with. Settings from environment variables contain
+// the default value, if the variable is not set. Images of operands are not included.
+func (o *operatorConfig) effectiveConfig(request *common.Request) (map[string]string, error) {
+	config := map[string]string{
+		operatorVersionKey:        common.GetOperatorVersion(),
+		commonTemplatesVersionKey: common_templates.Version,
+		tektonTasksVersionKey:     common.TektonTasksVersion,
+	}
+
+	for name, value := range o.flags {
+		config[flagKeyPrefix+name] = value
+	}
+
+	featureGates := request.Instance.Spec.FeatureGates
+	if featureGates == nil {
+		featureGates = &ssp.FeatureGates{}
+	}
+	config[featureGateKeyPrefix+"deployTektonTaskResources"] = strconv.FormatBool(featureGates.DeployTektonTaskResources)
+	config[featureGateKeyPrefix+"exportCommonInstancetypes"] = strconv.FormatBool(featureGates.ExportCommonInstancetypes)
+
+	settings, err := envSettings()
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range settings {
+		config[envKeyPrefix+name] = value
+	}
+
+	return config, nil
+}
+
+// envSettings returns the effective value of each setting configured by an environment variable.
+// An empty value means that the setting is not limited.
+func envSettings() (map[string]string, error) {
+	durations := map[string]func() (time.Duration, error){
+		common.DataImportCronMinIntervalKey:           common.GetDataImportCronMinInterval,
+		common.DataImportCronActiveRequeueIntervalKey: common.GetDataImportCronActiveRequeueInterval,
+		common.DataImportCronSteadyRequeueIntervalKey: common.GetDataImportCronSteadyRequeueInterval,
+		common.DataImportCronStartupDelayKey:          common.GetDataImportCronStartupDelay,
+		common.DataImportCronMaxBackoffKey:            common.GetDataImportCronMaxBackoff,
+		common.DegradedGracePeriodKey:                 common.GetDegradedGracePeriod,
+	}
+	ints := map[string]func() (int, error){
+		common.MaxAdditionalNamespacesKey:    common.GetMaxAdditionalNamespaces,
+		common.MaxDataImportCronCreationsKey: common.GetMaxDataImportCronCreations,
+		common.MaxConcurrentDataImportsKey:   common.GetMaxConcurrentDataImports,
+	}
+
+	settings := make(map[string]string, len(durations)+len(ints)+5)
+	for name, getter := range durations {
+		value, err := getter()
+		if err != nil {
+			return nil, err
+		}
+		settings[name] = value.String()
+	}
+	for name, getter := range ints {
+		value, err := getter()
+		if err != nil {
+			return nil, err
+		}
+		settings[name] = strconv.Itoa(value)
+	}
+
+	maxSpecSize, err := common.GetSSPMaxSpecSize()
+	if err != nil {
+		return nil, err
+	}
+	settings[common.SSPMaxSpecSizeKey] = strconv.FormatInt(maxSpecSize, 10)
+
+	requireDigest, err := common.GetRequireImageDigest()
+	if err != nil {
+		return nil, err
+	}
+	settings[common.RequireImageDigestKey] = strconv.FormatBool(requireDigest)
+
+	maxStorage, err := common.GetMaxDataImportCronStorage()
+	if err != nil {
+		return nil, err
+	}
+	settings[common.MaxDataImportCronStorageKey] = ""
+	if maxStorage != nil {
+		settings[common.MaxDataImportCronStorageKey] = maxStorage.String()
+	}
+
+	allowedPorts, err := common.GetInstancetypeURLAllowedPorts()
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]string, 0, len(allowedPorts))
+	for _, portRange := range allowedPorts {
+		ports = append(ports, portRange.String())
+	}
+	settings[common.InstancetypeURLAllowedPortsKey] = strings.Join(ports, ",")
+
+	// The policy is validated, but reported as configured, without the anchors added when it is compiled
+	if _, err := common.GetInstancetypeURLPolicy(); err != nil {
+		return nil, err
+	}
+	settings[common.InstancetypeURLPolicyKey] = os.Getenv(common.InstancetypeURLPolicyKey)
+
+	return settings, nil
+}
+
+func newConfigMap(namespace string, data map[string]string) *core.ConfigMap {
+	return &core.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: namespace,
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,195 @@
+package operator_config
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+	"kubevirt.io/ssp-operator/internal/operands"
+	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
+)
+
+var log = logf.Log.WithName("operator-config operand")
+
+var _ = Describe("Operator config operand", func() {
+	const (
+		namespace = "kubevirt"
+		name      = "test-ssp"
+
+		operatorVersion = "v0.0.1-test"
+	)
+
+	var (
+		runtimeFlags map[string]string
+
+		operand operands.Operand
+		request common.Request
+	)
+
+	BeforeEach(func() {
+		Expect(os.Setenv(common.OperatorVersionKey, operatorVersion)).To(Succeed())
+
+		runtimeFlags = map[string]string{
+			"leader-elect":         "true",
+			"metrics-bind-address": ":8443",
+		}
+		operand = New(runtimeFlags)
+
+		client := fake.NewClientBuilder().WithScheme(common.Scheme).Build()
+		request = common.Request{
+			Request: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: namespace,
+					Name:      name,
+				},
+			},
+			Client:  client,
+			Context: context.Background(),
+			Instance: &ssp.SSP{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "SSP",
+					APIVersion: ssp.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+			},
+			Logger:       log,
+			VersionCache: common.VersionCache{},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.Unsetenv(common.OperatorVersionKey)).To(Succeed())
+	})
+
+	getConfigMap := func() *core.ConfigMap {
+		configMap := &core.ConfigMap{}
+		key := client.ObjectKey{Name: ConfigMapName, Namespace: namespace}
+		ExpectWithOffset(1, request.Client.Get(request.Context, key, configMap)).To(Succeed())
+		return configMap
+	}
+
+	It("should create ConfigMap matching the runtime config", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(getConfigMap().Data).To(Equal(map[string]string{
+			"flag.leader-elect":                      "true",
+			"flag.metrics-bind-address":              ":8443",
+			"feature-gate.deployTektonTaskResources": "false",
+			"feature-gate.exportCommonInstancetypes": "false",
+			"version.operator":                       operatorVersion,
+			"version.common-templates":               common_templates.Version,
+			"version.tekton-tasks":                   common.TektonTasksVersion,
+
+			"env.DATA_IMPORT_CRON_MIN_INTERVAL":            "1h0m0s",
+			"env.DATA_IMPORT_CRON_ACTIVE_REQUEUE_INTERVAL": "10s",
+			"env.DATA_IMPORT_CRON_STEADY_REQUEUE_INTERVAL": "10m0s",
+			"env.DATA_IMPORT_CRON_STARTUP_DELAY":           "0s",
+			"env.DATA_IMPORT_CRON_MAX_BACKOFF":             "5m0s",
+			"env.DEGRADED_GRACE_PERIOD":                    "0s",
+			"env.MAX_ADDITIONAL_NAMESPACES":                "20",
+			"env.MAX_DATA_IMPORT_CRON_CREATIONS":           "0",
+			"env.MAX_CONCURRENT_DATA_IMPORTS":              "0",
+			"env.SSP_MAX_SPEC_SIZE":                        "1048576",
+			"env.REQUIRE_IMAGE_DIGEST":                     "false",
+			"env.MAX_DATA_IMPORT_CRON_STORAGE":             "",
+			"env.INSTANCETYPE_URL_ALLOWED_PORTS":           "",
+			"env.INSTANCETYPE_URL_POLICY":                  "",
+		}))
+	})
+
+	It("should label ConfigMap with the configuration component", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getConfigMap().Labels).To(HaveKeyWithValue(common.AppKubernetesComponentLabel, common.AppComponentConfiguration.String()))
+	})
+
+	Context("with settings from environment variables", func() {
+		settings := map[string]string{
+			common.DataImportCronActiveRequeueIntervalKey: "30s",
+			common.MaxDataImportCronCreationsKey:          "5",
+			common.DegradedGracePeriodKey:                 "2m",
+			common.InstancetypeURLAllowedPortsKey:         "22, 8443-8445",
+			common.InstancetypeURLPolicyKey:               "https://github.com/.*",
+		}
+
+		BeforeEach(func() {
+			for name, value := range settings {
+				Expect(os.Setenv(name, value)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			for name := range settings {
+				Expect(os.Unsetenv(name)).To(Succeed())
+			}
+		})
+
+		It("should report effective values of the settings", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			data := getConfigMap().Data
+			Expect(data).To(HaveKeyWithValue("env.DATA_IMPORT_CRON_ACTIVE_REQUEUE_INTERVAL", "30s"))
+			Expect(data).To(HaveKeyWithValue("env.MAX_DATA_IMPORT_CRON_CREATIONS", "5"))
+			Expect(data).To(HaveKeyWithValue("env.DEGRADED_GRACE_PERIOD", "2m0s"))
+			Expect(data).To(HaveKeyWithValue("env.INSTANCETYPE_URL_ALLOWED_PORTS", "22,8443-8445"))
+			Expect(data).To(HaveKeyWithValue("env.INSTANCETYPE_URL_POLICY", "https://github.com/.*"))
+		})
+
+		It("should fail on invalid setting", func() {
+			Expect(os.Setenv(common.MaxDataImportCronCreationsKey, "many")).To(Succeed())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).To(MatchError(ContainSubstring(common.MaxDataImportCronCreationsKey)))
+		})
+	})
+
+	It("should update ConfigMap when feature gates change", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getConfigMap().Data).To(HaveKeyWithValue("feature-gate.deployTektonTaskResources", "false"))
+
+		request.Instance.Spec.FeatureGates = &ssp.FeatureGates{
+			DeployTektonTaskResources: true,
+		}
+
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getConfigMap().Data).To(HaveKeyWithValue("feature-gate.deployTektonTaskResources", "true"))
+	})
+
+	It("should restore ConfigMap content when modified", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap := getConfigMap()
+		expectedData := configMap.Data
+		configMap.Data = map[string]string{"flag.leader-elect": "false"}
+		Expect(request.Client.Update(request.Context, configMap)).To(Succeed())
+
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getConfigMap().Data).To(Equal(expectedData))
+	})
+})
+
+func TestOperatorConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Operator Config Suite")
+}
@@ -167,7 +167,7 @@ func reconcileTektonPipelinesFuncs(pipelines []pipeline.Pipeline) []common.Recon
 						if strings.HasPrefix(param.Name, "virtioContainer") {
 							foundPipeline.Spec.Params[i].Default = &pipeline.ParamValue{
 								Type:      pipeline.ParamTypeString,
-								StringVal: common.GetVirtioImage(),
+								StringVal: request.ComponentImage(common.GetVirtioImage()),
 							}
 						}
 					}
@@ -239,14 +239,14 @@ func reconcileTektonTasksFuncs(tasks []pipeline.Task) []common.ReconcileFunc {
 			if task.Name == modifyWindowsVMIsoFileName {
 				for i, step := range task.Spec.Steps {
 					if step.Name == "create-iso-file" {
-						task.Spec.Steps[i].Image = AllowedTasks[modifyDataObjectTaskName]()
+						task.Spec.Steps[i].Image = request.ComponentImage(AllowedTasks[modifyDataObjectTaskName]())
 					}
 					if step.Name == "convert-iso-file" || step.Name == "modify-iso-file" {
-						task.Spec.Steps[i].Image = AllowedTasks[diskVirtCustomizeTaskName]()
+						task.Spec.Steps[i].Image = request.ComponentImage(AllowedTasks[diskVirtCustomizeTaskName]())
 					}
 				}
 			} else {
-				task.Spec.Steps[0].Image = AllowedTasks[task.Name]()
+				task.Spec.Steps[0].Image = request.ComponentImage(AllowedTasks[task.Name]())
 			}
 			task.Labels[TektonTasksVersionLabel] = common.TektonTasksVersion
 			return common.CreateOrUpdate(request).
@@ -20,6 +20,7 @@ import (
 	"kubevirt.io/ssp-operator/internal/operands"
 	tektonbundle "kubevirt.io/ssp-operator/internal/tekton-bundle"
 	. "kubevirt.io/ssp-operator/internal/test-utils"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -80,6 +81,22 @@ var _ = Describe("environments", func() {
 			}
 		})
 
+		It("should override image registry of tasks", func() {
+			request.Instance.Spec.ImageRegistryOverride = "mirror.example.com"
+
+			_, err := operand.Reconcile(request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, task := range bundle.Tasks {
+				foundTask := &pipeline.Task{}
+				Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(&task), foundTask)).To(Succeed())
+				for _, step := range foundTask.Spec.Steps {
+					Expect(step.Image).To(Equal(common.OverrideImageRegistry(AllowedTasks[task.Name](), "mirror.example.com")))
+					Expect(step.Image).To(HavePrefix("mirror.example.com/"))
+				}
+			}
+		})
+
 		It("should remove tekton-tasks resources on cleanup", func() {
 			_, err := operand.Reconcile(request)
 			Expect(err).ToNot(HaveOccurred())
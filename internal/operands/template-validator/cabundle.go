@@ -0,0 +1,133 @@
+package template_validator
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	admission "k8s.io/api/admissionregistration/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+// ConditionCABundleValid is the SSP condition reporting if the caBundle of the template validator
+// webhook configuration can verify the serving certificate of the template validator.
+const ConditionCABundleValid conditionsv1.ConditionType = "TemplateValidatorCABundleValid"
+
+// servingCert is the serving certificate of the template validator with the CA certificates that issued it
+type servingCert struct {
+	leaf          *x509.Certificate
+	intermediates *x509.CertPool
+	caBundle      []byte
+}
+
+// getServingCert reads the serving certificate from the Secret mounted to the template validator pods.
+// The CA certificates are taken from the ca.crt key if it exists, or from the certificates following
+// the leaf certificate in tls.crt. Nil is returned if the Secret does not exist or contains no CA certificate.
+func getServingCert(request *common.Request) (*servingCert, error) {
+	secret := &v1.Secret{}
+	err := request.UncachedReader.Get(request.Context, client.ObjectKey{Namespace: request.Namespace, Name: SecretName}, secret)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := parseCertificates(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s in Secret %s: %w", v1.TLSCertKey, SecretName, err)
+	}
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	cert := &servingCert{
+		leaf:          chain[0],
+		intermediates: x509.NewCertPool(),
+	}
+	for _, c := range chain[1:] {
+		cert.intermediates.AddCert(c)
+	}
+
+	caCerts := chain[1:]
+	if caData, ok := secret.Data["ca.crt"]; ok {
+		caCerts, err = parseCertificates(caData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ca.crt in Secret %s: %w", SecretName, err)
+		}
+	}
+	for _, c := range caCerts {
+		if c.IsCA {
+			cert.caBundle = append(cert.caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+		}
+	}
+	if len(cert.caBundle) == 0 {
+		return nil, nil
+	}
+	return cert, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// verifiedBy returns true if the serving certificate is issued by one of the certificates in the caBundle
+func (s *servingCert) verifiedBy(caBundle []byte) bool {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		return false
+	}
+	_, err := s.leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: s.intermediates,
+		// Expiration is not a caBundle mismatch, it is handled by certificate rotation
+		CurrentTime: s.leaf.NotBefore,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// repairCaBundles replaces caBundles, that cannot verify the serving certificate, by the CA certificates
+// of the serving certificate. It returns names of the repaired webhooks.
+// Empty caBundles are not repaired, because they are injected later.
+func (s *servingCert) repairCaBundles(webhooks []admission.ValidatingWebhook) []string {
+	var repaired []string
+	for i := range webhooks {
+		clientConfig := &webhooks[i].ClientConfig
+		if len(clientConfig.CABundle) == 0 || s.verifiedBy(clientConfig.CABundle) {
+			continue
+		}
+		clientConfig.CABundle = append([]byte(nil), s.caBundle...)
+		repaired = append(repaired, webhooks[i].Name)
+	}
+	return repaired
+}
+
+func setCABundleValidCondition(request *common.Request, status v1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&request.Instance.Status.Conditions, conditionsv1.Condition{
+		Type:    ConditionCABundleValid,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
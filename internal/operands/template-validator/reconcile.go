@@ -1,10 +1,19 @@
 package template_validator
 
 import (
+	"fmt"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	admission "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/pointer"
 	ssp "kubevirt.io/ssp-operator/api/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -17,16 +26,23 @@ import (
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
 
 // RBAC for created roles
 // +kubebuilder:rbac:groups=template.openshift.io,resources=templates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kubevirt.io,resources=virtualmachines,verbs=get;list;watch
 
+func init() {
+	utilruntime.Must(routev1.Install(common.Scheme))
+}
+
 func WatchTypes() []operands.WatchType {
 	return []operands.WatchType{
 		{Object: &v1.ServiceAccount{}},
 		{Object: &v1.Service{}},
 		{Object: &apps.Deployment{}, WatchFullObject: true},
+		{Object: &routev1.Route{}},
 	}
 }
 
@@ -53,12 +69,22 @@ func (t *templateValidator) WatchClusterTypes() []operands.WatchType {
 }
 
 func (t *templateValidator) Reconcile(request *common.Request) ([]common.ReconcileResult, error) {
+	if !request.CrdList.CrdExists(VirtualMachineCrd) {
+		request.Logger.V(1).Info(fmt.Sprintf("Template validator is not deployed, because CRD %s does not exist", VirtualMachineCrd))
+		setDeployedCondition(request, v1.ConditionFalse, "KubeVirtNotInstalled",
+			fmt.Sprintf("Template validator is not deployed, because KubeVirt CRD %s does not exist", VirtualMachineCrd))
+		// The validator may have been deployed before KubeVirt was removed
+		return removeValidator(request)
+	}
+
+	setDeployedCondition(request, v1.ConditionTrue, "Deployed", "Template validator is deployed")
 	return common.CollectResourceStatus(request,
 		reconcileClusterRole,
 		reconcileServiceAccount,
 		reconcileClusterRoleBinding,
 		reconcileService,
 		reconcilePrometheusService,
+		reconcileMetricsRoute,
 		reconcileDeployment,
 		reconcileValidatingWebhook,
 	)
@@ -72,6 +98,71 @@ func (t *templateValidator) Cleanup(request *common.Request) ([]common.CleanupRe
 	)
 }
 
+// removeValidator removes the webhook configuration and the deployment gracefully.
+// The webhook configuration is removed first, because its failure policy is Fail
+// and admission requests would be rejected while no validator pod is running.
+// Then the deployment is scaled down, so the validator pods can finish in-flight
+// admission requests, and it is removed only after all pods terminated.
+func removeValidator(request *common.Request) ([]common.ReconcileResult, error) {
+	webhookResults, err := common.DeleteAll(request, newValidatingWebhook(request.Namespace))
+	if err != nil {
+		return nil, err
+	}
+	if !webhookResults[0].Deleted {
+		message := "Waiting for template validator webhook configuration to be removed"
+		return []common.ReconcileResult{{
+			Status: common.ResourceStatus{
+				Progressing: &message,
+			},
+			Resource: webhookResults[0].Resource,
+		}}, nil
+	}
+
+	drained, err := drainDeployment(request)
+	if err != nil {
+		return nil, err
+	}
+	if !drained {
+		message := "Waiting for template validator pods to terminate"
+		return []common.ReconcileResult{{
+			Status: common.ResourceStatus{
+				Progressing: &message,
+			},
+			Resource: newDeploymentMeta(request.Namespace),
+		}}, nil
+	}
+
+	if _, err := common.DeleteAll(request, newDeploymentMeta(request.Namespace)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// drainDeployment scales the validator deployment down and returns true when no pods are running
+func drainDeployment(request *common.Request) (bool, error) {
+	deployment := &apps.Deployment{}
+	err := request.Client.Get(request.Context, client.ObjectKeyFromObject(newDeploymentMeta(request.Namespace)), deployment)
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !metav1.IsControlledBy(deployment, request.Instance) {
+		// The deployment is not owned by this SSP, so it will not be deleted
+		return true, nil
+	}
+
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		request.Logger.Info("Scaling down template validator deployment before removing it")
+		deployment.Spec.Replicas = pointer.Int32(0)
+		if err := request.Client.Update(request.Context, deployment); err != nil {
+			return false, err
+		}
+	}
+	return deployment.Status.Replicas == 0, nil
+}
+
 var _ operands.Operand = &templateValidator{}
 
 func New() operands.Operand {
@@ -83,6 +174,24 @@ const (
 	operandComponent = common.AppComponentTemplating
 )
 
+const (
+	// VirtualMachineCrd is the CRD, whose existence shows that KubeVirt is installed.
+	// The template validator is deployed only when it exists.
+	VirtualMachineCrd = "virtualmachines.kubevirt.io"
+
+	// ConditionDeployed is the SSP condition reporting if the template validator is deployed
+	ConditionDeployed conditionsv1.ConditionType = "TemplateValidatorDeployed"
+)
+
+func setDeployedCondition(request *common.Request, status v1.ConditionStatus, reason, message string) {
+	conditionsv1.SetStatusCondition(&request.Instance.Status.Conditions, conditionsv1.Condition{
+		Type:    ConditionDeployed,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
 func reconcileClusterRole(request *common.Request) (common.ReconcileResult, error) {
 	return common.CreateOrUpdate(request).
 		ClusterResource(newClusterRole()).
@@ -118,13 +227,47 @@ func reconcilePrometheusService(request *common.Request) (common.ReconcileResult
 		Reconcile()
 }
 
+func reconcileMetricsRoute(request *common.Request) (common.ReconcileResult, error) {
+	validatorSpec := request.Instance.Spec.TemplateValidator
+	if validatorSpec == nil || validatorSpec.MetricsRoute == nil {
+		route := newMetricsRoute(request.Namespace, "")
+		err := request.Client.Delete(request.Context, route)
+		if err != nil && !errors.IsNotFound(err) {
+			request.Logger.Error(err, fmt.Sprintf("Error deleting \"%s\": %s", route.GetName(), err))
+			return common.ReconcileResult{}, err
+		}
+		return common.ReconcileResult{
+			Resource: route,
+		}, nil
+	}
+
+	return common.CreateOrUpdate(request).
+		NamespacedResource(newMetricsRoute(request.Namespace, validatorSpec.MetricsRoute.Host)).
+		WithAppLabels(operandName, operandComponent).
+		UpdateFunc(func(newRes, foundRes client.Object) {
+			newRoute := newRes.(*routev1.Route)
+			foundRoute := foundRes.(*routev1.Route)
+			// Keep the host generated by the cluster
+			host := foundRoute.Spec.Host
+			foundRoute.Spec = newRoute.Spec
+			if foundRoute.Spec.Host == "" {
+				foundRoute.Spec.Host = host
+			}
+		}).
+		Reconcile()
+}
+
 func reconcileDeployment(request *common.Request) (common.ReconcileResult, error) {
 	image := getTemplateValidatorImage()
 	if image == "" {
 		panic("Cannot reconcile without valid image name")
 	}
-	numberOfReplicas := int32(1)
+	image = request.ComponentImage(image)
 	validatorSpec := request.Instance.Spec.TemplateValidator
+	if validatorSpec != nil && validatorSpec.Image != "" {
+		image = validatorSpec.Image
+	}
+	numberOfReplicas := int32(1)
 	if validatorSpec != nil && validatorSpec.Replicas != nil {
 		numberOfReplicas = *validatorSpec.Replicas
 		if request.IsSingleReplicaTopologyMode() && (numberOfReplicas > 1) {
@@ -139,12 +282,62 @@ func reconcileDeployment(request *common.Request) (common.ReconcileResult, error
 
 	deployment := newDeployment(request.Namespace, numberOfReplicas, image, sspTLSOptions)
 	injectPlacementMetadata(&deployment.Spec.Template.Spec, validatorSpec)
+	injectSidecars(&deployment.Spec.Template.Spec, validatorSpec)
+	if priorityClassName := request.Instance.Spec.PriorityClassName; priorityClassName != "" {
+		deployment.Spec.Template.Spec.PriorityClassName = priorityClassName
+	}
+	injectRestartTrigger(&deployment.Spec.Template, request.Instance)
 	return common.CreateOrUpdate(request).
 		NamespacedResource(deployment).
 		WithAppLabels(operandName, operandComponent).
+		StatusFunc(deploymentStatus).
 		Reconcile()
 }
 
+// deploymentStatus reports the status of the template validator deployment,
+// with messages containing the ready and desired number of replicas.
+func deploymentStatus(resource client.Object) common.ResourceStatus {
+	deployment := resource.(*apps.Deployment)
+	desiredReplicas := pointer.Int32Deref(deployment.Spec.Replicas, 1)
+	readyReplicas := deployment.Status.AvailableReplicas
+
+	status := common.ResourceStatus{}
+	if readyReplicas == desiredReplicas {
+		return status
+	}
+
+	msg := fmt.Sprintf("Template validator is not ready, ready replicas: %d, desired replicas: %d",
+		readyReplicas, desiredReplicas)
+	if desiredReplicas > 0 && readyReplicas == 0 {
+		status.NotAvailable = &msg
+	}
+	status.Progressing = &msg
+	status.Degraded = &msg
+	return status
+}
+
+func injectSidecars(podSpec *v1.PodSpec, componentConfig *ssp.TemplateValidator) {
+	if componentConfig == nil {
+		return
+	}
+	for i := range componentConfig.Sidecars {
+		podSpec.Containers = append(podSpec.Containers, *componentConfig.Sidecars[i].DeepCopy())
+	}
+}
+
+// injectRestartTrigger copies the restart annotation from the SSP CR to the pod template,
+// so a change of its value rolls out new pods.
+func injectRestartTrigger(podTemplate *v1.PodTemplateSpec, sspObj *ssp.SSP) {
+	restartValue, ok := sspObj.GetAnnotations()[ssp.TemplateValidatorRestartAnnotation]
+	if !ok {
+		return
+	}
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[restartTriggerAnnotation] = restartValue
+}
+
 // Merge all Tolerations, Affinity and NodeSelectors from NodePlacement into pod spec
 func injectPlacementMetadata(podSpec *v1.PodSpec, componentConfig *ssp.TemplateValidator) {
 	if componentConfig == nil || componentConfig.Placement == nil {
@@ -217,8 +410,28 @@ func mergePodAntiAffinity(currentAffinity *v1.Affinity, configPodAntiAffinity *v
 }
 
 func reconcileValidatingWebhook(request *common.Request) (common.ReconcileResult, error) {
-	return common.CreateOrUpdate(request).
-		ClusterResource(newValidatingWebhook(request.Namespace)).
+	webhookConf := newValidatingWebhook(request.Namespace)
+	if validatorSpec := request.Instance.Spec.TemplateValidator; validatorSpec != nil && validatorSpec.MatchPolicy != nil {
+		matchPolicy := admission.MatchPolicyType(*validatorSpec.MatchPolicy)
+		for i := range webhookConf.Webhooks {
+			webhookConf.Webhooks[i].MatchPolicy = &matchPolicy
+		}
+	}
+	if validatorSpec := request.Instance.Spec.TemplateValidator; validatorSpec != nil && validatorSpec.SideEffects != nil {
+		sideEffects := admission.SideEffectClass(*validatorSpec.SideEffects)
+		for i := range webhookConf.Webhooks {
+			webhookConf.Webhooks[i].SideEffects = &sideEffects
+		}
+	}
+
+	cert, err := getServingCert(request)
+	if err != nil {
+		return common.ReconcileResult{}, err
+	}
+
+	var repairedWebhooks []string
+	result, err := common.CreateOrUpdate(request).
+		ClusterResource(webhookConf).
 		WithAppLabels(operandName, operandComponent).
 		UpdateFunc(func(newRes, foundRes client.Object) {
 			newWebhookConf := newRes.(*admission.ValidatingWebhookConfiguration)
@@ -228,9 +441,32 @@ func reconcileValidatingWebhook(request *common.Request) (common.ReconcileResult
 			// so it will not be overwritten
 			copyFoundCaBundles(newWebhookConf.Webhooks, foundWebhookConf.Webhooks)
 
+			// A caBundle not matching the serving certificate makes all admission requests fail
+			if cert != nil {
+				repairedWebhooks = cert.repairCaBundles(newWebhookConf.Webhooks)
+			}
+
 			foundWebhookConf.Webhooks = newWebhookConf.Webhooks
 		}).
+		Options(common.ReconcileOptions{AlwaysCallUpdateFunc: true}).
 		Reconcile()
+	if err != nil {
+		return common.ReconcileResult{}, err
+	}
+
+	switch {
+	case cert == nil:
+		conditionsv1.RemoveStatusCondition(&request.Instance.Status.Conditions, ConditionCABundleValid)
+	case len(repairedWebhooks) > 0:
+		request.Logger.Info(fmt.Sprintf("Repaired caBundle of webhooks not matching the serving certificate: %s",
+			strings.Join(repairedWebhooks, ", ")))
+		setCABundleValidCondition(request, v1.ConditionFalse, "Repaired",
+			fmt.Sprintf("The caBundle of webhooks %s did not match the serving certificate and was replaced",
+				strings.Join(repairedWebhooks, ", ")))
+	default:
+		setCABundleValidCondition(request, v1.ConditionTrue, "Valid", "No caBundle mismatching the serving certificate was found")
+	}
+	return result, nil
 }
 
 func copyFoundCaBundles(newWebhooks []admission.ValidatingWebhook, foundWebhooks []admission.ValidatingWebhook) {
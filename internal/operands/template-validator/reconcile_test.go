@@ -2,12 +2,23 @@ package template_validator
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
 	lifecycleapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	admission "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
@@ -43,6 +54,7 @@ var _ = Describe("Template validator operand", func() {
 	BeforeEach(func() {
 		s := scheme.Scheme
 		Expect(ssp.AddToScheme(s)).ToNot(HaveOccurred())
+		Expect(routev1.Install(s)).ToNot(HaveOccurred())
 
 		client := fake.NewClientBuilder().WithScheme(s).Build()
 		request = common.Request{
@@ -52,8 +64,9 @@ var _ = Describe("Template validator operand", func() {
 					Name:      name,
 				},
 			},
-			Client:  client,
-			Context: context.Background(),
+			Client:         client,
+			UncachedReader: client,
+			Context:        context.Background(),
 			Instance: &ssp.SSP{
 				TypeMeta: meta.TypeMeta{
 					Kind:       "SSP",
@@ -74,9 +87,137 @@ var _ = Describe("Template validator operand", func() {
 			},
 			Logger:       log,
 			VersionCache: common.VersionCache{},
+			CrdList:      fakeCrdList{VirtualMachineCrd: {}},
 		}
 	})
 
+	It("should set deployed condition when KubeVirt is installed", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionDeployed)
+		Expect(condition).ToNot(BeNil())
+		Expect(condition.Status).To(Equal(core.ConditionTrue))
+	})
+
+	Context("without KubeVirt", func() {
+		BeforeEach(func() {
+			request.CrdList = fakeCrdList{}
+		})
+
+		It("should not create validator resources", func() {
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			ExpectResourceNotExists(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig), request)
+			ExpectResourceNotExists(newValidatingWebhook(namespace), request)
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionDeployed)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(core.ConditionFalse))
+			Expect(condition.Reason).To(Equal("KubeVirtNotInstalled"))
+			Expect(condition.Message).To(ContainSubstring(VirtualMachineCrd))
+		})
+
+		It("should remove validator when KubeVirt is removed", func() {
+			request.CrdList = fakeCrdList{VirtualMachineCrd: {}}
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig), request)
+			ExpectResourceExists(newValidatingWebhook(namespace), request)
+
+			request.CrdList = fakeCrdList{}
+			// The first reconciliation removes the webhook configuration, the second one the deployment
+			for i := 0; i < 2; i++ {
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			ExpectResourceNotExists(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig), request)
+			ExpectResourceNotExists(newValidatingWebhook(namespace), request)
+		})
+
+		It("should remove validator resources in order", func() {
+			request.CrdList = fakeCrdList{VirtualMachineCrd: {}}
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			var operations []string
+			request.Client = recordingClient{Client: request.Client, operations: &operations}
+			request.CrdList = fakeCrdList{}
+			for i := 0; i < 2; i++ {
+				_, err = operand.Reconcile(&request)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(operations).To(Equal([]string{
+				"delete *v1.ValidatingWebhookConfiguration",
+				"update *v1.Deployment",
+				"delete *v1.Deployment",
+			}))
+		})
+
+		It("should remove webhook before waiting for validator pods to terminate", func() {
+			request.CrdList = fakeCrdList{VirtualMachineCrd: {}}
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			key := client.ObjectKeyFromObject(newDeploymentMeta(namespace))
+			updateDeployment(key, &request, func(deployment *apps.Deployment) {
+				deployment.Status.Replicas = replicas
+			})
+
+			request.CrdList = fakeCrdList{}
+			results, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status.Progressing).To(HaveValue(ContainSubstring("webhook configuration")))
+
+			deployment := &apps.Deployment{}
+			Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).To(HaveValue(Equal(replicas)))
+			ExpectResourceNotExists(newValidatingWebhook(namespace), request)
+
+			results, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Status.Progressing).To(HaveValue(ContainSubstring("pods to terminate")))
+
+			Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).To(HaveValue(BeZero()))
+
+			updateDeployment(key, &request, func(deployment *apps.Deployment) {
+				deployment.Status.Replicas = 0
+			})
+
+			results, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			ExpectResourceNotExists(newValidatingWebhook(namespace), request)
+			ExpectResourceNotExists(newDeploymentMeta(namespace), request)
+		})
+
+		It("should deploy validator when KubeVirt is installed", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceNotExists(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig), request)
+
+			request.CrdList = fakeCrdList{VirtualMachineCrd: {}}
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceExists(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig), request)
+			ExpectResourceExists(newValidatingWebhook(namespace), request)
+
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionDeployed)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(core.ConditionTrue))
+		})
+	})
+
 	It("should create validator resources", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -110,6 +251,364 @@ var _ = Describe("Template validator operand", func() {
 		Expect(updatedWebhook.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte(testCaBundle)))
 	})
 
+	DescribeTable("should set webhook matchPolicy", func(matchPolicy admission.MatchPolicyType) {
+		request.Instance.Spec.TemplateValidator.MatchPolicy = pointer.String(string(matchPolicy))
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+		webhook := &admission.ValidatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+
+		Expect(webhook.Webhooks).ToNot(BeEmpty())
+		for _, wh := range webhook.Webhooks {
+			Expect(wh.MatchPolicy).To(HaveValue(Equal(matchPolicy)))
+		}
+	},
+		Entry("Exact", admission.Exact),
+		Entry("Equivalent", admission.Equivalent),
+	)
+
+	It("should not set webhook matchPolicy by default", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+		webhook := &admission.ValidatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+
+		for _, wh := range webhook.Webhooks {
+			Expect(wh.MatchPolicy).To(BeNil())
+		}
+	})
+
+	DescribeTable("should set webhook sideEffects", func(sideEffects *string, expected admission.SideEffectClass) {
+		request.Instance.Spec.TemplateValidator.SideEffects = sideEffects
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+		webhook := &admission.ValidatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+
+		Expect(webhook.Webhooks).ToNot(BeEmpty())
+		for _, wh := range webhook.Webhooks {
+			Expect(wh.SideEffects).To(HaveValue(Equal(expected)))
+		}
+	},
+		Entry("None by default", nil, admission.SideEffectClassNone),
+		Entry("None", pointer.String(string(admission.SideEffectClassNone)), admission.SideEffectClassNone),
+		Entry("NoneOnDryRun", pointer.String(string(admission.SideEffectClassNoneOnDryRun)), admission.SideEffectClassNoneOnDryRun),
+	)
+
+	It("should update webhook sideEffects when it changes", func() {
+		request.Instance.Spec.TemplateValidator.SideEffects = pointer.String(string(admission.SideEffectClassNoneOnDryRun))
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		request.Instance.Spec.TemplateValidator.SideEffects = nil
+		_, err = operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+		webhook := &admission.ValidatingWebhookConfiguration{}
+		Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+		for _, wh := range webhook.Webhooks {
+			Expect(wh.SideEffects).To(HaveValue(Equal(admission.SideEffectClassNone)))
+		}
+	})
+
+	It("should add sidecars to deployment", func() {
+		sidecars := []core.Container{{
+			Name:  "logging",
+			Image: "logging-image",
+		}, {
+			Name:  "metrics",
+			Image: "metrics-image",
+		}}
+		request.Instance.Spec.TemplateValidator.Sidecars = sidecars
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := &apps.Deployment{}
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig))
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		containers := deployment.Spec.Template.Spec.Containers
+		Expect(containers).To(HaveLen(3))
+		Expect(containers[0].Name).To(Equal(ContainerName))
+		Expect(containers[1:]).To(Equal(sidecars))
+	})
+
+	It("should add common metadata to validator resources", func() {
+		request.Instance.Spec.CommonMetadata = &ssp.CommonMetadata{
+			Labels:      map[string]string{"example.com/cost-center": "1234"},
+			Annotations: map[string]string{"example.com/owner": "platform-team"},
+		}
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, obj := range []client.Object{
+			newDeploymentMeta(namespace),
+			newService(namespace),
+			newValidatingWebhook(namespace),
+		} {
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+			Expect(obj.GetLabels()).To(HaveKeyWithValue("example.com/cost-center", "1234"))
+			Expect(obj.GetLabels()).To(HaveKeyWithValue(common.AppKubernetesNameLabel, operandName))
+			Expect(obj.GetAnnotations()).To(HaveKeyWithValue("example.com/owner", "platform-team"))
+		}
+	})
+
+	It("should override image registry", func() {
+		request.Instance.Spec.ImageRegistryOverride = "mirror.example.com:5000/kubevirt"
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := &apps.Deployment{}
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig))
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(
+			common.OverrideImageRegistry(getTemplateValidatorImage(), "mirror.example.com:5000/kubevirt")))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(HavePrefix("mirror.example.com:5000/kubevirt/"))
+	})
+
+	It("should use image from spec", func() {
+		const image = "registry.example.com/kubevirt/kubevirt-template-validator:v1.0.0"
+		request.Instance.Spec.TemplateValidator.Image = image
+		request.Instance.Spec.ImageRegistryOverride = "mirror.example.com:5000/kubevirt"
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := &apps.Deployment{}
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig))
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(image))
+	})
+
+	It("should use default image when image in spec is empty", func() {
+		request.Instance.Spec.TemplateValidator.Image = ""
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := &apps.Deployment{}
+		key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig))
+		Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal(getTemplateValidatorImage()))
+	})
+
+	It("should roll out validator pods when restart annotation changes", func() {
+		getPodTemplateAnnotations := func() map[string]string {
+			deployment := &apps.Deployment{}
+			key := client.ObjectKeyFromObject(newDeployment(namespace, replicas, "test-img", emptySSPTLSConfig))
+			Expect(request.Client.Get(request.Context, key, deployment)).To(Succeed())
+			return deployment.Spec.Template.Annotations
+		}
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getPodTemplateAnnotations()).ToNot(HaveKey(restartTriggerAnnotation))
+
+		for _, restartValue := range []string{"first", "second"} {
+			request.Instance.Annotations = map[string]string{
+				ssp.TemplateValidatorRestartAnnotation: restartValue,
+			}
+			// The SSP controller clears the cache when the annotation changes
+			request.VersionCache = common.VersionCache{}
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getPodTemplateAnnotations()).To(HaveKeyWithValue(restartTriggerAnnotation, restartValue))
+		}
+	})
+
+	Context("webhook caBundle", func() {
+		var (
+			validCA   *testCertificate
+			otherCA   *testCertificate
+			webhookCA []byte
+		)
+
+		setWebhookCaBundle := func(caBundle []byte) {
+			key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+			webhook := &admission.ValidatingWebhookConfiguration{}
+			Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+			for i := range webhook.Webhooks {
+				webhook.Webhooks[i].ClientConfig.CABundle = caBundle
+			}
+			Expect(request.Client.Update(request.Context, webhook)).To(Succeed())
+		}
+
+		getWebhookCaBundles := func() [][]byte {
+			key := client.ObjectKeyFromObject(newValidatingWebhook(namespace))
+			webhook := &admission.ValidatingWebhookConfiguration{}
+			Expect(request.Client.Get(request.Context, key, webhook)).To(Succeed())
+			var caBundles [][]byte
+			for _, wh := range webhook.Webhooks {
+				caBundles = append(caBundles, wh.ClientConfig.CABundle)
+			}
+			return caBundles
+		}
+
+		expectCondition := func(status core.ConditionStatus, reason string) {
+			condition := conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCABundleValid)
+			ExpectWithOffset(1, condition).ToNot(BeNil())
+			ExpectWithOffset(1, condition.Status).To(Equal(status))
+			ExpectWithOffset(1, condition.Reason).To(Equal(reason))
+		}
+
+		BeforeEach(func() {
+			validCA = newTestCertificate("valid-ca", nil)
+			otherCA = newTestCertificate("other-ca", nil)
+			servingCert := newTestCertificate("virt-template-validator."+namespace+".svc", validCA)
+
+			// The serving certificate is followed by its CA, like in Secrets created by the service CA operator
+			Expect(request.Client.Create(request.Context, &core.Secret{
+				ObjectMeta: meta.ObjectMeta{
+					Name:      SecretName,
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					core.TLSCertKey: append(servingCert.pem(), validCA.pem()...),
+				},
+			})).To(Succeed())
+			webhookCA = validCA.pem()
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should keep caBundle matching the serving certificate", func() {
+			setWebhookCaBundle(webhookCA)
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(Equal(webhookCA))
+			}
+			expectCondition(core.ConditionTrue, "Valid")
+		})
+
+		It("should accept caBundle containing multiple CAs", func() {
+			setWebhookCaBundle(append(otherCA.pem(), webhookCA...))
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(Equal(append(otherCA.pem(), webhookCA...)))
+			}
+			expectCondition(core.ConditionTrue, "Valid")
+		})
+
+		It("should repair caBundle not matching the serving certificate", func() {
+			setWebhookCaBundle(otherCA.pem())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(Equal(webhookCA))
+			}
+			expectCondition(core.ConditionFalse, "Repaired")
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			expectCondition(core.ConditionTrue, "Valid")
+		})
+
+		It("should repair malformed caBundle", func() {
+			setWebhookCaBundle([]byte("malformed"))
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(Equal(webhookCA))
+			}
+			expectCondition(core.ConditionFalse, "Repaired")
+		})
+
+		It("should not set empty caBundle", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(BeEmpty())
+			}
+		})
+
+		It("should remove condition when serving certificate does not exist", func() {
+			Expect(request.Client.Delete(request.Context, &core.Secret{
+				ObjectMeta: meta.ObjectMeta{
+					Name:      SecretName,
+					Namespace: namespace,
+				},
+			})).To(Succeed())
+			setWebhookCaBundle(otherCA.pem())
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, caBundle := range getWebhookCaBundles() {
+				Expect(caBundle).To(Equal(otherCA.pem()))
+			}
+			Expect(conditionsv1.FindStatusCondition(request.Instance.Status.Conditions, ConditionCABundleValid)).To(BeNil())
+		})
+	})
+
+	Context("metrics Route", func() {
+		It("should not create Route by default", func() {
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpectResourceNotExists(newMetricsRoute(namespace, ""), request)
+		})
+
+		It("should create Route pointing to metrics service", func() {
+			const host = "metrics.example.com"
+			request.Instance.Spec.TemplateValidator.MetricsRoute = &ssp.MetricsRoute{
+				Host: host,
+			}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+
+			route := &routev1.Route{}
+			Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(newMetricsRoute(namespace, "")), route)).To(Succeed())
+			Expect(route.Spec.Host).To(Equal(host))
+			Expect(route.Spec.To.Kind).To(Equal("Service"))
+			Expect(route.Spec.To.Name).To(Equal(MetricsServiceName))
+			Expect(route.OwnerReferences).To(HaveLen(1))
+			Expect(route.OwnerReferences[0].Name).To(Equal(name))
+		})
+
+		It("should remove Route when disabled", func() {
+			request.Instance.Spec.TemplateValidator.MetricsRoute = &ssp.MetricsRoute{}
+
+			_, err := operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceExists(newMetricsRoute(namespace, ""), request)
+
+			request.Instance.Spec.TemplateValidator.MetricsRoute = nil
+
+			_, err = operand.Reconcile(&request)
+			Expect(err).ToNot(HaveOccurred())
+			ExpectResourceNotExists(newMetricsRoute(namespace, ""), request)
+		})
+	})
+
 	It("should not update service cluster IP", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -168,7 +667,8 @@ var _ = Describe("Template validator operand", func() {
 		// Only deployment should be progressing
 		for _, reconcileResult := range reconcileResults {
 			if _, ok := reconcileResult.Resource.(*apps.Deployment); ok {
-				Expect(reconcileResult.Status.NotAvailable).ToNot(BeNil())
+				Expect(reconcileResult.Status.NotAvailable).To(HaveValue(
+					Equal("Template validator is not ready, ready replicas: 0, desired replicas: 2")))
 				Expect(reconcileResult.Status.Progressing).ToNot(BeNil())
 				Expect(reconcileResult.Status.Degraded).ToNot(BeNil())
 			} else {
@@ -197,6 +697,29 @@ var _ = Describe("Template validator operand", func() {
 		}
 	})
 
+	It("should report partially ready deployment as available", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		key := client.ObjectKeyFromObject(newDeploymentMeta(namespace))
+		updateDeployment(key, &request, func(deployment *apps.Deployment) {
+			deployment.Status.Replicas = replicas
+			deployment.Status.ReadyReplicas = 1
+			deployment.Status.AvailableReplicas = 1
+		})
+
+		reconcileResults, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, reconcileResult := range reconcileResults {
+			if _, ok := reconcileResult.Resource.(*apps.Deployment); ok {
+				Expect(reconcileResult.Status.NotAvailable).To(BeNil())
+				Expect(reconcileResult.Status.Progressing).To(HaveValue(
+					Equal("Template validator is not ready, ready replicas: 1, desired replicas: 2")))
+			}
+		}
+	})
+
 	Context("should create correct deployment affinity", func() {
 
 		const kubernetesHostnameTopologyKey = "kubernetes.io/hostname"
@@ -406,6 +929,73 @@ func updateDeployment(key client.ObjectKey, request *common.Request, updateFunc
 	Expect(request.Client.Status().Update(request.Context, deployment)).ToNot(HaveOccurred())
 }
 
+// recordingClient records all update and delete operations
+type recordingClient struct {
+	client.Client
+	operations *[]string
+}
+
+func (r recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	*r.operations = append(*r.operations, fmt.Sprintf("update %T", obj))
+	return r.Client.Update(ctx, obj, opts...)
+}
+
+func (r recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	*r.operations = append(*r.operations, fmt.Sprintf("delete %T", obj))
+	return r.Client.Delete(ctx, obj, opts...)
+}
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates a CA certificate if the issuer is nil, or a serving certificate signed by the issuer
+func newTestCertificate(commonName string, issuer *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+	}
+
+	parent, signer := template, key
+	if issuer == nil {
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{commonName}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		parent, signer = issuer.cert, issuer.key
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDER)
+	Expect(err).ToNot(HaveOccurred())
+	return &testCertificate{cert: cert, key: key}
+}
+
+func (t *testCertificate) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: t.cert.Raw})
+}
+
+type fakeCrdList map[string]struct{}
+
+func (f fakeCrdList) CrdExists(crdName string) bool {
+	_, exists := f[crdName]
+	return exists
+}
+
+func (f fakeCrdList) MissingCrds() []string {
+	return nil
+}
+
 func TestValidator(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Template Validator Suite")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	routev1 "github.com/openshift/api/route/v1"
 	templatev1 "github.com/openshift/api/template/v1"
 	admission "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
@@ -35,9 +36,12 @@ const (
 	ServiceAccountName            = "template-validator"
 	ServiceName                   = VirtTemplateValidator
 	MetricsServiceName            = "template-validator-metrics"
+	MetricsRouteName              = MetricsServiceName
 	DeploymentName                = VirtTemplateValidator
+	ContainerName                 = "webhook"
 	PrometheusLabel               = "prometheus.ssp.kubevirt.io"
 	kubernetesHostnameTopologyKey = "kubernetes.io/hostname"
+	restartTriggerAnnotation      = "ssp.kubevirt.io/restart-trigger"
 )
 
 func CommonLabels() map[string]string {
@@ -46,6 +50,14 @@ func CommonLabels() map[string]string {
 	}
 }
 
+// PodLabels returns the labels of template validator pods
+func PodLabels() map[string]string {
+	podLabels := CommonLabels()
+	podLabels[PrometheusLabel] = "true"
+	podLabels["name"] = DeploymentName
+	return podLabels
+}
+
 func getTemplateValidatorImage() string {
 	return common.EnvOrDefault(common.TemplateValidatorImageKey, defaultTemplateValidatorImage)
 }
@@ -144,15 +156,22 @@ func newPodAntiAffinity(key, topologyKey string, operator metav1.LabelSelectorOp
 	}
 }
 
+func newDeploymentMeta(namespace string) *apps.Deployment {
+	return &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName,
+			Namespace: namespace,
+		},
+	}
+}
+
 func newDeployment(namespace string, replicas int32, image string, sspTLSOptions *common.SSPTLSOptions) *apps.Deployment {
 	const volumeName = "tls"
 	const certMountPath = "/etc/webhook/certs"
 	trueVal := true
 	falseVal := false
 
-	podLabels := CommonLabels()
-	podLabels[PrometheusLabel] = "true"
-	podLabels["name"] = DeploymentName
+	podLabels := PodLabels()
 	podAntiAffinity := newPodAntiAffinity(KubevirtIo, kubernetesHostnameTopologyKey, metav1.LabelSelectorOpIn, []string{VirtTemplateValidator})
 	return &apps.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -182,7 +201,7 @@ func newDeployment(namespace string, replicas int32, image string, sspTLSOptions
 					ServiceAccountName: ServiceAccountName,
 					PriorityClassName:  "system-cluster-critical",
 					Containers: []core.Container{{
-						Name:            "webhook",
+						Name:            ContainerName,
 						Image:           image,
 						ImagePullPolicy: core.PullIfNotPresent,
 						Resources: core.ResourceRequirements{
@@ -351,3 +370,27 @@ func newPrometheusService(namespace string) *core.Service {
 		},
 	}
 }
+
+func newMetricsRoute(namespace string, host string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MetricsRouteName,
+			Namespace: namespace,
+		},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: MetricsServiceName,
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString(metrics.MetricsPortName),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationReencrypt,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			},
+			WildcardPolicy: routev1.WildcardPolicyNone,
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	routev1 "github.com/openshift/api/route/v1"
 	apps "k8s.io/api/apps/v1"
@@ -233,7 +234,7 @@ func reconcileService(service core.Service) common.ReconcileFunc {
 func reconcileDeployment(deployment apps.Deployment) common.ReconcileFunc {
 	return func(request *common.Request) (common.ReconcileResult, error) {
 		deployment.Namespace = getVmConsoleProxyNamespace(request)
-		deployment.Spec.Template.Spec.Containers[0].Image = getVmConsoleProxyImage()
+		deployment.Spec.Template.Spec.Containers[0].Image = request.ComponentImage(getVmConsoleProxyImage())
 		return common.CreateOrUpdate(request).
 			ClusterResource(&deployment).
 			WithAppLabels(operandName, operandComponent).
@@ -246,10 +247,46 @@ func reconcileRoute(serviceName string) common.ReconcileFunc {
 		return common.CreateOrUpdate(request).
 			ClusterResource(newRoute(getVmConsoleProxyNamespace(request), serviceName)).
 			WithAppLabels(operandName, operandComponent).
+			StatusFunc(routeStatus).
 			Reconcile()
 	}
 }
 
+// routeStatus reports the Route as not available until it is admitted by a router.
+// A Route rejected by all routers is also reported as degraded.
+func routeStatus(resource client.Object) common.ResourceStatus {
+	route := resource.(*routev1.Route)
+
+	status := common.ResourceStatus{}
+	var rejectedMessages []string
+	for _, ingress := range route.Status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type != routev1.RouteAdmitted {
+				continue
+			}
+			switch condition.Status {
+			case core.ConditionTrue:
+				return status
+			case core.ConditionFalse:
+				rejectedMessages = append(rejectedMessages,
+					fmt.Sprintf("router %s: %s: %s", ingress.RouterName, condition.Reason, condition.Message))
+			}
+		}
+	}
+
+	if len(rejectedMessages) > 0 {
+		msg := fmt.Sprintf("Route is not admitted, %s", strings.Join(rejectedMessages, ", "))
+		status.NotAvailable = &msg
+		status.Degraded = &msg
+		return status
+	}
+
+	msg := "Route is not yet admitted by a router"
+	status.NotAvailable = &msg
+	status.Progressing = &msg
+	return status
+}
+
 func isEnabled(request *common.Request) bool {
 	if request.Instance.GetAnnotations() == nil {
 		return false
@@ -80,6 +80,17 @@ var _ = Describe("VM Console Proxy Operand", func() {
 		ExpectResourceExists(newRoute(namespace, serviceName), request)
 	})
 
+	It("should override image registry", func() {
+		request.Instance.Spec.ImageRegistryOverride = "mirror.example.com"
+
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		deployment := &apps.Deployment{}
+		Expect(request.Client.Get(request.Context, client.ObjectKeyFromObject(bundle.Deployment), deployment)).To(Succeed())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("mirror.example.com/kubevirt/vm-console-proxy:v0.1.0"))
+	})
+
 	It("should remove cluster resources on cleanup", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -143,13 +154,18 @@ var _ = Describe("VM Console Proxy Operand", func() {
 		reconcileResults, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
 
-		// Only deployment should be progressing
+		// Only deployment and not admitted route should be progressing
 		for _, reconcileResult := range reconcileResults {
-			if _, ok := reconcileResult.Resource.(*apps.Deployment); ok {
+			switch reconcileResult.Resource.(type) {
+			case *apps.Deployment:
 				Expect(reconcileResult.Status.NotAvailable).ToNot(BeNil())
 				Expect(reconcileResult.Status.Progressing).ToNot(BeNil())
 				Expect(reconcileResult.Status.Degraded).ToNot(BeNil())
-			} else {
+			case *routev1.Route:
+				Expect(reconcileResult.Status.NotAvailable).ToNot(BeNil())
+				Expect(reconcileResult.Status.Progressing).ToNot(BeNil())
+				Expect(reconcileResult.Status.Degraded).To(BeNil())
+			default:
 				Expect(reconcileResult.Status.NotAvailable).To(BeNil())
 				Expect(reconcileResult.Status.Progressing).To(BeNil())
 				Expect(reconcileResult.Status.Degraded).To(BeNil())
@@ -164,6 +180,8 @@ var _ = Describe("VM Console Proxy Operand", func() {
 			deployment.Status.UnavailableReplicas = 0
 		})
 
+		updateRouteStatus(client.ObjectKeyFromObject(newRoute(namespace, serviceName)), &request, admittedRouteIngress())
+
 		reconcileResults, err = operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
 
@@ -175,6 +193,36 @@ var _ = Describe("VM Console Proxy Operand", func() {
 		}
 	})
 
+	It("should report rejected route as degraded", func() {
+		_, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		updateRouteStatus(client.ObjectKeyFromObject(newRoute(namespace, serviceName)), &request, routev1.RouteIngress{
+			Host:       "vm-console-proxy.example.com",
+			RouterName: "default",
+			Conditions: []routev1.RouteIngressCondition{{
+				Type:    routev1.RouteAdmitted,
+				Status:  core.ConditionFalse,
+				Reason:  "HostAlreadyClaimed",
+				Message: "route already exists",
+			}},
+		})
+
+		reconcileResults, err := operand.Reconcile(&request)
+		Expect(err).ToNot(HaveOccurred())
+
+		var routeResult *common.ReconcileResult
+		for i := range reconcileResults {
+			if _, ok := reconcileResults[i].Resource.(*routev1.Route); ok {
+				routeResult = &reconcileResults[i]
+			}
+		}
+		Expect(routeResult).ToNot(BeNil())
+		Expect(routeResult.Status.NotAvailable).ToNot(BeNil())
+		Expect(routeResult.Status.Degraded).ToNot(BeNil())
+		Expect(*routeResult.Status.Degraded).To(ContainSubstring("HostAlreadyClaimed"))
+	})
+
 	It("should delete resources when enabled annotation is removed", func() {
 		_, err := operand.Reconcile(&request)
 		Expect(err).ToNot(HaveOccurred())
@@ -530,3 +578,21 @@ func updateDeployment(key client.ObjectKey, request *common.Request, updateFunc
 	Expect(request.Client.Update(request.Context, deployment)).ToNot(HaveOccurred())
 	Expect(request.Client.Status().Update(request.Context, deployment)).ToNot(HaveOccurred())
 }
+
+func updateRouteStatus(key client.ObjectKey, request *common.Request, ingress routev1.RouteIngress) {
+	route := &routev1.Route{}
+	Expect(request.Client.Get(request.Context, key, route)).ToNot(HaveOccurred())
+	route.Status.Ingress = []routev1.RouteIngress{ingress}
+	Expect(request.Client.Status().Update(request.Context, route)).ToNot(HaveOccurred())
+}
+
+func admittedRouteIngress() routev1.RouteIngress {
+	return routev1.RouteIngress{
+		Host:       "vm-console-proxy.example.com",
+		RouterName: "default",
+		Conditions: []routev1.RouteIngressCondition{{
+			Type:   routev1.RouteAdmitted,
+			Status: core.ConditionTrue,
+		}},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 
 	templatev1 "github.com/openshift/api/template/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -92,6 +93,36 @@ func extractDataSources(templates []templatev1.Template) ([]cdiv1beta1.DataSourc
 	return dataSources, nil
 }
 
+// DataSourceTemplateRefs returns the sorted names of templates referencing each DataSource, keyed by the DataSource name
+func DataSourceTemplateRefs(templates []templatev1.Template) (map[string][]string, error) {
+	refs := map[string][]string{}
+	for i := range templates {
+		template := &templates[i]
+		if len(template.Objects) == 0 {
+			continue
+		}
+
+		usesDataSources, err := vmTemplateUsesSourceRef(template)
+		if err != nil {
+			return nil, err
+		}
+		if !usesDataSources {
+			continue
+		}
+
+		name, exists := findDataSourceName(template)
+		if !exists {
+			continue
+		}
+		refs[name] = append(refs[name], template.Name)
+	}
+
+	for name := range refs {
+		sort.Strings(refs[name])
+	}
+	return refs, nil
+}
+
 func vmTemplateUsesSourceRef(template *templatev1.Template) (bool, error) {
 	vmUnstructured := &unstructured.Unstructured{}
 	err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(template.Objects[0].Raw), 1024).Decode(vmUnstructured)
@@ -64,6 +64,15 @@ var _ = Describe("Template bundle", Ordered, func() {
 		Expect(ds2.Spec.Source.PVC.Name).To(Equal("win10"))
 		Expect(ds2.Spec.Source.PVC.Namespace).To(Equal("kubevirt-os-images"))
 	})
+
+	It("should map DataSources to templates referencing them", func() {
+		refs, err := DataSourceTemplateRefs(testBundle.Templates)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(refs).To(Equal(map[string][]string{
+			"centos-stream8": {"centos-stream8-desktop-large", "centos-stream8-server-medium"},
+			"win10":          {"windows10-desktop-medium"},
+		}))
+	})
 })
 
 func TestTemplateBundle(t *testing.T) {
@@ -17,27 +17,42 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"path"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	rbac "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/yaml"
 
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
 	"kubevirt.io/ssp-operator/controllers"
 	"kubevirt.io/ssp-operator/internal/common"
 	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
+	data_sources "kubevirt.io/ssp-operator/internal/operands/data-sources"
 	"kubevirt.io/ssp-operator/webhooks"
 	// +kubebuilder:scaffold:imports
 )
@@ -64,12 +79,31 @@ const (
 	sdkTLSKey = "tls.key"
 
 	webhookPort = 9443
+
+	defaultCacheSyncTimeout = 2 * time.Minute
+
+	pprofReadHeaderTimeout = 10 * time.Second
+
+	validateDirCommand = "validate-dir"
+	printRBACCommand   = "print-rbac"
+
+	operatorClusterRoleName = "operator-role"
+	leaderElectionRoleName  = "leader-election-role"
 )
 
+func registerMetrics(registry prometheus.Registerer) {
+	registry.MustRegister(common_templates.CommonTemplatesRestored)
+	registry.MustRegister(common_templates.TemplatesInNamespace)
+	registry.MustRegister(data_sources.DataImportCronReady)
+	registry.MustRegister(common.SSPOperatorReconcilingProperly)
+	registry.MustRegister(common.SSPReconcileTotal)
+	registry.MustRegister(common.SSPStartTimeSeconds)
+	common.SSPStartTimeSeconds.SetToCurrentTime()
+}
+
 func runPrometheusServer(metricsAddr string, tlsOptions common.SSPTLSOptions) error {
 	setupLog.Info("Starting Prometheus metrics endpoint server with TLS")
-	metrics.Registry.MustRegister(common_templates.CommonTemplatesRestored)
-	metrics.Registry.MustRegister(common.SSPOperatorReconcilingProperly)
+	registerMetrics(metrics.Registry)
 	handler := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", handler)
@@ -99,6 +133,37 @@ func runPrometheusServer(metricsAddr string, tlsOptions common.SSPTLSOptions) er
 	return nil
 }
 
+// newPprofServer returns a server exposing the runtime profiling endpoints,
+// or nil if profiling is not enabled.
+func newPprofServer(enablePprof bool, pprofAddr string) *http.Server {
+	if !enablePprof {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return &http.Server{
+		Addr:              pprofAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: pprofReadHeaderTimeout,
+	}
+}
+
+func runPprofServer(server *http.Server) {
+	setupLog.Info("Starting pprof server", "address", server.Addr)
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil {
+			setupLog.Error(err, "Failed to start pprof server")
+		}
+	}()
+}
+
 func getWebhookServer(sspTLSOptions common.SSPTLSOptions) *webhook.Server {
 	// If TLSSecurityProfile is empty, we want to return nil so that the default
 	// webhook server configuration is used.
@@ -115,21 +180,63 @@ func getWebhookServer(sspTLSOptions common.SSPTLSOptions) *webhook.Server {
 	return &webhook.Server{Port: webhookPort, TLSMinVersion: sspTLSOptions.MinTLSVersion, TLSOpts: funcs}
 }
 
+func getManagerOptions(probeAddr string, enableLeaderElection bool, cacheSyncTimeout time.Duration, tlsOptions common.SSPTLSOptions) (ctrl.Options, error) {
+	if cacheSyncTimeout <= 0 {
+		return ctrl.Options{}, fmt.Errorf("cache sync timeout must be positive, got %s", cacheSyncTimeout)
+	}
+
+	return ctrl.Options{
+		Scheme:                 common.Scheme,
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       leaderElectionID,
+		Controller: v1alpha1.ControllerConfigurationSpec{
+			CacheSyncTimeout: &cacheSyncTimeout,
+		},
+		// If WebhookServer is set to nil, a default one will be created.
+		WebhookServer: getWebhookServer(tlsOptions),
+	}, nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == validateDirCommand {
+		os.Exit(runValidateDir(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == printRBACCommand {
+		os.Exit(runPrintRBAC(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var cacheSyncTimeout time.Duration
+	var enablePprof bool
+	var pprofAddr string
+	var finalizerName string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8443", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", defaultCacheSyncTimeout,
+		"The time limit for waiting for the controller caches to sync. Must be positive.")
+	flag.BoolVar(&enablePprof, "enable-pprof", false,
+		"Enable the runtime profiling endpoint. It should be used only for debugging.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "localhost:6060", "The address the pprof endpoint binds to.")
+	flag.StringVar(&finalizerName, "finalizer-name", controllers.DefaultFinalizerName,
+		"The finalizer added to the SSP CR. Finalizers added with a previous name are replaced.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if errs := validation.IsQualifiedName(finalizerName); len(errs) > 0 {
+		setupLog.Error(fmt.Errorf("%s", strings.Join(errs, ", ")), "Invalid finalizer name", "finalizer", finalizerName)
+		os.Exit(1)
+	}
+
 	err := createCertificateSymlinks()
 	if err != nil {
 		setupLog.Error(err, "Error creating certificate symlinks")
@@ -150,16 +257,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 common.Scheme,
-		MetricsBindAddress:     "0",
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       leaderElectionID,
-		// If WebhookServer is set to nil, a default one will be created.
-		WebhookServer: getWebhookServer(*tlsOptions),
-	})
+	if pprofServer := newPprofServer(enablePprof, pprofAddr); pprofServer != nil {
+		runPprofServer(pprofServer)
+	}
+
+	managerOptions, err := getManagerOptions(probeAddr, enableLeaderElection, cacheSyncTimeout, *tlsOptions)
+	if err != nil {
+		setupLog.Error(err, "Invalid manager options")
+		os.Exit(1)
+	}
 
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -181,12 +289,132 @@ func main() {
 	}
 
 	// +kubebuilder:scaffold:builder
-	if err = controllers.CreateAndStartReconciler(ctx, mgr); err != nil {
+	if err = controllers.CreateAndStartReconciler(ctx, mgr, finalizerName, getRuntimeFlags()); err != nil {
 		setupLog.Error(err, "unable to create or start controller", "controller", "SSP")
 		os.Exit(1)
 	}
 }
 
+// getRuntimeFlags returns the effective values of all command line flags
+func getRuntimeFlags() map[string]string {
+	runtimeFlags := map[string]string{}
+	flag.VisitAll(func(f *flag.Flag) {
+		runtimeFlags[f.Name] = f.Value.String()
+	})
+	return runtimeFlags
+}
+
+// runValidateDir validates all SSP manifests in a directory and returns the exit code
+func runValidateDir(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(validateDirCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	live := flags.Bool("live", false,
+		"Validate against the cluster from the current kubeconfig. "+
+			"Otherwise, objects referenced by SSPs, like namespaces, are assumed to exist.")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s [--live] <path>\n", validateDirCommand)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	// The validator logs every validated object, which is not useful here
+	ctrl.SetLogger(logr.Discard())
+
+	clientFunc := webhooks.NewOfflineClient
+	if *live {
+		config, err := ctrl.GetConfig()
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to get cluster config: %v\n", err)
+			return 2
+		}
+		apiClient, err := client.New(config, client.Options{Scheme: common.Scheme})
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to create client: %v\n", err)
+			return 2
+		}
+		clientFunc = func(*ssp.SSP) (client.Client, error) {
+			return apiClient, nil
+		}
+	}
+
+	results, err := webhooks.ValidateDir(context.Background(), flags.Arg(0), clientFunc)
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to read directory: %v\n", err)
+		return 2
+	}
+
+	exitCode := 0
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			fmt.Fprintf(stdout, "%s: FAILED: %v\n", result.Path, result.Err)
+			exitCode = 1
+		case result.SSPCount == 0:
+			fmt.Fprintf(stdout, "%s: SKIPPED: no SSP found\n", result.Path)
+		default:
+			fmt.Fprintf(stdout, "%s: OK\n", result.Path)
+		}
+	}
+	return exitCode
+}
+
+// runPrintRBAC prints the ClusterRole and the leader election Role with rules the operator requires
+// and returns the exit code
+func runPrintRBAC(args []string, stdout, stderr io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintf(stderr, "Usage: %s\n", printRBACCommand)
+		return 2
+	}
+
+	rules, err := controllers.RequiredRBACRules()
+	if err != nil {
+		fmt.Fprintf(stderr, "Failed to get RBAC rules: %v\n", err)
+		return 1
+	}
+
+	clusterRole := &rbac.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbac.SchemeGroupVersion.String(),
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: operatorClusterRoleName,
+		},
+		Rules: rules,
+	}
+	role := &rbac.Role{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbac.SchemeGroupVersion.String(),
+			Kind:       "Role",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: leaderElectionRoleName,
+		},
+		Rules: controllers.RequiredLeaderElectionRBACRules(),
+	}
+
+	for i, obj := range []interface{}{clusterRole, role} {
+		output, err := yaml.Marshal(obj)
+		if err != nil {
+			fmt.Fprintf(stderr, "Failed to marshal RBAC: %v\n", err)
+			return 1
+		}
+		if i > 0 {
+			output = append([]byte("---\n"), output...)
+		}
+		if _, err := stdout.Write(output); err != nil {
+			return 1
+		}
+	}
+	return 0
+}
+
 func createCertificateSymlinks() error {
 	olmDir, olmDirErr := os.Stat(olmTLSDir)
 	_, sdkDirErr := os.Stat(sdkTLSDir)
@@ -0,0 +1,234 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+var _ = Describe("Manager options", func() {
+	It("should configure manager with cache sync timeout", func() {
+		const cacheSyncTimeout = 7 * time.Minute
+
+		options, err := getManagerOptions("0", false, cacheSyncTimeout, common.SSPTLSOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		// Avoid API discovery, so the manager can be created without a cluster
+		options.MapperProvider = func(*rest.Config) (meta.RESTMapper, error) {
+			return meta.NewDefaultRESTMapper(nil), nil
+		}
+
+		mgr, err := ctrl.NewManager(&rest.Config{Host: "https://localhost:6443"}, options)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(mgr.GetControllerOptions().CacheSyncTimeout).ToNot(BeNil())
+		Expect(*mgr.GetControllerOptions().CacheSyncTimeout).To(Equal(cacheSyncTimeout))
+	})
+
+	DescribeTable("should fail if cache sync timeout is not positive", func(cacheSyncTimeout time.Duration) {
+		_, err := getManagerOptions("0", false, cacheSyncTimeout, common.SSPTLSOptions{})
+		Expect(err).To(MatchError(ContainSubstring("cache sync timeout must be positive")))
+	},
+		Entry("zero", time.Duration(0)),
+		Entry("negative", -time.Second),
+	)
+})
+
+var _ = Describe("pprof server", func() {
+	It("should not be created when disabled", func() {
+		Expect(newPprofServer(false, "localhost:6060")).To(BeNil())
+	})
+
+	It("should serve profiling endpoints when enabled", func() {
+		server := newPprofServer(true, "localhost:6060")
+		Expect(server).ToNot(BeNil())
+		Expect(server.Addr).To(Equal("localhost:6060"))
+
+		testServer := httptest.NewServer(server.Handler)
+		defer testServer.Close()
+
+		for _, endpoint := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+			response, err := testServer.Client().Get(testServer.URL + endpoint)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Body.Close()).To(Succeed())
+			Expect(response.StatusCode).To(Equal(http.StatusOK), endpoint)
+		}
+	})
+})
+
+var _ = Describe("print-rbac command", func() {
+	// The golden file can be updated by running: go run . print-rbac > testdata/print-rbac.yaml
+	const goldenFile = "testdata/print-rbac.yaml"
+
+	It("should print RBAC matching the golden file", func() {
+		expected, err := os.ReadFile(goldenFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		Expect(runPrintRBAC(nil, stdout, stderr)).To(Equal(0), stderr.String())
+		Expect(stdout.String()).To(Equal(string(expected)),
+			"RBAC rules changed, update the golden file %s", goldenFile)
+	})
+
+	It("should fail with arguments", func() {
+		stdout := &bytes.Buffer{}
+		stderr := &bytes.Buffer{}
+		Expect(runPrintRBAC([]string{"unexpected"}, stdout, stderr)).To(Equal(2))
+		Expect(stdout.String()).To(BeEmpty())
+		Expect(stderr.String()).To(ContainSubstring("Usage: print-rbac"))
+	})
+
+	Context("compared to deployed RBAC", func() {
+		var printedRules map[string][]rbac.PolicyRule
+
+		BeforeEach(func() {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			Expect(runPrintRBAC(nil, stdout, stderr)).To(Equal(0), stderr.String())
+			printedRules = decodeRBACRules(stdout.Bytes())
+			Expect(printedRules).To(HaveKey("ClusterRole"))
+			Expect(printedRules).To(HaveKey("Role"))
+		})
+
+		// config/rbac/role.yaml is generated by controller-gen from the kubebuilder RBAC markers
+		It("should print ClusterRole with the same permissions as generated from RBAC markers", func() {
+			generated, err := os.ReadFile("config/rbac/role.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			generatedRules := decodeRBACRules(generated)
+			Expect(generatedRules).To(HaveKey("ClusterRole"))
+
+			Expect(notAllowedPermissions(generatedRules["ClusterRole"], printedRules["ClusterRole"])).To(BeEmpty(),
+				"printed ClusterRole is missing permissions from RBAC markers")
+			Expect(notAllowedPermissions(printedRules["ClusterRole"], generatedRules["ClusterRole"])).To(BeEmpty(),
+				"printed ClusterRole has permissions without RBAC markers")
+		})
+
+		It("should print Role allowed by the leader election Role", func() {
+			leaderElectionRole, err := os.ReadFile("config/rbac/leader_election_role.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			leaderElectionRules := decodeRBACRules(leaderElectionRole)
+			Expect(leaderElectionRules).To(HaveKey("Role"))
+
+			Expect(notAllowedPermissions(printedRules["Role"], leaderElectionRules["Role"])).To(BeEmpty())
+		})
+	})
+})
+
+// decodeRBACRules decodes rules from a multi-document YAML, keyed by the kind of the document
+func decodeRBACRules(data []byte) map[string][]rbac.PolicyRule {
+	type rbacDocument struct {
+		Kind  string            `json:"kind"`
+		Rules []rbac.PolicyRule `json:"rules"`
+	}
+
+	rules := map[string][]rbac.PolicyRule{}
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 1024)
+	for {
+		document := &rbacDocument{}
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			return rules
+		}
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		rules[document.Kind] = append(rules[document.Kind], document.Rules...)
+	}
+}
+
+// notAllowedPermissions returns the permissions granted by rules, that are not allowed by allowingRules.
+// A wildcard in rules is allowed only by a wildcard in allowingRules.
+func notAllowedPermissions(rules, allowingRules []rbac.PolicyRule) []string {
+	var notAllowed []string
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					if !isAllowed(allowingRules, group, resource, verb) {
+						notAllowed = append(notAllowed, fmt.Sprintf("%s %q/%s", verb, group, resource))
+					}
+				}
+			}
+		}
+	}
+	return notAllowed
+}
+
+func isAllowed(rules []rbac.PolicyRule, group, resource, verb string) bool {
+	for _, rule := range rules {
+		if matchesRuleValue(rule.APIGroups, group) &&
+			matchesRuleValue(rule.Resources, resource) &&
+			matchesRuleValue(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRuleValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == rbac.ResourceAll {
+			return true
+		}
+	}
+	return false
+}
+
+var _ = Describe("Metrics", func() {
+	It("should set start time when registering metrics", func() {
+		before := time.Now().Unix()
+		registry := prometheus.NewRegistry()
+		registerMetrics(registry)
+
+		families, err := registry.Gather()
+		Expect(err).ToNot(HaveOccurred())
+
+		var startTime float64
+		for _, family := range families {
+			if family.GetName() == "kubevirt_ssp_start_time_seconds" {
+				Expect(family.GetMetric()).To(HaveLen(1))
+				startTime = family.GetMetric()[0].GetGauge().GetValue()
+			}
+		}
+		Expect(startTime).To(BeNumerically(">=", before))
+		Expect(startTime).To(BeNumerically("<=", time.Now().Unix()+1))
+	})
+})
+
+func TestOperator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Operator Suite")
+}
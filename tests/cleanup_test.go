@@ -49,7 +49,7 @@ var _ = Describe("Cleanup", func() {
 
 		sspObj := getSsp()
 
-		Expect(apiClient.Delete(ctx, sspObj)).To(Succeed())
+		Expect(forceDeleteSsp(sspObj)).To(Succeed())
 		waitForDeletion(client.ObjectKeyFromObject(sspObj), &ssp.SSP{})
 
 		// Check that all deployed resources were deleted
@@ -1260,6 +1260,64 @@ var _ = Describe("DataSources", func() {
 				Expect(err).ToNot(HaveOccurred(), "unrelated DataImportCron was removed")
 			})
 		})
+
+		// DataImportCrons are suspended by moving the CDI internal next cron time annotation to the future.
+		// These tests fail, if CDI stops respecting the annotation.
+		Context("with next cron time annotation", func() {
+			const suspendedCronName = "test-suspended-cron"
+
+			var cron *cdiv1beta1.DataImportCron
+
+			getLastExecutionTimestamp := func() *metav1.Time {
+				foundCron := &cdiv1beta1.DataImportCron{}
+				Expect(apiClient.Get(ctx, client.ObjectKeyFromObject(cron), foundCron)).To(Succeed())
+				return foundCron.Status.LastExecutionTimestamp
+			}
+
+			BeforeEach(func() {
+				retentionPolicy := cdiv1beta1.DataImportCronRetainNone
+				cronTemplate.Name = suspendedCronName
+				cronTemplate.Namespace = internal.GoldenImagesNamespace
+				cronTemplate.Spec.ManagedDataSource = suspendedCronName
+				cronTemplate.Spec.RetentionPolicy = &retentionPolicy
+
+				dataImportCron := cronTemplate.AsDataImportCron()
+				cron = &dataImportCron
+				cron.Annotations = map[string]string{
+					data_sources.NextCronTimeAnnotation: time.Now().Add(365 * 24 * time.Hour).UTC().Format(time.RFC3339),
+				}
+				for key, value := range commonAnnotations {
+					cron.Annotations[key] = value
+				}
+				Expect(apiClient.Create(ctx, cron)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				err := apiClient.Delete(ctx, cron)
+				if !errors.IsNotFound(err) {
+					Expect(err).ToNot(HaveOccurred(), "Failed to delete DataImportCron")
+				}
+			})
+
+			It("should not check source before the next cron time", func() {
+				Consistently(getLastExecutionTimestamp, time.Minute, 5*time.Second).Should(BeNil(),
+					"CDI checked the source of a suspended DataImportCron")
+			})
+
+			It("should check source after the next cron time is moved to the current time", func() {
+				Eventually(func() error {
+					foundCron := &cdiv1beta1.DataImportCron{}
+					if err := apiClient.Get(ctx, client.ObjectKeyFromObject(cron), foundCron); err != nil {
+						return err
+					}
+					foundCron.Annotations[data_sources.NextCronTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+					return apiClient.Update(ctx, foundCron)
+				}, env.ShortTimeout(), time.Second).Should(Succeed())
+
+				Eventually(getLastExecutionTimestamp, env.Timeout(), time.Second).ShouldNot(BeNil(),
+					"CDI did not check the source of a resumed DataImportCron")
+			})
+		})
 	})
 })
 
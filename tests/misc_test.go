@@ -79,7 +79,7 @@ var _ = Describe("Observed generation", func() {
 		defer watch.Stop()
 
 		sspObj := getSsp()
-		Expect(apiClient.Delete(ctx, sspObj)).ToNot(HaveOccurred())
+		Expect(forceDeleteSsp(sspObj)).ToNot(HaveOccurred())
 
 		// Check for deletion timestamp before the SSP operator notices change
 		err = WatchChangesUntil(watch, func(updatedSsp *ssp.SSP) bool {
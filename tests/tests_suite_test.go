@@ -116,6 +116,8 @@ func (s *newSspStrategy) Init() {
 			Annotations: map[string]string{
 				vm_console_proxy.EnableAnnotation:                  "true",
 				vm_console_proxy.VmConsoleProxyNamespaceAnnotation: s.GetVmConsoleProxyNamespace(),
+				// Tests enable Tekton resources and revert to this SSP afterwards
+				ssp.OrphanResourcesAcknowledgedAnnotation: "true",
 			},
 		},
 		Spec: ssp.SSPSpec{
@@ -149,7 +151,7 @@ func (s *newSspStrategy) Cleanup() {
 	}
 
 	if s.ssp != nil {
-		err := apiClient.Delete(ctx, s.ssp)
+		err := forceDeleteSsp(s.ssp)
 		expectSuccessOrNotFound(err)
 		waitForDeletion(client.ObjectKey{
 			Name:      s.GetName(),
@@ -515,6 +517,20 @@ func waitUntilDeployed() {
 	deploymentTimedOut = false
 }
 
+// forceDeleteSsp sets the force-delete annotation before deleting the SSP CR,
+// because the webhook rejects deletion while the SSP CR owns DataSources.
+func forceDeleteSsp(sspObj *ssp.SSP) error {
+	patch := client.MergeFrom(sspObj.DeepCopy())
+	if sspObj.Annotations == nil {
+		sspObj.Annotations = map[string]string{}
+	}
+	sspObj.Annotations[ssp.ForceDeleteAnnotation] = "true"
+	if err := apiClient.Patch(ctx, sspObj, patch); err != nil {
+		return err
+	}
+	return apiClient.Delete(ctx, sspObj)
+}
+
 func waitForDeletion(key client.ObjectKey, obj client.Object) {
 	EventuallyWithOffset(1, func() bool {
 		err := apiClient.Get(ctx, key, obj)
@@ -98,7 +98,7 @@ var _ = Describe("Validation webhook", func() {
 				strategy.SkipSspUpdateTestsIfNeeded()
 
 				foundSsp := getSsp()
-				Expect(apiClient.Delete(ctx, foundSsp)).ToNot(HaveOccurred())
+				Expect(forceDeleteSsp(foundSsp)).ToNot(HaveOccurred())
 				waitForDeletion(client.ObjectKey{Name: foundSsp.GetName(), Namespace: foundSsp.GetNamespace()}, &ssp.SSP{})
 
 				foundSsp.ObjectMeta = v1.ObjectMeta{
@@ -0,0 +1,87 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal"
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+const (
+	defaultPath = "/mutate-ssp-kubevirt-io-v1beta2-ssp"
+
+	openshiftCommonTemplatesNamespace = "openshift"
+)
+
+// +kubebuilder:webhook:verbs=create,path=/mutate-ssp-kubevirt-io-v1beta2-ssp,mutating=true,failurePolicy=fail,groups=ssp.kubevirt.io,resources=ssps,versions=v1beta2,name=defaulting.ssp.kubevirt.io,admissionReviewVersions=v1,sideEffects=None
+
+type sspDefaulter struct {
+	apiReader         client.Reader
+	operatorNamespace string
+}
+
+var _ admission.CustomDefaulter = &sspDefaulter{}
+
+// Default sets the common templates namespace, if it is empty on a newly created SSP with common templates enabled.
+// Existing SSP objects are never modified.
+func (s *sspDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	sspObj := obj.(*ssp.SSP)
+
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	if sspObj.Spec.CommonTemplates.Namespace != "" || !internal.IsCommonTemplatesEnabled(sspObj) {
+		return nil
+	}
+
+	namespace, err := s.defaultCommonTemplatesNamespace(ctx)
+	if err != nil {
+		return fmt.Errorf("could not determine default namespace for common templates, please try again: %w", err)
+	}
+
+	ssplog.Info("default common templates namespace", "name", sspObj.Name, "namespace", namespace)
+	sspObj.Spec.CommonTemplates.Namespace = namespace
+	return nil
+}
+
+func (s *sspDefaulter) defaultCommonTemplatesNamespace(ctx context.Context) (string, error) {
+	runningOnOpenShift, err := common.RunningOnOpenshift(ctx, s.apiReader)
+	if err != nil {
+		return "", err
+	}
+	if runningOnOpenShift {
+		return openshiftCommonTemplatesNamespace, nil
+	}
+	return s.operatorNamespace, nil
+}
+
+func newSspDefaulter(apiReader client.Reader, operatorNamespace string) *sspDefaulter {
+	return &sspDefaulter{
+		apiReader:         apiReader,
+		operatorNamespace: operatorNamespace,
+	}
+}
@@ -0,0 +1,126 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	osconfv1 "github.com/openshift/api/config/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+var _ = Describe("SSP Defaulting", func() {
+	const (
+		operatorNamespace  = "test-operator-ns"
+		templatesNamespace = "test-templates-ns"
+	)
+
+	var (
+		objects = make([]runtime.Object, 0)
+
+		defaulter admission.CustomDefaulter
+		ctx       context.Context
+		sspObj    *ssp.SSP
+	)
+
+	JustBeforeEach(func() {
+		apiReader := fake.NewClientBuilder().WithScheme(common.Scheme).WithRuntimeObjects(objects...).Build()
+
+		defaulter = newSspDefaulter(apiReader, operatorNamespace)
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+			},
+		})
+	})
+
+	BeforeEach(func() {
+		sspObj = &ssp.SSP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-ssp",
+				Namespace: "test-ns",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		objects = make([]runtime.Object, 0)
+	})
+
+	It("should set operator namespace as common templates namespace", func() {
+		Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+		Expect(sspObj.Spec.CommonTemplates.Namespace).To(Equal(operatorNamespace))
+	})
+
+	It("should preserve explicit common templates namespace", func() {
+		sspObj.Spec.CommonTemplates.Namespace = templatesNamespace
+
+		Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+		Expect(sspObj.Spec.CommonTemplates.Namespace).To(Equal(templatesNamespace))
+	})
+
+	It("should not set common templates namespace when common templates are disabled", func() {
+		sspObj.Spec.CommonTemplates.Enabled = pointer.Bool(false)
+
+		Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+		Expect(sspObj.Spec.CommonTemplates.Namespace).To(BeEmpty())
+	})
+
+	It("should not change common templates namespace on update", func() {
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+			},
+		})
+
+		Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+		Expect(sspObj.Spec.CommonTemplates.Namespace).To(BeEmpty())
+	})
+
+	Context("on OpenShift", func() {
+		BeforeEach(func() {
+			objects = append(objects, &osconfv1.ClusterVersion{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "version",
+				},
+			})
+		})
+
+		It("should set openshift namespace as common templates namespace", func() {
+			Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+			Expect(sspObj.Spec.CommonTemplates.Namespace).To(Equal("openshift"))
+		})
+
+		It("should preserve explicit common templates namespace", func() {
+			sspObj.Spec.CommonTemplates.Namespace = templatesNamespace
+
+			Expect(defaulter.Default(ctx, sspObj)).To(Succeed())
+			Expect(sspObj.Spec.CommonTemplates.Namespace).To(Equal(templatesNamespace))
+		})
+	})
+})
@@ -0,0 +1,112 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	common_instancetypes "kubevirt.io/ssp-operator/internal/operands/common-instancetypes"
+)
+
+// defaultGitRefProbeTimeout is short, because the probe runs during admission
+const defaultGitRefProbeTimeout = 5 * time.Second
+
+// errGitRefNotFound is returned by a gitRefProber, if the repository does not contain the ref
+var errGitRefNotFound = common_instancetypes.ErrGitRefNotFound
+
+// gitRefProber checks if a ref exists in a remote git repository.
+// It returns errGitRefNotFound if the repository was reached, but the ref does not exist.
+// Other errors mean that the check could not be done.
+type gitRefProber interface {
+	ProbeRef(ctx context.Context, repoURL string, ref string) error
+}
+
+// lsRemoteProber uses 'git ls-remote', the same git binary is used by kustomize to fetch remote targets
+type lsRemoteProber struct{}
+
+var _ gitRefProber = lsRemoteProber{}
+
+func (lsRemoteProber) ProbeRef(ctx context.Context, repoURL string, ref string) error {
+	_, err := common_instancetypes.GitLsRemote(ctx, repoURL, ref)
+	return err
+}
+
+// validateCommonInstancetypesURLRef checks that the ref of the commonInstancetypes URL exists,
+// if it is requested by the annotation on the SSP CR. The URL has to be syntactically valid.
+// If the remote repository cannot be reached, or the request is a dry run, only the syntactic validation is used.
+func (s *sspValidator) validateCommonInstancetypesURLRef(ctx context.Context, sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if sspObj.GetAnnotations()[ssp.ValidateInstancetypeURLAnnotation] != "true" || isDryRun(ctx) {
+		return nil
+	}
+	if sspObj.Spec.CommonInstancetypes == nil || sspObj.Spec.CommonInstancetypes.URL == nil {
+		return nil
+	}
+
+	rawURL := *sspObj.Spec.CommonInstancetypes.URL
+	if strings.HasPrefix(rawURL, common_instancetypes.OCIURLPrefix) {
+		// OCI artifacts are referenced by digest, there is no ref to probe
+		return nil
+	}
+	repoURL, ref, err := common_instancetypes.ParseKustomizeGitURL(rawURL)
+	if err != nil {
+		return field.ErrorList{invalidField(fldPath, err.Error())}
+	}
+	if common_instancetypes.IsCommitHash(ref) {
+		ssplog.Info("skipping probe of commonInstancetypes URL, commit hashes cannot be probed", "ref", ref)
+		return nil
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, s.gitRefProbeTimeout)
+	defer cancel()
+
+	err = s.gitRefProber.ProbeRef(probeCtx, repoURL, ref)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errGitRefNotFound):
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("%s is invalid, ref %q does not exist in repository %s", rawURL, ref, repoURL))}
+	default:
+		ssplog.Info("could not probe commonInstancetypes URL, falling back to syntactic validation",
+			"repository", repoURL, "ref", ref, "error", err.Error())
+		return nil
+	}
+}
+
+// validateCommonInstancetypesURLRefChange probes the URL only if the URL or the annotation changed,
+// so unrelated updates of the SSP CR do not access the network.
+func (s *sspValidator) validateCommonInstancetypesURLRefChange(ctx context.Context, oldSsp, newSsp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if getCommonInstancetypesURL(oldSsp) == getCommonInstancetypesURL(newSsp) &&
+		oldSsp.GetAnnotations()[ssp.ValidateInstancetypeURLAnnotation] == newSsp.GetAnnotations()[ssp.ValidateInstancetypeURLAnnotation] {
+		return nil
+	}
+	return s.validateCommonInstancetypesURLRef(ctx, newSsp, fldPath)
+}
+
+func getCommonInstancetypesURL(sspObj *ssp.SSP) string {
+	if sspObj.Spec.CommonInstancetypes == nil {
+		return ""
+	}
+	return pointer.StringDeref(sspObj.Spec.CommonInstancetypes.URL, "")
+}
@@ -0,0 +1,91 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("git ls-remote prober", func() {
+	var repoDir string
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoDir}, args...)...)
+		output, err := cmd.CombinedOutput()
+		ExpectWithOffset(1, err).ToNot(HaveOccurred(), string(output))
+	}
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git is not installed")
+		}
+
+		repoDir = GinkgoT().TempDir()
+		git("init", "--quiet")
+		git("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "--allow-empty", "-m", "initial")
+		git("tag", "v0.2.0")
+	})
+
+	It("should find existing tag", func() {
+		Expect(lsRemoteProber{}.ProbeRef(context.Background(), "file://"+repoDir, "v0.2.0")).To(Succeed())
+	})
+
+	It("should report missing tag", func() {
+		err := lsRemoteProber{}.ProbeRef(context.Background(), "file://"+repoDir, "v0.3.0")
+		Expect(err).To(MatchError(errGitRefNotFound))
+	})
+
+	It("should report other error when repository does not exist", func() {
+		err := lsRemoteProber{}.ProbeRef(context.Background(), "file://"+repoDir+"/nonexisting", "v0.2.0")
+		Expect(err).To(HaveOccurred())
+		Expect(err).ToNot(MatchError(errGitRefNotFound))
+	})
+
+	It("should not hang when remote does not respond", func() {
+		// The fake ssh command starts a background process, that keeps the output pipe open
+		// after the command itself is killed.
+		setEnv("GIT_SSH_COMMAND", "sleep 60 & sleep 60; true")
+		setEnv("GIT_SSH_VARIANT", "simple")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := lsRemoteProber{}.ProbeRef(ctx, "ssh://git@example.com/org/repo.git", "v0.2.0")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+	})
+})
+
+// setEnv sets the environment variable for the current test and restores it afterwards
+func setEnv(key, value string) {
+	oldValue, wasSet := os.LookupEnv(key)
+	ExpectWithOffset(1, os.Setenv(key, value)).To(Succeed())
+	DeferCleanup(func() {
+		if wasSet {
+			Expect(os.Setenv(key, oldValue)).To(Succeed())
+		} else {
+			Expect(os.Unsetenv(key)).To(Succeed())
+		}
+	})
+}
@@ -0,0 +1,66 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxScheduleRuns is the number of consecutive runs checked for the shortest interval of a schedule.
+// It covers two days of a schedule running every minute.
+const maxScheduleRuns = 2*24*60 + 1
+
+// parseCronSchedule parses a DataImportCron schedule the same way as CDI does.
+func parseCronSchedule(schedule string) (cron.Schedule, error) {
+	// The parser panics on a time zone without schedule
+	if strings.HasPrefix(schedule, "TZ=") || strings.HasPrefix(schedule, "CRON_TZ=") {
+		if !strings.Contains(schedule, " ") {
+			return nil, fmt.Errorf("missing schedule after time zone")
+		}
+	}
+	return cron.ParseStandard(schedule)
+}
+
+// scheduleMinInterval returns the shortest time between two consecutive runs of the schedule
+// after the given time. Only a limited number of runs is checked, so for schedules running
+// on a few days of the year, the result may be longer than the real shortest interval.
+// If the schedule does not run at least twice, false is returned.
+func scheduleMinInterval(schedule cron.Schedule, from time.Time) (time.Duration, bool) {
+	var minInterval time.Duration
+	found := false
+	previous := schedule.Next(from)
+	for i := 1; i < maxScheduleRuns && !previous.IsZero(); i++ {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if interval := next.Sub(previous); !found || interval < minInterval {
+			minInterval = interval
+			found = true
+		}
+		// Cron expressions cannot run more often than once a minute
+		if minInterval <= time.Minute {
+			break
+		}
+		previous = next
+	}
+	return minInterval, found
+}
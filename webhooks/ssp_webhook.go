@@ -18,36 +18,92 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
+	cdiv1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/controller-lifecycle-operator-sdk/api"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	sspv1beta3 "kubevirt.io/ssp-operator/api/v1beta3"
+	"kubevirt.io/ssp-operator/internal"
+	"kubevirt.io/ssp-operator/internal/common"
+	common_instancetypes "kubevirt.io/ssp-operator/internal/operands/common-instancetypes"
+	common_templates "kubevirt.io/ssp-operator/internal/operands/common-templates"
+	template_validator "kubevirt.io/ssp-operator/internal/operands/template-validator"
 )
 
 var ssplog = logf.Log.WithName("ssp-resource")
 
+const (
+	validatePath = "/validate-ssp-kubevirt-io-v1beta2-ssp"
+	convertPath  = "/convert"
+)
+
 func Setup(mgr ctrl.Manager) error {
-	return ctrl.NewWebhookManagedBy(mgr).
-		For(&ssp.SSP{}).
-		WithValidator(newSspValidator(mgr.GetClient())).
-		Complete()
+	// The handler is registered directly, instead of using ctrl.NewWebhookManagedBy(),
+	// so that the responses can contain warnings.
+	mgr.GetWebhookServer().Register(validatePath, &webhook.Admission{
+		Handler: newWarningHandler(newSspValidator(mgr.GetClient(), mgr.GetAPIReader())),
+	})
+
+	operatorNamespace, err := common.GetOperatorNamespace(ssplog)
+	if err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(defaultPath,
+		admission.WithCustomDefaulter(&ssp.SSP{}, newSspDefaulter(mgr.GetAPIReader(), operatorNamespace)))
+
+	// The conversion webhook converts SSP objects between v1beta2, which is the storage version, and v1beta1 or v1beta3
+	mgr.GetWebhookServer().Register(convertPath, &conversion.Webhook{})
+	return nil
 }
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-ssp-kubevirt-io-v1beta2-ssp,mutating=false,failurePolicy=fail,groups=ssp.kubevirt.io,resources=ssps,versions=v1beta1;v1beta2,name=validation.ssp.kubevirt.io,admissionReviewVersions=v1,sideEffects=None
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cdi.kubevirt.io,resources=storageprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-ssp-kubevirt-io-v1beta2-ssp,mutating=false,failurePolicy=fail,groups=ssp.kubevirt.io,resources=ssps,versions=v1beta1;v1beta2,name=validation.ssp.kubevirt.io,admissionReviewVersions=v1,sideEffects=None
 
 type sspValidator struct {
 	apiClient client.Client
+	// uncachedReader is used to list existing SSP CRs, because the cache can be stale
+	// when multiple SSP CRs are created at the same time
+	uncachedReader client.Reader
+
+	gitRefProber       gitRefProber
+	gitRefProbeTimeout time.Duration
+
+	// fipsEnabled returns true if the cluster runs in FIPS mode
+	fipsEnabled func() (bool, error)
 }
 
 var _ admission.CustomValidator = &sspValidator{}
@@ -55,70 +111,339 @@ var _ admission.CustomValidator = &sspValidator{}
 func (s *sspValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	sspObj := obj.(*ssp.SSP)
 
-	var ssps ssp.SSPList
+	ssplog.Info("validate create", "name", sspObj.Name)
+	if errs := validateAPIVersionAndSize(sspObj); len(errs) > 0 {
+		return invalidSSPError(sspObj, errs)
+	}
 
 	// Check if no other SSP resources are present in the cluster
-	ssplog.Info("validate create", "name", sspObj.Name)
-	err := s.apiClient.List(ctx, &ssps, &client.ListOptions{})
+	dryRun := isDryRun(ctx)
+	if dryRun {
+		ssplog.Info("dry run request, skipping checks that probe the cluster", "name", sspObj.Name)
+	} else {
+		var ssps ssp.SSPList
+		err := s.uncachedReader.List(ctx, &ssps, &client.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("could not list SSPs for validation, please try again: %v", err)
+		}
+		if len(ssps.Items) > 0 {
+			return existingSspsError(ssps.Items)
+		}
+	}
+
+	specPath := field.NewPath("spec")
+	commonTemplatesPath := specPath.Child("commonTemplates")
+
+	var allErrs field.ErrorList
+	namespaceErrs := validateCommonTemplatesNamespace(sspObj, commonTemplatesPath.Child("namespace"))
+	allErrs = append(allErrs, namespaceErrs...)
+
+	// Check if the common templates namespace exists
+	if len(namespaceErrs) == 0 && !dryRun && internal.IsCommonTemplatesEnabled(sspObj) {
+		namespaceName := sspObj.Spec.CommonTemplates.Namespace
+		var namespace v1.Namespace
+		err := s.apiClient.Get(ctx, client.ObjectKey{Name: namespaceName}, &namespace)
+		if err != nil {
+			allErrs = append(allErrs, invalidField(commonTemplatesPath.Child("namespace"),
+				fmt.Sprintf("creation failed, the configured namespace for common templates does not exist: %v", namespaceName)))
+		}
+	}
+
+	allErrs = append(allErrs, validatePinnedTemplateVersion(sspObj, pinnedTemplateVersionPath())...)
+	allErrs = append(allErrs, s.validateSpec(ctx, sspObj, specPath)...)
+
+	// The remote repository is probed only if the URL is valid and allowed by the policy
+	instancetypesErrs := validateCommonInstancetypes(sspObj, specPath.Child("commonInstancetypes"))
+	allErrs = append(allErrs, instancetypesErrs...)
+	if len(instancetypesErrs) == 0 {
+		allErrs = append(allErrs, s.validateCommonInstancetypesURLRef(ctx, sspObj, specPath.Child("commonInstancetypes", "url"))...)
+	}
+
+	return invalidSSPError(sspObj, allErrs)
+}
+
+func (s *sspValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldSsp := oldObj.(*ssp.SSP)
+	newSsp := newObj.(*ssp.SSP)
+
+	ssplog.Info("validate update", "name", newSsp.Name)
+	if errs := validateAPIVersionAndSize(newSsp); len(errs) > 0 {
+		return invalidSSPError(newSsp, errs)
+	}
+
+	specPath := field.NewPath("spec")
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateCommonTemplatesNamespace(newSsp, specPath.Child("commonTemplates", "namespace"))...)
+	allErrs = append(allErrs, validatePinnedTemplateVersionChange(oldSsp, newSsp, pinnedTemplateVersionPath())...)
+	allErrs = append(allErrs, s.validateSpec(ctx, newSsp, specPath)...)
+	allErrs = append(allErrs, s.validateTemplateValidatorReplicasReduction(ctx, oldSsp, newSsp, specPath.Child("templateValidator", "replicas"))...)
+	allErrs = append(allErrs, validateFeatureGatesChange(oldSsp, newSsp, specPath.Child("featureGates"))...)
+
+	// The remote repository is probed only if the URL is valid and allowed by the policy
+	instancetypesErrs := validateCommonInstancetypes(newSsp, specPath.Child("commonInstancetypes"))
+	allErrs = append(allErrs, instancetypesErrs...)
+	if len(instancetypesErrs) == 0 {
+		allErrs = append(allErrs, s.validateCommonInstancetypesURLRefChange(ctx, oldSsp, newSsp, specPath.Child("commonInstancetypes", "url"))...)
+	}
+
+	return invalidSSPError(newSsp, allErrs)
+}
+
+// validateAPIVersionAndSize runs the checks that make the rest of the validation pointless,
+// because the SSP object cannot be fully understood, or is too large to be stored.
+func validateAPIVersionAndSize(sspObj *ssp.SSP) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateAPIVersion(sspObj, field.NewPath("apiVersion"))...)
+	allErrs = append(allErrs, validateSpecSize(sspObj, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// validateSpec runs the validations of the SSP spec, that are shared by create and update requests
+func (s *sspValidator) validateSpec(ctx context.Context, sspObj *ssp.SSP, specPath *field.Path) field.ErrorList {
+	commonTemplatesPath := specPath.Child("commonTemplates")
+	templateValidatorPath := specPath.Child("templateValidator")
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, s.validateCanaryNamespace(ctx, sspObj, commonTemplatesPath.Child("canaryNamespace"))...)
+	allErrs = append(allErrs, s.validateGoldenImagesNamespace(ctx, sspObj, commonTemplatesPath.Child("goldenImagesNamespace"))...)
+	allErrs = append(allErrs, validateAdditionalNamespaces(sspObj, commonTemplatesPath.Child("additionalNamespaces"))...)
+	allErrs = append(allErrs, validateIncludedWorkloads(sspObj, commonTemplatesPath.Child("includedWorkloads"))...)
+	allErrs = append(allErrs, validateDataImportCronTemplates(sspObj, commonTemplatesPath.Child("dataImportCronTemplates"))...)
+
+	allErrs = append(allErrs, s.validatePlacement(ctx, sspObj, templateValidatorPath.Child("placement"))...)
+	allErrs = append(allErrs, s.validateTemplateValidatorReplicas(ctx, sspObj, templateValidatorPath.Child("replicas"))...)
+	allErrs = append(allErrs, validateTemplateValidatorMatchPolicy(sspObj, templateValidatorPath.Child("matchPolicy"))...)
+	allErrs = append(allErrs, validateTemplateValidatorSideEffects(sspObj, templateValidatorPath.Child("sideEffects"))...)
+	allErrs = append(allErrs, validateTemplateValidatorSidecars(sspObj, templateValidatorPath.Child("sidecars"))...)
+	allErrs = append(allErrs, validateTemplateValidatorImage(sspObj, templateValidatorPath.Child("image"))...)
+
+	allErrs = append(allErrs, s.validatePriorityClass(ctx, sspObj, specPath.Child("priorityClassName"))...)
+	allErrs = append(allErrs, s.validateTLSSecurityProfile(sspObj, specPath.Child("tlsSecurityProfile"))...)
+	allErrs = append(allErrs, validateImageRegistryOverride(sspObj, specPath.Child("imageRegistryOverride"))...)
+	allErrs = append(allErrs, validateManagedByLabelValue(sspObj, specPath.Child("managedByLabelValue"))...)
+	allErrs = append(allErrs, validateCommonMetadata(sspObj, specPath.Child("commonMetadata"))...)
+	allErrs = append(allErrs, s.validateCommonInstancetypesSSHSecret(ctx, sspObj, specPath.Child("commonInstancetypes", "sshSecretName"))...)
+	return allErrs
+}
+
+// invalidSSPError returns an Invalid API error containing all field errors, so clients can map them to the SSP fields.
+// It returns nil if the list is empty.
+func invalidSSPError(sspObj *ssp.SSP, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return errors.NewInvalid(ssp.GroupVersion.WithKind("SSP").GroupKind(), sspObj.Name, allErrs)
+}
+
+// invalidField returns an Invalid field error without the value, because the detail
+// already describes the value, or the value must not be shown, like credentials in URLs.
+func invalidField(fldPath *field.Path, detail string) *field.Error {
+	return &field.Error{
+		Type:     field.ErrorTypeInvalid,
+		Field:    fldPath.String(),
+		BadValue: field.OmitValueType{},
+		Detail:   detail,
+	}
+}
+
+func pinnedTemplateVersionPath() *field.Path {
+	return field.NewPath("metadata", "annotations").Key(ssp.PinTemplateVersionAnnotation)
+}
+
+// ValidateDelete rejects deletion of the SSP CR, while it owns DataSources in the golden images namespace,
+// because they would be removed together with the SSP CR, even if they are used by VMs.
+func (s *sspValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	sspObj := obj.(*ssp.SSP)
+
+	ssplog.Info("validate delete", "name", sspObj.Name)
+	if sspObj.GetAnnotations()[ssp.ForceDeleteAnnotation] == "true" {
+		return nil
+	}
+
+	ownedDataSources, err := s.listOwnedGoldenImagesDataSources(ctx, sspObj)
 	if err != nil {
-		return fmt.Errorf("could not list SSPs for validation, please try again: %v", err)
+		return fmt.Errorf("could not list DataSources for validation, please try again: %w", err)
 	}
-	if len(ssps.Items) > 0 {
-		return fmt.Errorf("creation failed, an SSP CR already exists in namespace %v: %v", ssps.Items[0].ObjectMeta.Namespace, ssps.Items[0].ObjectMeta.Name)
+	if len(ownedDataSources) == 0 {
+		return nil
 	}
 
-	// Check if the common templates namespace exists
-	namespaceName := sspObj.Spec.CommonTemplates.Namespace
-	var namespace v1.Namespace
-	err = s.apiClient.Get(ctx, client.ObjectKey{Name: namespaceName}, &namespace)
+	return fmt.Errorf("deletion failed, the SSP CR owns DataSources in namespace %s: %s. "+
+		"They would be removed with the SSP CR, even if they are used by VMs. "+
+		"To delete the SSP CR anyway, set annotation %s=true on it",
+		internal.GetGoldenImagesNamespace(sspObj), strings.Join(ownedDataSources, ", "), ssp.ForceDeleteAnnotation)
+}
+
+// listOwnedGoldenImagesDataSources returns sorted names of DataSources in the golden images namespace owned by the SSP CR
+func (s *sspValidator) listOwnedGoldenImagesDataSources(ctx context.Context, sspObj *ssp.SSP) ([]string, error) {
+	dataSources := &cdiv1beta1.DataSourceList{}
+	err := s.apiClient.List(ctx, dataSources, client.InNamespace(internal.GetGoldenImagesNamespace(sspObj)))
+	if meta.IsNoMatchError(err) {
+		// CDI is not installed, so there are no DataSources
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("creation failed, the configured namespace for common templates does not exist: %v", namespaceName)
+		return nil, err
 	}
 
-	if err = s.validatePlacement(ctx, sspObj); err != nil {
-		return fmt.Errorf("placement api validation error: %w", err)
+	// The owner annotation contains the kind, which is not set if the object was not decoded from a request
+	owner := sspObj.DeepCopy()
+	owner.SetGroupVersionKind(ssp.GroupVersion.WithKind("SSP"))
+
+	var names []string
+	for i := range dataSources.Items {
+		if common.CheckOwnerAnnotation(&dataSources.Items[i], owner) {
+			names = append(names, dataSources.Items[i].Name)
+		}
 	}
+	sort.Strings(names)
+	return names, nil
+}
 
-	if err := validateDataImportCronTemplates(sspObj); err != nil {
-		return fmt.Errorf("dataImportCronTemplates validation error: %w", err)
+// isDryRun returns true if the admission request in the context is a dry run. Checks that probe
+// the cluster or remote repositories are skipped for dry runs, so server-side apply dry runs
+// used by GitOps tools are fast and predictable. Syntactic checks are still done.
+func isDryRun(ctx context.Context) bool {
+	request, err := admission.RequestFromContext(ctx)
+	return err == nil && pointer.BoolDeref(request.DryRun, false)
+}
+
+// existingSspsError lists all existing SSP CRs, so all of them can be found if more than one
+// was created by concurrent requests.
+func existingSspsError(ssps []ssp.SSP) error {
+	if len(ssps) == 1 {
+		return fmt.Errorf("creation failed, an SSP CR already exists in namespace %v: %v", ssps[0].Namespace, ssps[0].Name)
 	}
 
-	if err := validateCommonInstancetypes(sspObj); err != nil {
-		return fmt.Errorf("commonInstancetypes validation error: %w", err)
+	names := make([]string, 0, len(ssps))
+	for i := range ssps {
+		names = append(names, ssps[i].Namespace+"/"+ssps[i].Name)
 	}
+	sort.Strings(names)
+	return fmt.Errorf("creation failed, %d SSP CRs already exist: %s", len(ssps), strings.Join(names, ", "))
+}
 
-	return nil
+// supportedAPIVersions are the versions of the SSP API known to this operator version
+var supportedAPIVersions = []string{"v1beta1", ssp.GroupVersion.Version, sspv1beta3.GroupVersion.Version}
+
+// validateAPIVersion rejects SSP objects of an API version newer than this operator version supports,
+// which can happen if the CRD was updated without upgrading the operator.
+func validateAPIVersion(sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	version := sspObj.GroupVersionKind().Version
+	if version == "" {
+		return nil
+	}
+	for _, supportedVersion := range supportedAPIVersions {
+		if version == supportedVersion {
+			return nil
+		}
+	}
+	return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+		"SSP apiVersion %s is not supported by SSP operator version %s, supported versions are: %s. "+
+			"Upgrade the SSP operator to use this API version",
+		sspObj.APIVersion, common.GetOperatorVersion(), strings.Join(supportedAPIVersions, ", ")))}
 }
 
-func (s *sspValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) error {
-	newSsp := newObj.(*ssp.SSP)
+// specSizeWarningRatio is the fraction of the maximum spec size, above which a warning is returned
+const specSizeWarningRatio = 0.8
 
-	ssplog.Info("validate update", "name", newSsp.Name)
+func validateSpecSize(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	maxSize, err := common.GetSSPMaxSpecSize()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
 
-	if err := s.validatePlacement(ctx, newSsp); err != nil {
-		return fmt.Errorf("placement api validation error: %w", err)
+	size, err := specSize(ssp)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if size > maxSize {
+		return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf(
+			"the serialized SSP spec has %d bytes, which exceeds the maximum allowed size of %d bytes, "+
+				"consider referencing external configuration instead of inlining large lists like dataImportCronTemplates", size, maxSize))}
 	}
+	return nil
+}
 
-	if err := validateDataImportCronTemplates(newSsp); err != nil {
-		return fmt.Errorf("dataImportCronTemplates validation error: %w", err)
+func specSize(ssp *ssp.SSP) (int64, error) {
+	serializedSpec, err := json.Marshal(ssp.Spec)
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialize SSP spec: %w", err)
 	}
+	return int64(len(serializedSpec)), nil
+}
 
-	if err := validateCommonInstancetypes(newSsp); err != nil {
-		return fmt.Errorf("commonInstancetypes validation error: %w", err)
+func getSpecSizeWarnings(ssp *ssp.SSP) []string {
+	maxSize, err := common.GetSSPMaxSpecSize()
+	if err != nil {
+		return nil
+	}
+	size, err := specSize(ssp)
+	if err != nil {
+		return nil
 	}
+	if float64(size) > specSizeWarningRatio*float64(maxSize) {
+		return []string{fmt.Sprintf("the serialized SSP spec has %d bytes, which is close to the maximum allowed size of %d bytes, "+
+			"consider referencing external configuration instead of inlining large lists like dataImportCronTemplates", size, maxSize)}
+	}
+	return nil
+}
 
+func validateCommonTemplatesNamespace(sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	namespace := sspObj.Spec.CommonTemplates.Namespace
+	if namespace == "" && !internal.IsCommonTemplatesEnabled(sspObj) {
+		// The namespace is not used when common templates are disabled
+		return nil
+	}
+	if namespace == "" {
+		if sspObj.Spec.CommonInstancetypes != nil {
+			return field.ErrorList{field.Required(fldPath, "commonTemplates.namespace must not be empty when commonInstancetypes is configured, "+
+				"it has to be set to the namespace where common templates are deployed")}
+		}
+		return field.ErrorList{field.Required(fldPath, "commonTemplates.namespace must not be empty, it has to be set to the namespace where common templates are deployed")}
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("commonTemplates.namespace %q is not a valid namespace name: %s", namespace, strings.Join(errs, ", ")))}
+	}
 	return nil
 }
 
-func (s *sspValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+// validateGoldenImagesNamespace checks that the configured golden images namespace exists,
+// because it is not created by the operator.
+func (s *sspValidator) validateGoldenImagesNamespace(ctx context.Context, sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	namespaceName := sspObj.Spec.CommonTemplates.GoldenImagesNamespace
+	if internal.IsGoldenImagesNamespaceManaged(sspObj) {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(namespaceName); len(errs) > 0 {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"commonTemplates.goldenImagesNamespace %q is not a valid namespace name: %s", namespaceName, strings.Join(errs, ", ")))}
+	}
+	if isDryRun(ctx) {
+		return nil
+	}
+
+	var namespace v1.Namespace
+	err := s.apiClient.Get(ctx, client.ObjectKey{Name: namespaceName}, &namespace)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("the configured golden images namespace does not exist: %v", namespaceName))}
+	}
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath,
+			fmt.Errorf("could not get the golden images namespace for validation, please try again: %w", err))}
+	}
 	return nil
 }
 
-func (s *sspValidator) validatePlacement(ctx context.Context, ssp *ssp.SSP) error {
-	if ssp.Spec.TemplateValidator == nil {
+func (s *sspValidator) validatePlacement(ctx context.Context, ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.TemplateValidator == nil || isDryRun(ctx) {
 		return nil
 	}
-	return s.validateOperandPlacement(ctx, ssp.Namespace, ssp.Spec.TemplateValidator.Placement)
+	if err := s.validateOperandPlacement(ctx, ssp.Namespace, ssp.Spec.TemplateValidator.Placement); err != nil {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("placement api validation error: %v", err))}
+	}
+	return nil
 }
 
 func (s *sspValidator) validateOperandPlacement(ctx context.Context, namespace string, placement *api.NodePlacement) error {
@@ -172,31 +497,978 @@ func (s *sspValidator) validateOperandPlacement(ctx context.Context, namespace s
 	return s.apiClient.Create(ctx, deployment, &client.CreateOptions{DryRun: []string{metav1.DryRunAll}})
 }
 
+// validateTemplateValidatorReplicas rejects more replicas than schedulable nodes, if the placement
+// requires template validator pods to run on different nodes. The default pod anti-affinity
+// of the template validator is only preferred, so it does not limit the number of replicas.
+// The validation is skipped if nodes cannot be listed.
+func (s *sspValidator) validateTemplateValidatorReplicas(ctx context.Context, sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	validatorSpec := sspObj.Spec.TemplateValidator
+	if validatorSpec == nil {
+		return nil
+	}
+	replicas := pointer.Int32Deref(validatorSpec.Replicas, 1)
+	if replicas <= 1 || !requiresPodPerNode(validatorSpec.Placement) || isDryRun(ctx) {
+		return nil
+	}
+
+	nodes := &v1.NodeList{}
+	if err := s.apiClient.List(ctx, nodes); err != nil {
+		ssplog.Info("could not list nodes, skipping validation of template validator replicas", "error", err.Error())
+		return nil
+	}
+
+	schedulableNodes := 0
+	for i := range nodes.Items {
+		if isNodeSchedulable(&nodes.Items[i], validatorSpec.Placement) {
+			schedulableNodes++
+		}
+	}
+	if int(replicas) > schedulableNodes {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"replicas %d can never be scheduled, the placement requires each template validator pod "+
+				"to run on a different node, but only %d nodes are schedulable", replicas, schedulableNodes))}
+	}
+	return nil
+}
+
+// validateTemplateValidatorReplicasReduction rejects reducing the template validator replicas below
+// minAvailable of a PodDisruptionBudget selecting the template validator pods, because it could never be satisfied.
+// A PodDisruptionBudget with maxUnavailable can be satisfied by any number of replicas, so it is not checked.
+// PodDisruptionBudgets are not listed for dry run requests.
+func (s *sspValidator) validateTemplateValidatorReplicasReduction(ctx context.Context, oldSsp, newSsp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	newReplicas := templateValidatorReplicas(newSsp)
+	if newReplicas >= templateValidatorReplicas(oldSsp) || isDryRun(ctx) {
+		return nil
+	}
+
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := s.apiClient.List(ctx, pdbs, client.InNamespace(newSsp.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("could not list PodDisruptionBudgets, please try again: %w", err))}
+	}
+
+	podLabels := labels.Set(template_validator.PodLabels())
+	for i := range pdbs.Items {
+		pdb := &pdbs.Items[i]
+		if pdb.Spec.MinAvailable == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(podLabels) {
+			continue
+		}
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.Spec.MinAvailable, int(newReplicas), true)
+		if err != nil {
+			return field.ErrorList{invalidField(fldPath, fmt.Sprintf("invalid minAvailable of PodDisruptionBudget %s: %v", pdb.Name, err))}
+		}
+		if int(newReplicas) < minAvailable {
+			return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+				"replicas %d are fewer than minAvailable %d of PodDisruptionBudget %s, "+
+					"the PodDisruptionBudget could never be satisfied", newReplicas, minAvailable, pdb.Name))}
+		}
+	}
+	return nil
+}
+
+func templateValidatorReplicas(sspObj *ssp.SSP) int32 {
+	if sspObj.Spec.TemplateValidator == nil {
+		return 1
+	}
+	return pointer.Int32Deref(sspObj.Spec.TemplateValidator.Replicas, 1)
+}
+
+// requiresPodPerNode returns true if the placement contains a required pod anti-affinity,
+// that does not allow two template validator pods on the same node.
+func requiresPodPerNode(placement *api.NodePlacement) bool {
+	if placement == nil || placement.Affinity == nil || placement.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	podLabels := labels.Set(template_validator.PodLabels())
+	for _, term := range placement.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if term.TopologyKey != v1.LabelHostname {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(podLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeSchedulable checks if template validator pods with the placement can be scheduled on the node
+func isNodeSchedulable(node *v1.Node, placement *api.NodePlacement) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if !labels.SelectorFromSet(placement.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !isTaintTolerated(taint, placement.Tolerations) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTaintTolerated(taint *v1.Taint, tolerations []v1.Toleration) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sspValidator) validatePriorityClass(ctx context.Context, ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	priorityClassName := ssp.Spec.PriorityClassName
+	if priorityClassName == "" || isDryRun(ctx) {
+		return nil
+	}
+
+	var priorityClass schedulingv1.PriorityClass
+	err := s.apiClient.Get(ctx, client.ObjectKey{Name: priorityClassName}, &priorityClass)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("the referenced PriorityClass does not exist: %s", priorityClassName))}
+	}
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("could not get PriorityClass %s, please try again: %w", priorityClassName, err))}
+	}
+	return nil
+}
+
+func (s *sspValidator) validateCanaryNamespace(ctx context.Context, ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	canaryNamespace := ssp.Spec.CommonTemplates.CanaryNamespace
+	if canaryNamespace == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(canaryNamespace); len(errs) > 0 {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"commonTemplates.canaryNamespace %q is not a valid namespace name: %s", canaryNamespace, strings.Join(errs, ", ")))}
+	}
+	if canaryNamespace == ssp.Spec.CommonTemplates.Namespace {
+		return field.ErrorList{invalidField(fldPath, "commonTemplates.canaryNamespace must be different from commonTemplates.namespace")}
+	}
+	if isDryRun(ctx) {
+		return nil
+	}
+
+	var namespace v1.Namespace
+	err := s.apiClient.Get(ctx, client.ObjectKey{Name: canaryNamespace}, &namespace)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("the configured canary namespace does not exist: %v", canaryNamespace))}
+	}
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath,
+			fmt.Errorf("could not get the canary namespace for validation, please try again: %w", err))}
+	}
+	return nil
+}
+
+// bundledTemplateVersions returns the common templates versions embedded in the operator.
+// It is a variable, so tests can replace it.
+var bundledTemplateVersions = func() ([]string, error) {
+	return common_templates.BundledVersions(common_templates.BundleDir)
+}
+
+func validatePinnedTemplateVersion(sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	pinnedVersion, isPinned := sspObj.GetAnnotations()[ssp.PinTemplateVersionAnnotation]
+	if !isPinned {
+		return nil
+	}
+
+	versions, err := bundledTemplateVersions()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("could not list embedded common templates versions, please try again: %w", err))}
+	}
+	for _, version := range versions {
+		if version == pinnedVersion {
+			return nil
+		}
+	}
+	return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+		"annotation %s: common templates version %q is not embedded in the operator, available versions are: %s",
+		ssp.PinTemplateVersionAnnotation, pinnedVersion, strings.Join(versions, ", ")))}
+}
+
+// validatePinnedTemplateVersionChange validates the pinned version only if it changed,
+// so the SSP CR can be updated after an operator upgrade removed the pinned version.
+func validatePinnedTemplateVersionChange(oldSsp, newSsp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	oldVersion, oldPinned := oldSsp.GetAnnotations()[ssp.PinTemplateVersionAnnotation]
+	newVersion, newPinned := newSsp.GetAnnotations()[ssp.PinTemplateVersionAnnotation]
+	if oldPinned == newPinned && oldVersion == newVersion {
+		return nil
+	}
+	return validatePinnedTemplateVersion(newSsp, fldPath)
+}
+
+func validateTemplateValidatorMatchPolicy(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.TemplateValidator == nil || ssp.Spec.TemplateValidator.MatchPolicy == nil {
+		return nil
+	}
+
+	switch matchPolicy := admissionv1.MatchPolicyType(*ssp.Spec.TemplateValidator.MatchPolicy); matchPolicy {
+	case admissionv1.Exact, admissionv1.Equivalent:
+		return nil
+	default:
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"invalid matchPolicy %q, allowed values are %q and %q", matchPolicy, admissionv1.Exact, admissionv1.Equivalent))}
+	}
+}
+
+// validateTemplateValidatorSideEffects allows only values accepted by the admissionregistration.k8s.io/v1 API
+func validateTemplateValidatorSideEffects(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.TemplateValidator == nil || ssp.Spec.TemplateValidator.SideEffects == nil {
+		return nil
+	}
+
+	switch sideEffects := admissionv1.SideEffectClass(*ssp.Spec.TemplateValidator.SideEffects); sideEffects {
+	case admissionv1.SideEffectClassNone, admissionv1.SideEffectClassNoneOnDryRun:
+		return nil
+	default:
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("invalid sideEffects %q, allowed values are %q and %q",
+			sideEffects, admissionv1.SideEffectClassNone, admissionv1.SideEffectClassNoneOnDryRun))}
+	}
+}
+
+func validateTemplateValidatorSidecars(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.TemplateValidator == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	names := map[string]struct{}{}
+	for i, sidecar := range ssp.Spec.TemplateValidator.Sidecars {
+		namePath := fldPath.Index(i).Child("name")
+		if sidecar.Name == template_validator.ContainerName {
+			allErrs = append(allErrs, invalidField(namePath, fmt.Sprintf("sidecar container name %q is reserved", sidecar.Name)))
+			continue
+		}
+		if _, exists := names[sidecar.Name]; exists {
+			allErrs = append(allErrs, invalidField(namePath, fmt.Sprintf("duplicate sidecar container name %q", sidecar.Name)))
+			continue
+		}
+		names[sidecar.Name] = struct{}{}
+	}
+	return allErrs
+}
+
+func validateTemplateValidatorImage(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.TemplateValidator == nil || ssp.Spec.TemplateValidator.Image == "" {
+		return nil
+	}
+	if err := common.ValidateImageReference(ssp.Spec.TemplateValidator.Image); err != nil {
+		return field.ErrorList{invalidField(fldPath, err.Error())}
+	}
+	return nil
+}
+
+// validateTLSSecurityProfile rejects TLS profiles with ciphers that cannot be used in FIPS mode,
+// because the operator and template validator pods would fail to start. It is a no-op on non-FIPS clusters.
+func (s *sspValidator) validateTLSSecurityProfile(sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	nonFIPSCiphers := common.NonFIPSCiphers(sspObj.Spec.TLSSecurityProfile)
+	if len(nonFIPSCiphers) == 0 {
+		return nil
+	}
+
+	fipsEnabled, err := s.fipsEnabled()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("could not detect FIPS mode: %w", err))}
+	}
+	if !fipsEnabled {
+		return nil
+	}
+	return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+		"the cluster runs in FIPS mode, but the %s TLS profile selects ciphers not allowed in FIPS mode: %s",
+		sspObj.Spec.TLSSecurityProfile.Type, strings.Join(nonFIPSCiphers, ", ")))}
+}
+
+func validateImageRegistryOverride(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.ImageRegistryOverride == "" {
+		return nil
+	}
+	if err := common.ValidateImageRegistry(ssp.Spec.ImageRegistryOverride); err != nil {
+		return field.ErrorList{invalidField(fldPath, err.Error())}
+	}
+	return nil
+}
+
+func validateManagedByLabelValue(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if errs := validation.IsValidLabelValue(ssp.Spec.ManagedByLabelValue); len(errs) > 0 {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"%q is not a valid label value: %s", ssp.Spec.ManagedByLabelValue, strings.Join(errs, ", ")))}
+	}
+	return nil
+}
+
+// reservedMetadataDomain is the domain of labels and annotations, that are used by KubeVirt components
+const reservedMetadataDomain = "kubevirt.io"
+
+func validateCommonMetadata(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	commonMetadata := ssp.Spec.CommonMetadata
+	if commonMetadata == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for _, key := range sortedKeys(commonMetadata.Labels) {
+		keyPath := fldPath.Child("labels").Key(key)
+		if err := validateCommonMetadataKey("label", key); err != nil {
+			allErrs = append(allErrs, invalidField(keyPath, err.Error()))
+			continue
+		}
+		if errs := validation.IsValidLabelValue(commonMetadata.Labels[key]); len(errs) > 0 {
+			allErrs = append(allErrs, invalidField(keyPath, fmt.Sprintf("value of label %s is not valid: %s", key, strings.Join(errs, ", "))))
+		}
+	}
+	for _, key := range sortedKeys(commonMetadata.Annotations) {
+		if err := validateCommonMetadataKey("annotation", key); err != nil {
+			allErrs = append(allErrs, invalidField(fldPath.Child("annotations").Key(key), err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateCommonMetadataKey(kind, key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("%s key %q is not valid: %s", kind, key, strings.Join(errs, ", "))
+	}
+	prefix, _, hasPrefix := strings.Cut(key, "/")
+	if hasPrefix && (prefix == reservedMetadataDomain || strings.HasSuffix(prefix, "."+reservedMetadataDomain)) {
+		return fmt.Errorf("%s key %q is reserved, keys in the %s domain cannot be used", kind, key, reservedMetadataDomain)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateFeatureGatesChange rejects disabling feature gates, whose deployed resources
+// are not removed by the operator, unless the orphaned resources are acknowledged.
+func validateFeatureGatesChange(oldSsp, newSsp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if newSsp.GetAnnotations()[ssp.OrphanResourcesAcknowledgedAnnotation] == "true" {
+		return nil
+	}
+
+	oldGates := oldSsp.Spec.FeatureGates
+	if oldGates == nil {
+		oldGates = &ssp.FeatureGates{}
+	}
+	newGates := newSsp.Spec.FeatureGates
+	if newGates == nil {
+		newGates = &ssp.FeatureGates{}
+	}
+
+	if oldGates.DeployTektonTaskResources && !newGates.DeployTektonTaskResources {
+		return field.ErrorList{field.Forbidden(fldPath.Child("deployTektonTaskResources"), fmt.Sprintf(
+			"disabling deployTektonTaskResources does not remove the deployed Tekton tasks and pipelines, "+
+				"set the %s annotation to \"true\" to acknowledge that they have to be removed manually",
+			ssp.OrphanResourcesAcknowledgedAnnotation))}
+	}
+	return nil
+}
+
+func validateAdditionalNamespaces(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	maxNamespaces, err := common.GetMaxAdditionalNamespaces()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+
+	count := len(ssp.Spec.CommonTemplates.AdditionalNamespaces)
+	if count > maxNamespaces {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("%d additional namespaces are configured, but at most %d are allowed", count, maxNamespaces))}
+	}
+	return nil
+}
+
+func validateIncludedWorkloads(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	supported := map[string]bool{}
+	for _, workload := range common_templates.SupportedWorkloads {
+		supported[workload] = true
+	}
+
+	var allErrs field.ErrorList
+	seen := map[string]bool{}
+	for i, workload := range ssp.Spec.CommonTemplates.IncludedWorkloads {
+		if !supported[workload] {
+			allErrs = append(allErrs, invalidField(fldPath.Index(i), fmt.Sprintf("workload %q is not supported, supported workloads are: %s",
+				workload, strings.Join(common_templates.SupportedWorkloads, ", "))))
+			continue
+		}
+		// A duplicate does not change the filter, so it is most likely a mistake
+		if seen[workload] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), workload))
+		}
+		seen[workload] = true
+	}
+	return allErrs
+}
+
 // TODO: also validate DataImportCronTemplates in general once CDI exposes its own validation
-func validateDataImportCronTemplates(ssp *ssp.SSP) error {
-	for _, cron := range ssp.Spec.CommonTemplates.DataImportCronTemplates {
+func validateDataImportCronTemplates(sspObj *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	minInterval, err := common.GetDataImportCronMinInterval()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	requireDigest, err := common.GetRequireImageDigest()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	maxStorage, err := common.GetMaxDataImportCronStorage()
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+
+	var allErrs field.ErrorList
+	goldenImagesNamespace := internal.GetGoldenImagesNamespace(sspObj)
+	for i := range sspObj.Spec.CommonTemplates.DataImportCronTemplates {
+		cron := &sspObj.Spec.CommonTemplates.DataImportCronTemplates[i]
+		cronPath := fldPath.Index(i)
+		dvSpecPath := cronPath.Child("spec", "template", "spec")
+
 		if cron.Name == "" {
-			return fmt.Errorf("missing name in DataImportCronTemplate")
+			allErrs = append(allErrs, field.Required(cronPath.Child("metadata", "name"), "missing name in DataImportCronTemplate"))
+			continue
+		}
+		if err := validateDataImportCronSchedule(cron.Name, cron.Spec.Schedule, minInterval); err != nil {
+			allErrs = append(allErrs, invalidField(cronPath.Child("spec", "schedule"), err.Error()))
 		}
+		if err := validateDataImportCronSource(cron); err != nil {
+			allErrs = append(allErrs, invalidField(cronPath.Child("source"), err.Error()))
+		}
+		if err := validateDataImportCronSourceRef(cron, goldenImagesNamespace); err != nil {
+			allErrs = append(allErrs, invalidField(dvSpecPath.Child("sourceRef"), err.Error()))
+		}
+		if err := validateDataImportCronEnableAnnotation(cron); err != nil {
+			allErrs = append(allErrs, invalidField(
+				cronPath.Child("metadata", "annotations").Key(ssp.DataImportCronTemplateEnableAnnotation), err.Error()))
+		}
+		if requireDigest {
+			if err := validateDataImportCronImageDigest(cron); err != nil {
+				sourcePath := dvSpecPath.Child("source", "registry")
+				if cron.Source != nil {
+					sourcePath = cronPath.Child("source", "url")
+				}
+				allErrs = append(allErrs, invalidField(sourcePath, err.Error()))
+			}
+		}
+		if maxStorage != nil {
+			if err := validateDataImportCronStorageRequest(cron, maxStorage); err != nil {
+				storagePath := dvSpecPath.Child("storage")
+				if cron.Spec.Template.Spec.Storage == nil {
+					storagePath = dvSpecPath.Child("pvc")
+				}
+				allErrs = append(allErrs, invalidField(storagePath.Child("resources", "requests").Key(string(v1.ResourceStorage)), err.Error()))
+			}
+		}
+	}
+	allErrs = append(allErrs, validateDataImportCronNames(sspObj.Spec.CommonTemplates.DataImportCronTemplates, goldenImagesNamespace, fldPath)...)
+	allErrs = append(allErrs, validateDataImportCronManagedDataSources(sspObj.Spec.CommonTemplates.DataImportCronTemplates, goldenImagesNamespace, fldPath)...)
+	return allErrs
+}
+
+// validateDataImportCronNames checks that no two DataImportCronTemplates have the same name
+// in the same namespace, because only one DataImportCron can be created for them.
+func validateDataImportCronNames(crons []ssp.DataImportCronTemplate, goldenImagesNamespace string, fldPath *field.Path) field.ErrorList {
+	seen := make(map[client.ObjectKey]int, len(crons))
+	var duplicates []string
+	var duplicateIndex int
+	for i, cron := range crons {
+		namespace := cron.Namespace
+		if namespace == "" {
+			namespace = goldenImagesNamespace
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: cron.Name}
+		seen[key]++
+		if seen[key] == 2 {
+			if len(duplicates) == 0 {
+				duplicateIndex = i
+			}
+			duplicates = append(duplicates, key.String())
+		}
+	}
+	if len(duplicates) > 0 {
+		return field.ErrorList{invalidField(fldPath.Index(duplicateIndex).Child("metadata", "name"),
+			fmt.Sprintf("DataImportCronTemplate names must be unique, duplicated names: %s", strings.Join(duplicates, ", ")))}
+	}
+	return nil
+}
+
+// validateDataImportCronManagedDataSources checks that no two DataImportCronTemplates
+// manage the same DataSource, because the DataImportCrons would keep overwriting it.
+func validateDataImportCronManagedDataSources(crons []ssp.DataImportCronTemplate, goldenImagesNamespace string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	cronsByDataSource := make(map[client.ObjectKey]string, len(crons))
+	for i, cron := range crons {
+		if cron.Spec.ManagedDataSource == "" {
+			continue
+		}
+		namespace := cron.Namespace
+		if namespace == "" {
+			namespace = goldenImagesNamespace
+		}
+		key := client.ObjectKey{Namespace: namespace, Name: cron.Spec.ManagedDataSource}
+		if otherCron, exists := cronsByDataSource[key]; exists {
+			allErrs = append(allErrs, invalidField(fldPath.Index(i).Child("spec", "managedDataSource"),
+				fmt.Sprintf("DataImportCronTemplates %s and %s manage the same DataSource %s", otherCron, cron.Name, key)))
+			continue
+		}
+		cronsByDataSource[key] = cron.Name
+	}
+	return allErrs
+}
+
+func validateDataImportCronEnableAnnotation(cron *ssp.DataImportCronTemplate) error {
+	value, exists := cron.GetAnnotations()[ssp.DataImportCronTemplateEnableAnnotation]
+	if !exists {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("DataImportCronTemplate %s has invalid value of annotation %s: %q, it must be \"true\" or \"false\"",
+			cron.Name, ssp.DataImportCronTemplateEnableAnnotation, value)
+	}
+	return nil
+}
+
+// validateDataImportCronSource checks that the fields required by the selected source transport are set
+func validateDataImportCronSource(cron *ssp.DataImportCronTemplate) error {
+	source := cron.Source
+	if source == nil {
+		return nil
+	}
+	if cron.Spec.Template.Spec.Source != nil {
+		return fmt.Errorf("DataImportCronTemplate %s must not set both source and spec.template.spec.source", cron.Name)
+	}
+	if source.URL == "" {
+		return fmt.Errorf("missing source URL in DataImportCronTemplate %s", cron.Name)
+	}
+
+	var allowedPrefixes []string
+	switch source.Transport {
+	case ssp.GoldenImageSourceRegistry:
+		allowedPrefixes = []string{
+			cdiv1beta1.RegistrySchemeDocker + "://",
+			cdiv1beta1.RegistrySchemeOci + "://",
+		}
+	default:
+		return fmt.Errorf("unsupported source transport %q in DataImportCronTemplate %s", source.Transport, cron.Name)
+	}
+
+	for _, prefix := range allowedPrefixes {
+		if strings.HasPrefix(source.URL, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("source URL of DataImportCronTemplate %s must start with one of %s for the %s transport",
+		cron.Name, strings.Join(allowedPrefixes, ", "), source.Transport)
+}
+
+// validateDataImportCronSourceRef checks that a DataSource referenced by the DataVolume template
+// is the DataSource managed by the DataImportCron, because it is ambiguous which DataSource is used otherwise.
+func validateDataImportCronSourceRef(cron *ssp.DataImportCronTemplate, goldenImagesNamespace string) error {
+	sourceRef := cron.Spec.Template.Spec.SourceRef
+	if sourceRef == nil || sourceRef.Kind != cdiv1beta1.DataVolumeDataSource || cron.Spec.ManagedDataSource == "" {
+		return nil
+	}
+
+	namespace := cron.Namespace
+	if namespace == "" {
+		namespace = goldenImagesNamespace
+	}
+	managedDataSource := client.ObjectKey{Namespace: namespace, Name: cron.Spec.ManagedDataSource}
+	referencedDataSource := client.ObjectKey{Namespace: namespace, Name: sourceRef.Name}
+	if sourceRef.Namespace != nil && *sourceRef.Namespace != "" {
+		referencedDataSource.Namespace = *sourceRef.Namespace
+	}
+	if referencedDataSource != managedDataSource {
+		return fmt.Errorf("DataImportCronTemplate %s manages DataSource %s, but spec.template.spec.sourceRef "+
+			"references a different DataSource %s", cron.Name, managedDataSource, referencedDataSource)
+	}
+	return nil
+}
+
+// imageDigestRegexp matches a digest reference, for example "@sha256:<hex>"
+var imageDigestRegexp = regexp.MustCompile(`@[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+
+// validateDataImportCronImageDigest checks that a registry source references the image by digest,
+// so the imported image cannot be changed by pushing a different image with the same tag.
+func validateDataImportCronImageDigest(cron *ssp.DataImportCronTemplate) error {
+	dataImportCron := cron.AsDataImportCron()
+	source := dataImportCron.Spec.Template.Spec.Source
+	if source == nil || source.Registry == nil {
+		return nil
+	}
+	registry := source.Registry
+	if registry.ImageStream != nil {
+		return fmt.Errorf("DataImportCronTemplate %s imports image stream %s, but only images referenced by digest are allowed",
+			cron.Name, *registry.ImageStream)
+	}
+	if registry.URL == nil {
+		return nil
+	}
+	imageURL := *registry.URL
+	if strings.HasPrefix(imageURL, cdiv1beta1.RegistrySchemeOci+"://") {
+		// OCI archives are not referenced by tags
+		return nil
+	}
+	if !imageDigestRegexp.MatchString(imageURL) {
+		return fmt.Errorf("DataImportCronTemplate %s imports image %s by tag, but only images referenced by digest are allowed",
+			cron.Name, imageURL)
+	}
+	return nil
+}
+
+// validateDataImportCronStorageRequest checks that the storage requested for the imported image does not exceed the cap
+func validateDataImportCronStorageRequest(cron *ssp.DataImportCronTemplate, maxStorage *resource.Quantity) error {
+	var requests v1.ResourceList
+	dvSpec := &cron.Spec.Template.Spec
+	if dvSpec.Storage != nil {
+		requests = dvSpec.Storage.Resources.Requests
+	} else if dvSpec.PVC != nil {
+		requests = dvSpec.PVC.Resources.Requests
+	}
+
+	storage, ok := requests[v1.ResourceStorage]
+	if ok && storage.Cmp(*maxStorage) > 0 {
+		return fmt.Errorf("DataImportCronTemplate %s requests %s of storage, but at most %s is allowed",
+			cron.Name, storage.String(), maxStorage.String())
 	}
 	return nil
 }
 
-func validateCommonInstancetypes(ssp *ssp.SSP) error {
-	if ssp.Spec.CommonInstancetypes == nil || ssp.Spec.CommonInstancetypes.URL == nil {
+func validateDataImportCronSchedule(cronName string, schedule string, minInterval time.Duration) error {
+	if schedule == "" {
+		return nil
+	}
+
+	parsedSchedule, err := parseCronSchedule(schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q in DataImportCronTemplate %s: %w", schedule, cronName, err)
+	}
+
+	if interval, ok := scheduleMinInterval(parsedSchedule, time.Now()); ok && interval < minInterval {
+		return fmt.Errorf("schedule %q in DataImportCronTemplate %s runs more frequently than the minimum allowed interval of %s",
+			schedule, cronName, minInterval)
+	}
+	return nil
+}
+
+// getWarnings returns problems that do not block the request, but should be reported to the user
+func (s *sspValidator) getWarnings(ctx context.Context, ssp *ssp.SSP) []string {
+	var warnings []string
+	warnings = append(warnings, getSpecSizeWarnings(ssp)...)
+	warnings = append(warnings, s.getDataImportCronTemplatesStorageWarnings(ctx, ssp)...)
+	warnings = append(warnings, getPausedDataImportsWarnings(ssp)...)
+	warnings = append(warnings, getTektonTasksDeprecationWarnings(ssp)...)
+	return warnings
+}
+
+const kubevirtTektonTasksURL = "https://github.com/kubevirt/kubevirt-tekton-tasks"
+
+// getTektonTasksDeprecationWarnings returns a warning for each configuration of the deprecated tekton-tasks operand
+func getTektonTasksDeprecationWarnings(ssp *ssp.SSP) []string {
+	var deprecatedFields []string
+	if ssp.Spec.TektonTasks != nil {
+		deprecatedFields = append(deprecatedFields, "spec.tektonTasks")
+	}
+	if ssp.Spec.FeatureGates != nil && ssp.Spec.FeatureGates.DeployTektonTaskResources {
+		deprecatedFields = append(deprecatedFields, "spec.featureGates.deployTektonTaskResources")
+	}
+
+	var warnings []string
+	for _, deprecatedField := range deprecatedFields {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated and will be removed in a future release, deploy the tasks from %s instead",
+			deprecatedField, kubevirtTektonTasksURL))
+	}
+	return warnings
+}
+
+func getPausedDataImportsWarnings(ssp *ssp.SSP) []string {
+	pauseDataImports := ssp.Spec.CommonTemplates.PauseDataImports
+	if pauseDataImports == nil || !*pauseDataImports {
+		return nil
+	}
+
+	var scheduledCrons []string
+	for _, cron := range ssp.Spec.CommonTemplates.DataImportCronTemplates {
+		if cron.Spec.Schedule != "" {
+			scheduledCrons = append(scheduledCrons, cron.Name)
+		}
+	}
+	if len(scheduledCrons) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("data imports are paused, scheduled imports of DataImportCronTemplates %s will not run until commonTemplates.pauseDataImports is unset",
+		strings.Join(scheduledCrons, ", "))}
+}
+
+func (s *sspValidator) getDataImportCronTemplatesStorageWarnings(ctx context.Context, ssp *ssp.SSP) []string {
+	if len(ssp.Spec.CommonTemplates.DataImportCronTemplates) == 0 || isDryRun(ctx) {
+		return nil
+	}
+
+	var storageProfiles cdiv1beta1.StorageProfileList
+	if err := s.apiClient.List(ctx, &storageProfiles); err != nil {
+		// Warnings are best effort, the StorageProfile CRD may not exist
+		ssplog.Info("could not list StorageProfiles", "error", err.Error())
+		return nil
+	}
+	if len(storageProfiles.Items) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	for _, cron := range ssp.Spec.CommonTemplates.DataImportCronTemplates {
+		volumeMode, accessModes, storageClassName := requestedStorage(&cron.Spec.Template.Spec)
+		if volumeMode == nil && len(accessModes) == 0 {
+			continue
+		}
+
+		supported := false
+		for i := range storageProfiles.Items {
+			profile := &storageProfiles.Items[i]
+			if storageClassName != nil && profile.Name != *storageClassName {
+				continue
+			}
+			if storageProfileSupports(profile, volumeMode, accessModes) {
+				supported = true
+				break
+			}
+		}
+		if supported {
+			continue
+		}
+
+		requested := fmt.Sprintf("accessModes %v", accessModes)
+		if volumeMode != nil {
+			requested = fmt.Sprintf("volumeMode %s and %s", *volumeMode, requested)
+		}
+		if storageClassName != nil {
+			warnings = append(warnings, fmt.Sprintf("DataImportCronTemplate %s requests %s, which are not supported by StorageProfile %s",
+				cron.Name, requested, *storageClassName))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("DataImportCronTemplate %s requests %s, which are not supported by any StorageProfile",
+				cron.Name, requested))
+		}
+	}
+	return warnings
+}
+
+func requestedStorage(dvSpec *cdiv1beta1.DataVolumeSpec) (*v1.PersistentVolumeMode, []v1.PersistentVolumeAccessMode, *string) {
+	if dvSpec.Storage != nil {
+		return dvSpec.Storage.VolumeMode, dvSpec.Storage.AccessModes, dvSpec.Storage.StorageClassName
+	}
+	if dvSpec.PVC != nil {
+		return dvSpec.PVC.VolumeMode, dvSpec.PVC.AccessModes, dvSpec.PVC.StorageClassName
+	}
+	return nil, nil, nil
+}
+
+func storageProfileSupports(profile *cdiv1beta1.StorageProfile, volumeMode *v1.PersistentVolumeMode, accessModes []v1.PersistentVolumeAccessMode) bool {
+	for _, propertySet := range profile.Status.ClaimPropertySets {
+		if volumeMode != nil {
+			profileVolumeMode := v1.PersistentVolumeFilesystem
+			if propertySet.VolumeMode != nil {
+				profileVolumeMode = *propertySet.VolumeMode
+			}
+			if profileVolumeMode != *volumeMode {
+				continue
+			}
+		}
+		if containsAllAccessModes(propertySet.AccessModes, accessModes) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAllAccessModes(available []v1.PersistentVolumeAccessMode, requested []v1.PersistentVolumeAccessMode) bool {
+	for _, accessMode := range requested {
+		found := false
+		for _, availableAccessMode := range available {
+			if availableAccessMode == accessMode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func validateCommonInstancetypes(ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.CommonInstancetypes == nil {
 		return nil
 	}
 
-	url := *ssp.Spec.CommonInstancetypes.URL
+	var allErrs field.ErrorList
+	if defaultPreference := ssp.Spec.CommonInstancetypes.DefaultPreference; defaultPreference != nil {
+		if errs := validation.IsDNS1123Subdomain(defaultPreference.Name); len(errs) > 0 {
+			allErrs = append(allErrs, invalidField(fldPath.Child("defaultPreference", "name"),
+				fmt.Sprintf("defaultPreference name %q is invalid: %s", defaultPreference.Name, strings.Join(errs, ", "))))
+		}
+	}
+
+	if ssp.Spec.CommonInstancetypes.URL == nil {
+		return allErrs
+	}
+	if err := validateCommonInstancetypesURL(*ssp.Spec.CommonInstancetypes.URL); err != nil {
+		allErrs = append(allErrs, invalidField(fldPath.Child("url"), err.Error()))
+	}
+	return allErrs
+}
+
+// validateCommonInstancetypesURL checks that the URL is a supported remote target allowed by the configured policy and ports
+func validateCommonInstancetypesURL(url string) error {
+	// Checked first, so the following errors including the URL cannot leak credentials
+	if err := validateCommonInstancetypesURLCredentials(url); err != nil {
+		return err
+	}
+	if err := validateCommonInstancetypesURLPolicy(url); err != nil {
+		return err
+	}
+	if strings.HasPrefix(url, common_instancetypes.OCIURLPrefix) {
+		if _, err := common_instancetypes.ParseOCIURL(url); err != nil {
+			return err
+		}
+		return validateCommonInstancetypesURLPort(url)
+	}
 	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "ssh://") {
-		return fmt.Errorf("%s is invalid, only https:// or ssh:// are supported as a remote kustomize target for commonInstancetypes", url)
+		return fmt.Errorf("%s is invalid, only https://, ssh:// or oci:// are supported as a remote target for commonInstancetypes", url)
 	}
 	if !strings.Contains(url, "?ref=") && !strings.Contains(url, "?version=") {
 		return fmt.Errorf("%s is invalid, the remote kustomize target for commonInstancetypes must include a static '?ref=$reference' or '?version=$reference'", url)
 	}
+	return validateCommonInstancetypesURLPort(url)
+}
+
+// validateCommonInstancetypesSSHSecret checks that 'ssh://' URLs reference a Secret containing a private key.
+// The Secret is read by the uncached reader, so Secrets are not cached by the operator.
+func (s *sspValidator) validateCommonInstancetypesSSHSecret(ctx context.Context, ssp *ssp.SSP, fldPath *field.Path) field.ErrorList {
+	if ssp.Spec.CommonInstancetypes == nil || ssp.Spec.CommonInstancetypes.URL == nil ||
+		!strings.HasPrefix(*ssp.Spec.CommonInstancetypes.URL, "ssh://") {
+		return nil
+	}
+
+	secretName := ssp.Spec.CommonInstancetypes.SSHSecretName
+	if secretName == "" {
+		return field.ErrorList{field.Required(fldPath, "sshSecretName is required for ssh:// URLs")}
+	}
+	if isDryRun(ctx) {
+		return nil
+	}
+
+	var secret v1.Secret
+	err := s.uncachedReader.Get(ctx, client.ObjectKey{Namespace: ssp.Namespace, Name: secretName}, &secret)
+	if errors.IsNotFound(err) {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf("the referenced Secret does not exist: %s/%s", ssp.Namespace, secretName))}
+	}
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("could not get Secret %s/%s, please try again: %w", ssp.Namespace, secretName, err))}
+	}
+	if len(secret.Data[v1.SSHAuthPrivateKey]) == 0 {
+		return field.ErrorList{invalidField(fldPath, fmt.Sprintf(
+			"the referenced Secret %s/%s does not contain the %s key", ssp.Namespace, secretName, v1.SSHAuthPrivateKey))}
+	}
 	return nil
 }
 
-func newSspValidator(clt client.Client) *sspValidator {
-	return &sspValidator{apiClient: clt}
+// validateCommonInstancetypesURLPolicy checks the URL against the regular expression
+// configured by the INSTANCETYPE_URL_POLICY environment variable.
+func validateCommonInstancetypesURLPolicy(rawURL string) error {
+	policy, err := common.GetInstancetypeURLPolicy()
+	if err != nil {
+		return err
+	}
+	if policy == nil || policy.MatchString(rawURL) {
+		return nil
+	}
+	return fmt.Errorf("%s is not allowed, commonInstancetypes URL must match the policy %q", rawURL, policy.String())
+}
+
+// validateCommonInstancetypesURLPort checks the port of the URL against the allowlist
+// configured by the INSTANCETYPE_URL_ALLOWED_PORTS environment variable.
+// If the URL does not contain a port, the default port of the scheme is checked.
+func validateCommonInstancetypesURLPort(rawURL string) error {
+	allowedPorts, err := common.GetInstancetypeURLAllowedPorts()
+	if err != nil {
+		return err
+	}
+	if len(allowedPorts) == 0 {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		// The parsing error is not included, because it contains the URL
+		return fmt.Errorf("commonInstancetypes URL cannot be parsed")
+	}
+
+	port := defaultURLPorts[parsedURL.Scheme]
+	if portStr := parsedURL.Port(); portStr != "" {
+		port, err = strconv.Atoi(portStr)
+		if err != nil {
+			return fmt.Errorf("commonInstancetypes URL port %q is invalid", portStr)
+		}
+	}
+
+	allowedPortStrings := make([]string, 0, len(allowedPorts))
+	for _, portRange := range allowedPorts {
+		if portRange.Contains(port) {
+			return nil
+		}
+		allowedPortStrings = append(allowedPortStrings, portRange.String())
+	}
+	return fmt.Errorf("commonInstancetypes URL port %d is not allowed, allowed ports are: %s", port, strings.Join(allowedPortStrings, ", "))
+}
+
+var defaultURLPorts = map[string]int{
+	"https": 443,
+	"ssh":   22,
+	"oci":   443,
+}
+
+// validateCommonInstancetypesURLCredentials rejects credentials embedded in the URL,
+// because the SSP CR is not a secret and its content is visible to anyone who can read it.
+// Only a user name without a password is allowed for ssh://, for example "ssh://git@github.com/...".
+func validateCommonInstancetypesURLCredentials(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		// The parsing error is not included, because it contains the URL
+		return fmt.Errorf("commonInstancetypes URL cannot be parsed")
+	}
+	if parsedURL.User == nil {
+		return nil
+	}
+	_, hasPassword := parsedURL.User.Password()
+	if parsedURL.Scheme == "ssh" && !hasPassword {
+		return nil
+	}
+	return fmt.Errorf("commonInstancetypes URL must not contain credentials, " +
+		"use an ssh:// URL with the private key in the Secret referenced by spec.commonInstancetypes.sshSecretName")
+}
+
+func newSspValidator(clt client.Client, uncachedReader client.Reader) *sspValidator {
+	return &sspValidator{
+		apiClient:          clt,
+		uncachedReader:     uncachedReader,
+		gitRefProber:       lsRemoteProber{},
+		gitRefProbeTimeout: defaultGitRefProbeTimeout,
+		fipsEnabled:        common.IsFIPSEnabled,
+	}
 }
@@ -0,0 +1,183 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+// FileValidationResult is the result of validating SSP objects in a single file
+type FileValidationResult struct {
+	Path string
+	// SSPCount is the number of SSP objects found in the file
+	SSPCount int
+	// Err is set if the file cannot be parsed, or if an SSP object in it is not valid
+	Err error
+}
+
+// ClientFunc returns the client used to validate the passed SSP object
+type ClientFunc func(sspObj *ssp.SSP) (client.Client, error)
+
+// ValidateDir validates all SSP objects in YAML files in the directory and its subdirectories,
+// using the same validation as the admission webhook. Results are sorted by file path.
+func ValidateDir(ctx context.Context, dir string, clientFunc ClientFunc) ([]FileValidationResult, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	results := make([]FileValidationResult, 0, len(paths))
+	for _, path := range paths {
+		sspCount, err := validateFile(ctx, path, clientFunc)
+		results = append(results, FileValidationResult{
+			Path:     path,
+			SSPCount: sspCount,
+			Err:      err,
+		})
+	}
+	return results, nil
+}
+
+// NewOfflineClient returns a fake client, which contains the cluster objects referenced
+// by the SSP object. It can be used to validate the SSP object without a cluster.
+func NewOfflineClient(sspObj *ssp.SSP) (client.Client, error) {
+	var objects []client.Object
+	if namespace := sspObj.Spec.CommonTemplates.Namespace; namespace != "" {
+		objects = append(objects, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})
+	}
+	if namespace := sspObj.Spec.CommonTemplates.GoldenImagesNamespace; namespace != "" {
+		objects = append(objects, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})
+	}
+	if priorityClassName := sspObj.Spec.PriorityClassName; priorityClassName != "" {
+		objects = append(objects, &schedulingv1.PriorityClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: priorityClassName,
+			},
+		})
+	}
+	return fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(objects...).Build(), nil
+}
+
+func validateFile(ctx context.Context, path string, clientFunc ClientFunc) (int, error) {
+	sspObjs, err := readSSPs(path)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, sspObj := range sspObjs {
+		apiClient, err := clientFunc(sspObj)
+		if err != nil {
+			return len(sspObjs), err
+		}
+		if err := validateSSP(ctx, apiClient, sspObj); err != nil {
+			return len(sspObjs), fmt.Errorf("SSP %s is not valid: %w", sspObj.GetName(), err)
+		}
+	}
+	return len(sspObjs), nil
+}
+
+func readSSPs(path string) ([]*ssp.SSP, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sspObjs []*ssp.SSP
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(file), 1024)
+	for {
+		obj := &unstructured.Unstructured{}
+		err = decoder.Decode(&obj.Object)
+		if err == io.EOF {
+			return sspObjs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse file: %w", err)
+		}
+
+		gvk := obj.GroupVersionKind()
+		if gvk.Group != ssp.GroupVersion.Group || gvk.Kind != "SSP" {
+			continue
+		}
+		if gvk.Version != ssp.GroupVersion.Version {
+			return nil, fmt.Errorf("unsupported apiVersion %s of SSP %s, only %s is supported",
+				obj.GetAPIVersion(), obj.GetName(), ssp.GroupVersion)
+		}
+
+		sspObj := &ssp.SSP{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sspObj); err != nil {
+			return nil, fmt.Errorf("failed to convert SSP %s: %w", obj.GetName(), err)
+		}
+		sspObjs = append(sspObjs, sspObj)
+	}
+}
+
+// validateSSP validates the SSP object as an update, if it already exists in the cluster.
+// Otherwise, it is validated as a new object.
+func validateSSP(ctx context.Context, apiClient client.Client, sspObj *ssp.SSP) error {
+	validator := newSspValidator(apiClient, apiClient)
+
+	existingSsp := &ssp.SSP{}
+	err := apiClient.Get(ctx, client.ObjectKeyFromObject(sspObj), existingSsp)
+	if err == nil {
+		return validator.ValidateUpdate(ctx, existingSsp, sspObj)
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	return validator.ValidateCreate(ctx, sspObj)
+}
@@ -0,0 +1,149 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+	"kubevirt.io/ssp-operator/internal/common"
+)
+
+var _ = Describe("Validate directory", func() {
+	const fixtureDir = "testdata/validate-dir"
+
+	fixturePath := func(name string) string {
+		return filepath.Join(fixtureDir, name)
+	}
+
+	It("should report results for all YAML files", func() {
+		results, err := ValidateDir(context.Background(), fixtureDir, NewOfflineClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		paths := make([]string, 0, len(results))
+		for _, result := range results {
+			paths = append(paths, result.Path)
+		}
+		Expect(paths).To(Equal([]string{
+			fixturePath("invalid.yaml"),
+			fixturePath("nested/multiple.yml"),
+			fixturePath("not-ssp.yaml"),
+			fixturePath("unsupported-version.yaml"),
+			fixturePath("valid.yaml"),
+		}))
+	})
+
+	It("should validate files", func() {
+		results, err := ValidateDir(context.Background(), fixtureDir, NewOfflineClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		resultsByPath := map[string]FileValidationResult{}
+		for _, result := range results {
+			resultsByPath[result.Path] = result
+		}
+
+		valid := resultsByPath[fixturePath("valid.yaml")]
+		Expect(valid.SSPCount).To(Equal(1))
+		Expect(valid.Err).ToNot(HaveOccurred())
+
+		invalid := resultsByPath[fixturePath("invalid.yaml")]
+		Expect(invalid.SSPCount).To(Equal(1))
+		Expect(invalid.Err).To(MatchError(ContainSubstring("SSP ssp-invalid is not valid")))
+
+		multiple := resultsByPath[fixturePath("nested/multiple.yml")]
+		Expect(multiple.SSPCount).To(Equal(2))
+		Expect(multiple.Err).To(MatchError(ContainSubstring("DataImportCronTemplates cron-a and cron-b manage the same DataSource")))
+
+		notSsp := resultsByPath[fixturePath("not-ssp.yaml")]
+		Expect(notSsp.SSPCount).To(BeZero())
+		Expect(notSsp.Err).ToNot(HaveOccurred())
+
+		unsupported := resultsByPath[fixturePath("unsupported-version.yaml")]
+		Expect(unsupported.Err).To(MatchError(ContainSubstring("unsupported apiVersion ssp.kubevirt.io/v1beta1")))
+	})
+
+	It("should fail if directory does not exist", func() {
+		_, err := ValidateDir(context.Background(), fixturePath("non-existing"), NewOfflineClient)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with live client", func() {
+		var apiClient client.Client
+
+		BeforeEach(func() {
+			apiClient = fake.NewClientBuilder().WithScheme(common.Scheme).WithObjects(
+				&v1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "openshift",
+					},
+				},
+			).Build()
+		})
+
+		liveClient := func(*ssp.SSP) (client.Client, error) {
+			return apiClient, nil
+		}
+
+		It("should fail if referenced priority class does not exist", func() {
+			results, err := ValidateDir(context.Background(), fixtureDir, liveClient)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, result := range results {
+				if result.Path == fixturePath("valid.yaml") {
+					Expect(result.Err).To(MatchError(ContainSubstring("ssp-priority")))
+				}
+			}
+		})
+
+		It("should validate existing SSP as update", func() {
+			Expect(apiClient.Create(context.Background(), &ssp.SSP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ssp-first",
+					Namespace: "kubevirt",
+				},
+				Spec: ssp.SSPSpec{
+					CommonTemplates: ssp.CommonTemplates{
+						Namespace: "openshift",
+					},
+				},
+			})).To(Succeed())
+
+			sspObj := &ssp.SSP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ssp-first",
+					Namespace: "kubevirt",
+				},
+				Spec: ssp.SSPSpec{
+					CommonTemplates: ssp.CommonTemplates{
+						Namespace: "openshift",
+					},
+				},
+			}
+			// Creating a second SSP would fail, because one already exists
+			Expect(validateSSP(context.Background(), apiClient, sspObj)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,69 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ssp "kubevirt.io/ssp-operator/api/v1beta2"
+)
+
+// warningHandler wraps the validating handler and adds warnings to allowed responses.
+// The CustomValidator interface in the used controller-runtime version cannot return warnings.
+type warningHandler struct {
+	handler   admission.Handler
+	validator *sspValidator
+	decoder   *admission.Decoder
+}
+
+var _ admission.Handler = &warningHandler{}
+var _ admission.DecoderInjector = &warningHandler{}
+
+func newWarningHandler(validator *sspValidator) *warningHandler {
+	return &warningHandler{
+		handler:   admission.WithCustomValidator(&ssp.SSP{}, validator).Handler,
+		validator: validator,
+	}
+}
+
+func (h *warningHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	_, err := admission.InjectDecoderInto(d, h.handler)
+	return err
+}
+
+func (h *warningHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	response := h.handler.Handle(ctx, req)
+	if !response.Allowed {
+		return response
+	}
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return response
+	}
+
+	sspObj := &ssp.SSP{}
+	if err := h.decoder.DecodeRaw(req.Object, sspObj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	response.Warnings = append(response.Warnings, h.validator.getWarnings(admission.NewContextWithRequest(ctx, req), sspObj)...)
+	return response
+}